@@ -30,7 +30,23 @@ func main() {
 
 	var servers []mainutil.CancelableFunc
 
-	// TODO: Add metrics server.
+	meter, metricsHandler, err := cfg.Metrics()
+	if err != nil {
+		log().Crit("failed to configure metrics", "error", err)
+		os.Exit(1)
+	}
+
+	if metricsHandler != nil {
+		servers = append(servers, func(ctx context.Context) error {
+			s := &http.Server{
+				Handler: metricsHandler,
+				Addr:    cfg.MetricsServerAddr,
+			}
+
+			log().Info("listening for metrics connections", "addr", s.Addr)
+			return serving.ListenWaitHTTP(ctx, s)
+		})
+	}
 
 	servers = append(servers, func(ctx context.Context) error {
 		var auth middleware.Authenticator
@@ -66,6 +82,11 @@ func main() {
 				return err
 			}
 
+			rc, err := cfg.RunClient()
+			if err != nil {
+				return err
+			}
+
 			vc, err := cfg.VaultTransitClient()
 			if err != nil {
 				return err
@@ -76,16 +97,41 @@ func main() {
 				return err
 			}
 
-			auth = middleware.NewKubernetesAuthenticator(
-				cfg.KubernetesClientFactory,
+			as, err := cfg.ArtifactStorage()
+			if err != nil {
+				return err
+			}
+
+			authOpts := []middleware.KubernetesAuthenticatorOption{
 				middleware.KubernetesAuthenticatorWithKubernetesIntermediary(kc),
+				middleware.KubernetesAuthenticatorWithRunClient(rc),
+				middleware.KubernetesAuthenticatorWithEventDeduplicationWindow(cfg.EventDeduplicationWindow),
+				middleware.KubernetesAuthenticatorWithMeter(meter),
 				middleware.KubernetesAuthenticatorWithLogServiceIntermediary(lc),
+				middleware.KubernetesAuthenticatorWithArtifactStorage(as),
 				middleware.KubernetesAuthenticatorWithChainToVaultTransitIntermediary(vc, cfg.VaultTransitPath, cfg.VaultTransitKey),
 				middleware.KubernetesAuthenticatorWithVaultResolver(cfg.VaultAuthURL, cfg.VaultAuthPath, cfg.VaultAuthRole),
-			)
+			}
+
+			sink, err := cfg.AuditSink()
+			if err != nil {
+				return err
+			} else if sink != nil {
+				authOpts = append(authOpts, middleware.KubernetesAuthenticatorWithAuditSink(sink))
+			}
+
+			switch cfg.SecretsBackend {
+			case opt.SecretsBackendVault:
+			case opt.SecretsBackendKubernetes:
+				authOpts = append(authOpts, middleware.KubernetesAuthenticatorWithKubernetesSecrets(rc))
+			default:
+				return fmt.Errorf("unknown secrets backend %q", cfg.SecretsBackend)
+			}
+
+			auth = middleware.NewKubernetesAuthenticator(cfg.KubernetesClientFactory, authOpts...)
 		}
 
-		var serverOpts []server.Option
+		serverOpts := []server.Option{server.WithMeter(meter)}
 		if cfg.Debug {
 			serverOpts = append(serverOpts, server.WithErrorSensitivity(errawr.ErrorSensitivityAll))
 		}
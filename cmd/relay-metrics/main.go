@@ -7,6 +7,7 @@ import (
 	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
 	"github.com/puppetlabs/relay-core/pkg/metrics/model"
 	"github.com/puppetlabs/relay-core/pkg/metrics/opt"
+	"github.com/puppetlabs/relay-core/pkg/metrics/reconciler/event"
 	"github.com/puppetlabs/relay-core/pkg/metrics/reconciler/run"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
@@ -16,6 +17,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 )
@@ -111,6 +113,9 @@ func main() {
 
 	mgr, err := ctrl.NewManager(kcc, ctrl.Options{
 		Scheme: Scheme,
+		NewCache: cache.BuilderWithOptions(cache.Options{
+			SelectorsByObject: event.SelectorsByObject(),
+		}),
 	})
 	if err != nil {
 		klog.Fatal(err.Error())
@@ -121,6 +126,11 @@ func main() {
 		klog.Fatal(err.Error())
 	}
 
+	err = event.Add(mgr, meter, cfg.EventFilters)
+	if err != nil {
+		klog.Fatal(err.Error())
+	}
+
 	go func() {
 		ticker := time.NewTicker(DefaultWorkflowRunPollingInterval)
 		defer ticker.Stop()
@@ -11,6 +11,7 @@ import (
 
 	vaultapi "github.com/hashicorp/vault/api"
 	"github.com/puppetlabs/leg/instrumentation/alerts"
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/eventctx"
 	"github.com/puppetlabs/leg/storage"
 	_ "github.com/puppetlabs/leg/storage/file"
 	_ "github.com/puppetlabs/leg/storage/gcs"
@@ -40,7 +41,7 @@ func main() {
 	kubeMasterURL := fs.String("kube-master-url", "", "url to the kubernetes master")
 	kubeNamespace := fs.String("kube-namespace", "", "an optional working namespace to restrict to for watching CRDs")
 	imagePullSecret := fs.String("image-pull-secret", "", "the optionally namespaced name of the image pull secret to use for system images")
-	storageAddr := fs.String("storage-addr", "", "the storage URL to upload logs into")
+	storageAddr := fs.String("storage-addr", "", "the storage URL to upload logs into and to read step artifacts from")
 	numWorkers := fs.Int("num-workers", 2, "the number of worker threads to spawn that process Workflow resources")
 	_ = fs.Bool("metrics-enabled", false, "enables the metrics collection and server")
 	_ = fs.String("metrics-server-bind-addr", "localhost:3050", "the host:port to bind the metrics server to")
@@ -50,11 +51,12 @@ func main() {
 	metadataAPIURLStr := fs.String("metadata-api-url", "", "URL to the metadata API")
 	webhookServerPort := fs.Int("webhook-server-port", 443, "the port to listen on for webhook requests")
 	webhookServerKeyDir := fs.String("webhook-server-key-dir", "", "path to a directory containing two files, tls.key and tls.crt, to secure the webhook server")
-	tenantSandboxing := fs.Bool("tenant-sandboxing", false, "enables gVisor sandbox for tenant pods")
+	tenantSandboxing := fs.Bool("tenant-sandboxing", false, "enables gVisor sandbox for tenant pods, overriding the runtime class in the configuration file")
 	tenantSandboxRuntimeClassName := fs.String("tenant-sandbox-runtime-class-name", "runsc", "name of the runtime class providing the gVisor containerd runtime")
 	sentryDSN := fs.String("sentry-dsn", "", "the Sentry DSN to use for error reporting")
 	dynamicRBACBinding := fs.Bool("dynamic-rbac-binding", false, "enable if RBAC rules are set up dynamically for the operator to reduce unhelpful reported errors")
 	runtimeToolsImage := fs.String("runtime-tools-image", model.ToolsImage, "the image to use for the runtime tools")
+	configFile := fs.String("config-file", "", "path to an operator configuration file describing node placement, DNS, sandboxing and network policy labels for tenant pods")
 
 	err := fs.Parse(os.Args[1:])
 	if err != nil {
@@ -85,6 +87,18 @@ func main() {
 		}
 	}
 
+	platformConfig := config.DefaultPlatformConfig()
+	if *configFile != "" {
+		platformConfig, err = config.LoadPlatformConfig(*configFile)
+		if err != nil {
+			log.Fatal("Error reading the -config-file", err)
+		}
+	}
+
+	if *tenantSandboxing {
+		platformConfig.Sandbox.RuntimeClassName = *tenantSandboxRuntimeClassName
+	}
+
 	if *webhookServerKeyDir == "" {
 		log.Fatal("The webhook server key directory -webhook-server-key-dir must be specified")
 	}
@@ -149,6 +163,7 @@ func main() {
 		AlertsDelegate:          alertsDelegate,
 		DynamicRBACBinding:      *dynamicRBACBinding,
 		RuntimeToolsImage:       *runtimeToolsImage,
+		Platform:                platformConfig,
 	}
 
 	dm, err := dependency.NewDependencyManager(cfg, kcc, vc, jwtSigner, blobStore)
@@ -168,9 +183,8 @@ func main() {
 		log.Fatal("Could not add all controllers to operator manager", err)
 	}
 
-	var podEnforcementHandlerOpts []admission.PodEnforcementHandlerOption
-	if *tenantSandboxing {
-		podEnforcementHandlerOpts = append(podEnforcementHandlerOpts, admission.PodEnforcementHandlerWithRuntimeClassName(*tenantSandboxRuntimeClassName))
+	podEnforcementHandlerOpts := []admission.PodEnforcementHandlerOption{
+		admission.PodEnforcementHandlerWithPlatformConfig(platformConfig),
 	}
 	if *standalone {
 		podEnforcementHandlerOpts = append(podEnforcementHandlerOpts, admission.PodEnforcementHandlerWithStandaloneMode(true))
@@ -180,7 +194,7 @@ func main() {
 		Handler: admission.NewPodEnforcementHandler(podEnforcementHandlerOpts...),
 	})
 
-	if err := dm.Manager.Start(signals.SetupSignalHandler()); err != nil {
+	if err := dm.Manager.Start(eventctx.WithEventRecorder(signals.SetupSignalHandler(), dm.Manager, "relay-operator")); err != nil {
 		log.Fatal("Manager exited non-zero", err)
 	}
 }
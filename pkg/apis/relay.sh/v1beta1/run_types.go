@@ -82,6 +82,20 @@ type RunStatus struct {
 	// +listMapKey=name
 	Steps []*StepStatus `json:"steps,omitempty"`
 
+	// Outputs are the values of the outputs declared by the workflow,
+	// available once this run completes.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Outputs []*RunOutput `json:"outputs,omitempty"`
+
+	// Notification is the delivery status of the completion notification for
+	// this run, if the tenant has a notification sink configured.
+	//
+	// +optional
+	Notification *RunNotificationStatus `json:"notification,omitempty"`
+
 	// StartTime is the this run began executing.
 	//
 	// +optional
@@ -100,6 +114,68 @@ type RunStatus struct {
 	Conditions []RunCondition `json:"conditions,omitempty"`
 }
 
+type RunOutput struct {
+	// Name is the name of this output.
+	Name string `json:"name"`
+
+	// Sensitive is whether this output is computed from sensitive or
+	// privileged data. If this output is sensitive, the value will not be set.
+	//
+	// +optional
+	Sensitive bool `json:"sensitive"`
+
+	// Value is the result of evaluating the workflow output expression. It is
+	// not set if the expression could not be fully resolved.
+	//
+	// +optional
+	Value *Unstructured `json:"value,omitempty"`
+
+	// Message explains why the value of this output is not available when
+	// the expression could not be evaluated or fully resolved.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+type RunNotificationState string
+
+const (
+	// RunNotificationPending indicates that the notification has not yet been
+	// delivered, but another attempt will be made.
+	RunNotificationPending RunNotificationState = "Pending"
+
+	// RunNotificationDelivered indicates that the notification sink accepted
+	// the notification.
+	RunNotificationDelivered RunNotificationState = "Delivered"
+
+	// RunNotificationFailed indicates that all delivery attempts have been
+	// exhausted.
+	RunNotificationFailed RunNotificationState = "Failed"
+)
+
+type RunNotificationStatus struct {
+	// State is the current delivery state of the notification.
+	//
+	// +kubebuilder:validation:Enum=Pending;Delivered;Failed
+	State RunNotificationState `json:"state"`
+
+	// Attempts is the number of times delivery has been attempted.
+	//
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// LastAttemptTime is the time of the most recent delivery attempt.
+	//
+	// +optional
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+
+	// Message is a human-readable description of the result of the most
+	// recent delivery attempt.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 // RunList enumerates many Run resources.
 //
 // +kubebuilder:object:root=true
@@ -32,6 +32,23 @@ type StepOutput struct {
 	Value *Unstructured `json:"value"`
 }
 
+type StepArtifact struct {
+	// Name is the name of this artifact.
+	Name string `json:"name"`
+
+	// ContentType is the media type provided when the artifact was uploaded.
+	//
+	// +optional
+	ContentType string `json:"contentType,omitempty"`
+
+	// Size is the size of the artifact in bytes.
+	Size int64 `json:"size"`
+
+	// Digest is the content digest of the artifact, prefixed by the hash
+	// algorithm used, e.g. "sha256:...".
+	Digest string `json:"digest"`
+}
+
 // WhenEvaluationStepMessageSource indicates that a step message came from the
 // runtime processing of its when conditions.
 type WhenEvaluationStepMessageSource struct {
@@ -185,6 +202,13 @@ type StepStatus struct {
 	// +listMapKey=name
 	Outputs []*StepOutput `json:"outputs,omitempty"`
 
+	// Artifacts are each of the files uploaded by this step, if any.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Artifacts []*StepArtifact `json:"artifacts,omitempty"`
+
 	// +optional
 	// +listType=map
 	// +listMapKey=name
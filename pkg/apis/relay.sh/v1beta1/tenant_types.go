@@ -2,6 +2,8 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -10,6 +12,12 @@ import (
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.status.namespace`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Active Runs",type=integer,JSONPath=`.status.runs.active`
+// +kubebuilder:printcolumn:name="Failed Runs",type=integer,JSONPath=`.status.runs.failed`
+// +kubebuilder:printcolumn:name="Ready Triggers",type=integer,JSONPath=`.status.triggers.ready`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type Tenant struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -20,6 +28,23 @@ type Tenant struct {
 }
 
 type TenantSpec struct {
+	// Defaults are applied to every step and trigger attached to this tenant.
+	//
+	// +optional
+	Defaults *TenantDefaults `json:"defaults,omitempty"`
+
+	// LimitRange overrides the default and maximum resources of containers in
+	// the namespace managed by this tenant. Resources that are not specified
+	// keep their built-in values. It has no effect if the tenant does not
+	// manage its namespace.
+	//
+	// For each resource, the default request may not exceed the default, and
+	// neither may exceed the maximum. Otherwise the built-in values are used
+	// and the NamespaceReady condition reports the problem.
+	//
+	// +optional
+	LimitRange *TenantLimitRange `json:"limitRange,omitempty"`
+
 	// NamespaceTemplate defines a template for a namespace that will be created
 	// for this scope. If not specified, resources are created in the namespace
 	// of this resource.
@@ -27,6 +52,40 @@ type TenantSpec struct {
 	// +optional
 	NamespaceTemplate NamespaceTemplate `json:"namespaceTemplate,omitempty"`
 
+	// NetworkPolicy changes the network destinations that runs and triggers
+	// attached to this tenant may connect to.
+	//
+	// +optional
+	NetworkPolicy *TenantNetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// NotificationSink represents the destination for notifications sent when
+	// a run attached to this tenant completes. If not specified, no
+	// notifications are sent.
+	//
+	// +optional
+	NotificationSink NotificationSink `json:"notificationSink,omitempty"`
+
+	// PodSecurity sets the Pod Security Standards level enforced on the
+	// namespace managed by this tenant. If not specified, the namespace is not
+	// labeled. It has no effect if the tenant does not manage its namespace.
+	//
+	// +optional
+	PodSecurity *TenantPodSecurity `json:"podSecurity,omitempty"`
+
+	// Quota limits the total resources used by all runs and triggers in the
+	// namespace managed by this tenant. If not specified, the namespace has no
+	// quota. It has no effect if the tenant does not manage its namespace.
+	//
+	// +optional
+	Quota *TenantQuota `json:"quota,omitempty"`
+
+	// StepServiceAccountNames are the Kubernetes service accounts in the
+	// tenant namespace that steps may run as. Steps that use any other
+	// service account fail validation.
+	//
+	// +optional
+	StepServiceAccountNames []string `json:"stepServiceAccountNames,omitempty"`
+
 	// ToolInjection allows configuration of the PVC to be used for the
 	// container runtime tools.
 	//
@@ -61,6 +120,116 @@ type NamespaceTemplate struct {
 	Metadata metav1.ObjectMeta `json:"metadata,omitempty"`
 }
 
+type TenantDefaults struct {
+	// Env are environment variables to provide to every step and trigger
+	// container. Values may use the same expressions as the environment of a
+	// step, such as !Secret. A variable with the same name in a step or
+	// trigger takes precedence.
+	//
+	// +optional
+	Env UnstructuredObject `json:"env,omitempty"`
+
+	// Labels are added to the pods of every step and trigger. They do not
+	// replace labels set by Relay.
+	//
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are added to the pods of every step and trigger. They do
+	// not replace annotations set by Relay.
+	//
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type TenantLimitRange struct {
+	// Default is the resource limit of containers that do not specify one.
+	//
+	// +optional
+	Default corev1.ResourceList `json:"default,omitempty"`
+
+	// DefaultRequest is the resource request of containers that do not
+	// specify one.
+	//
+	// +optional
+	DefaultRequest corev1.ResourceList `json:"defaultRequest,omitempty"`
+
+	// Max is the largest resource limit a container may have.
+	//
+	// +optional
+	Max corev1.ResourceList `json:"max,omitempty"`
+}
+
+type TenantNetworkPolicy struct {
+	// DenyInternetEgress prevents runs and triggers from connecting to any
+	// address outside the cluster that is not allowed by Egress. DNS queries
+	// to any address on port 53 remain allowed.
+	//
+	// +optional
+	DenyInternetEgress bool `json:"denyInternetEgress,omitempty"`
+
+	// Egress are additional destinations that runs and triggers may connect
+	// to, including private addresses that are otherwise denied.
+	//
+	// +optional
+	Egress []networkingv1.NetworkPolicyEgressRule `json:"egress,omitempty"`
+}
+
+// TenantPodSecurityLevel is a profile defined by the Pod Security Standards.
+type TenantPodSecurityLevel string
+
+const (
+	TenantPodSecurityPrivileged TenantPodSecurityLevel = "privileged"
+	TenantPodSecurityBaseline   TenantPodSecurityLevel = "baseline"
+	TenantPodSecurityRestricted TenantPodSecurityLevel = "restricted"
+)
+
+type TenantPodSecurity struct {
+	// Level is the profile that pods in the namespace must meet. Pods that do
+	// not meet it are rejected, and warnings and audit annotations are added
+	// for them.
+	//
+	// The restricted profile is enforced as baseline, and only warned about
+	// and audited, because the containers that Tekton adds to step pods do not
+	// meet it. Triggers do not meet it either, as this version of Knative does
+	// not allow them to use a seccomp profile or to disallow privilege
+	// escalation.
+	//
+	// +kubebuilder:validation:Enum=privileged;baseline;restricted
+	Level TenantPodSecurityLevel `json:"level"`
+
+	// Version is the Kubernetes minor version of the profile to use, such as
+	// "v1.23". If not specified, the latest version is used.
+	//
+	// +kubebuilder:validation:Pattern=`^(latest|v[0-9]+\.[0-9]+)$`
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
+type TenantQuota struct {
+	// CPU is the total CPU limit of all containers.
+	//
+	// +optional
+	CPU *resource.Quantity `json:"cpu,omitempty"`
+
+	// Memory is the total memory limit of all containers.
+	//
+	// +optional
+	Memory *resource.Quantity `json:"memory,omitempty"`
+
+	// EphemeralStorage is the total ephemeral storage limit of all
+	// containers.
+	//
+	// +optional
+	EphemeralStorage *resource.Quantity `json:"ephemeralStorage,omitempty"`
+
+	// Pods is the number of pods that may exist at once.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Pods *int32 `json:"pods,omitempty"`
+}
+
 type ToolInjection struct {
 	// VolumeClaimTemplate is an optional definition of the PVC that will be
 	// populated and attached to every tenant container.
@@ -69,14 +238,92 @@ type ToolInjection struct {
 	VolumeClaimTemplate *corev1.PersistentVolumeClaim `json:"volumeClaimTemplate,omitempty"`
 }
 
-// TriggerEventSink represents the destination for trigger events. At most one
-// of the fields may be specified at any one given time. If more than one is
-// specified, the behavior is undefined.
+// TriggerEventSink represents the destination for trigger events. Events are
+// either sent to an external service using API, or used to create runs of the
+// workflow selected by WorkflowRef. API and WorkflowRef may not both be
+// specified; if they are, the tenant will not become ready and no runs will be
+// created. Format and CloudEvents only apply to API, and Binding only applies
+// to WorkflowRef.
 type TriggerEventSink struct {
 	// API is an event sink for the propretiary Relay API.
 	//
 	// +optional
 	API *APITriggerEventSink `json:"api,omitempty"`
+
+	// Format is the encoding used to deliver events to the sink. If not
+	// specified, events are sent using the Relay API envelope.
+	//
+	// +kubebuilder:validation:Enum=relay;cloudevents
+	// +optional
+	Format TriggerEventSinkFormat `json:"format,omitempty"`
+
+	// CloudEvents configures how events are encoded when the format is
+	// cloudevents.
+	//
+	// +optional
+	CloudEvents *CloudEventsTriggerEventSinkOptions `json:"cloudEvents,omitempty"`
+
+	// WorkflowRef selects a workflow in the namespace of the tenant to run
+	// for each event. When specified, events are not sent to an external
+	// service; instead, a new run of the workflow is created directly.
+	//
+	// +optional
+	WorkflowRef *corev1.LocalObjectReference `json:"workflowRef,omitempty"`
+
+	// Binding maps event data to the parameters of runs created using
+	// WorkflowRef.
+	//
+	// +optional
+	Binding *TriggerBinding `json:"binding,omitempty"`
+}
+
+type TriggerBinding struct {
+	// Parameters are the values to assign to the parameters of the workflow.
+	// Each value may be any Relay specification expression. The data of the
+	// event is available as the default data source (for example, !Data foo)
+	// and as the event template variable (for example, ${event.foo}).
+	//
+	// +optional
+	Parameters UnstructuredObject `json:"parameters,omitempty"`
+}
+
+type TriggerEventSinkFormat string
+
+const (
+	// TriggerEventSinkFormatRelay sends events using the proprietary Relay API
+	// envelope.
+	TriggerEventSinkFormatRelay TriggerEventSinkFormat = "relay"
+
+	// TriggerEventSinkFormatCloudEvents sends events as CloudEvents 1.0 using
+	// the HTTP protocol binding.
+	TriggerEventSinkFormatCloudEvents TriggerEventSinkFormat = "cloudevents"
+)
+
+type CloudEventsMode string
+
+const (
+	// CloudEventsModeStructured encodes the entire event, including its
+	// attributes, in the request body.
+	CloudEventsModeStructured CloudEventsMode = "structured"
+
+	// CloudEventsModeBinary encodes the event attributes as HTTP headers and
+	// the event data as the request body.
+	CloudEventsModeBinary CloudEventsMode = "binary"
+)
+
+type CloudEventsTriggerEventSinkOptions struct {
+	// Mode is the content mode to use when sending events. If not specified,
+	// structured mode is used.
+	//
+	// +kubebuilder:validation:Enum=structured;binary
+	// +optional
+	Mode CloudEventsMode `json:"mode,omitempty"`
+
+	// DataSchema is a URI identifying the schema that the event data adheres
+	// to. It is sent as the dataschema attribute of each event.
+	//
+	// +optional
+	DataSchema string `json:"dataSchema,omitempty"`
 }
 
 type APITriggerEventSink struct {
@@ -117,6 +364,31 @@ type APIWorkflowExecutionSink struct {
 	TokenFrom *APITokenSource `json:"tokenFrom,omitempty"`
 }
 
+// NotificationSink represents the destination for run notifications. At most
+// one of the fields may be specified at any one given time. If more than one is
+// specified, the behavior is undefined.
+type NotificationSink struct {
+	// Webhook is a notification sink that delivers a signed JSON payload to an
+	// arbitrary HTTP endpoint.
+	//
+	// +optional
+	Webhook *WebhookNotificationSink `json:"webhook,omitempty"`
+}
+
+type WebhookNotificationSink struct {
+	URL string `json:"url"`
+
+	// Token is the secret used to sign notification payloads.
+	//
+	// +optional
+	Token string `json:"token,omitempty"`
+
+	// TokenFrom allows the signing secret to be provided by another resource.
+	//
+	// +optional
+	TokenFrom *APITokenSource `json:"tokenFrom,omitempty"`
+}
+
 type APITokenSource struct {
 	// SecretKeyRef selects an API token by looking up the value in a secret.
 	//
@@ -144,6 +416,29 @@ type TenantStatus struct {
 	// +optional
 	Namespace string `json:"namespace,omitempty"`
 
+	// Quota is the resource usage of the tenant namespace measured against the
+	// tenant quota.
+	//
+	// +optional
+	Quota *TenantQuotaStatus `json:"quota,omitempty"`
+
+	// Sinks reports the health of each sink configured for this tenant.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Sinks []TenantSinkStatus `json:"sinks,omitempty"`
+
+	// Runs counts the runs of the workflows attached to this tenant.
+	//
+	// +optional
+	Runs *TenantRunCounts `json:"runs,omitempty"`
+
+	// Triggers counts the webhook triggers attached to this tenant.
+	//
+	// +optional
+	Triggers *TenantTriggerCounts `json:"triggers,omitempty"`
+
 	// Conditions are the observations of this resource's state.
 	//
 	// +optional
@@ -152,6 +447,88 @@ type TenantStatus struct {
 	Conditions []TenantCondition `json:"conditions,omitempty"`
 }
 
+type TenantQuotaStatus struct {
+	// Hard is the enforced limit for each resource.
+	//
+	// +optional
+	Hard corev1.ResourceList `json:"hard,omitempty"`
+
+	// Used is the current total usage of each resource.
+	//
+	// +optional
+	Used corev1.ResourceList `json:"used,omitempty"`
+}
+
+type TenantSinkType string
+
+const (
+	// TenantSinkTriggerEvent is the trigger event sink.
+	TenantSinkTriggerEvent TenantSinkType = "TriggerEvent"
+
+	// TenantSinkWorkflowExecution is the workflow execution sink.
+	TenantSinkWorkflowExecution TenantSinkType = "WorkflowExecution"
+
+	// TenantSinkNotification is the notification sink.
+	TenantSinkNotification TenantSinkType = "Notification"
+)
+
+type TenantSinkStatus struct {
+	// Type identifies the sink.
+	//
+	// +kubebuilder:validation:Enum=TriggerEvent;WorkflowExecution;Notification
+	Type TenantSinkType `json:"type"`
+
+	// Ready is true if the sink is completely configured, including any
+	// token it reads from a secret.
+	Ready bool `json:"ready"`
+
+	// Message is a human-readable description of the configuration of the
+	// sink.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastDelivery is the result of the most recent delivery to the sink, if
+	// it is known.
+	//
+	// +optional
+	LastDelivery *TenantSinkDelivery `json:"lastDelivery,omitempty"`
+}
+
+type TenantSinkDelivery struct {
+	// Time is when the delivery was attempted.
+	Time metav1.Time `json:"time"`
+
+	// Succeeded is true if the sink accepted the delivery.
+	Succeeded bool `json:"succeeded"`
+
+	// Error is the reason the delivery failed.
+	//
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+type TenantRunCounts struct {
+	// Active is the number of runs that have not completed.
+	Active int32 `json:"active"`
+
+	// Succeeded is the number of runs that completed successfully.
+	Succeeded int32 `json:"succeeded"`
+
+	// Failed is the number of runs that completed unsuccessfully, including
+	// runs that were cancelled.
+	Failed int32 `json:"failed"`
+}
+
+type TenantTriggerCounts struct {
+	// Ready is the number of webhook triggers that can receive requests.
+	Ready int32 `json:"ready"`
+
+	// NotReady is the number of webhook triggers that cannot receive
+	// requests.
+	NotReady int32 `json:"notReady"`
+}
+
 type TenantConditionType string
 
 const (
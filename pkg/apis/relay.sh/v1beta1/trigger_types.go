@@ -2,6 +2,7 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -31,6 +32,183 @@ type WebhookTriggerSpec struct {
 
 	// Container defines the properties of the Docker container to run.
 	Container `json:",inline"`
+
+	// Verification configures how the trigger checks that incoming requests
+	// were signed by the expected sender.
+	//
+	// +optional
+	Verification *WebhookTriggerVerification `json:"verification,omitempty"`
+
+	// Visibility determines whether the webhook can be reached from outside
+	// the cluster. If not specified, the webhook is only available within the
+	// cluster.
+	//
+	// +kubebuilder:validation:Enum=cluster-local;external
+	// +optional
+	Visibility WebhookTriggerVisibility `json:"visibility,omitempty"`
+
+	// Domain configures a custom domain name for the webhook. The webhook is
+	// only reachable using the domain from outside the cluster if its
+	// visibility is external.
+	//
+	// +optional
+	Domain *WebhookTriggerDomain `json:"domain,omitempty"`
+
+	// Scaling configures how the webhook scales in response to request
+	// volume. If not specified, the webhook scales to zero when idle.
+	//
+	// +optional
+	Scaling *WebhookTriggerScaling `json:"scaling,omitempty"`
+
+	// Resources are the compute resources required by the webhook container.
+	//
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Limits restricts the rate and size of the events the webhook may emit.
+	//
+	// +optional
+	Limits *WebhookTriggerLimits `json:"limits,omitempty"`
+}
+
+type WebhookTriggerLimits struct {
+	// RequestsPerSecond is the sustained rate at which the webhook may emit
+	// events. Events emitted faster than this are rejected.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RequestsPerSecond *int32 `json:"requestsPerSecond,omitempty"`
+
+	// Burst is the number of events the webhook may emit at once before the
+	// rate limit applies. It also limits the number of requests each replica
+	// of the webhook handles concurrently. If not specified, it is the same as
+	// the requests per second.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Burst *int32 `json:"burst,omitempty"`
+
+	// MaxBodySize is the largest request body the webhook may send when it
+	// emits an event.
+	//
+	// +optional
+	MaxBodySize *resource.Quantity `json:"maxBodySize,omitempty"`
+}
+
+type WebhookTriggerScaling struct {
+	// MinScale is the minimum number of replicas to keep running. Setting this
+	// to a value greater than zero avoids cold starts.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinScale *int32 `json:"minScale,omitempty"`
+
+	// MaxScale is the maximum number of replicas to run. If not specified or
+	// zero, the number of replicas is not limited.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxScale *int32 `json:"maxScale,omitempty"`
+
+	// TargetConcurrency is the number of concurrent requests each replica
+	// should handle before the webhook is scaled up.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetConcurrency *int32 `json:"targetConcurrency,omitempty"`
+
+	// ScaleDownDelay is the amount of time to wait after request volume
+	// decreases before scaling down. It must not be longer than one hour.
+	//
+	// +optional
+	ScaleDownDelay *metav1.Duration `json:"scaleDownDelay,omitempty"`
+}
+
+type WebhookTriggerVisibility string
+
+const (
+	WebhookTriggerVisibilityClusterLocal WebhookTriggerVisibility = "cluster-local"
+	WebhookTriggerVisibilityExternal     WebhookTriggerVisibility = "external"
+)
+
+type WebhookTriggerDomain struct {
+	// Name is the fully-qualified domain name to route to the webhook.
+	Name string `json:"name"`
+
+	// TLS configures the certificate used to serve the domain. If not
+	// specified, the domain is served using the cluster's default
+	// certificate configuration.
+	//
+	// +optional
+	TLS *WebhookTriggerDomainTLS `json:"tls,omitempty"`
+}
+
+type WebhookTriggerDomainTLS struct {
+	// SecretName is the name of a secret of type kubernetes.io/tls in the
+	// tenant namespace containing the certificate and private key for the
+	// domain.
+	SecretName string `json:"secretName"`
+}
+
+type WebhookTriggerVerificationScheme string
+
+const (
+	// WebhookTriggerVerificationSchemeGitHub checks the HMAC-SHA256 digest in
+	// the X-Hub-Signature-256 header.
+	WebhookTriggerVerificationSchemeGitHub WebhookTriggerVerificationScheme = "github"
+
+	// WebhookTriggerVerificationSchemeGitLab compares the X-Gitlab-Token
+	// header to the secret.
+	WebhookTriggerVerificationSchemeGitLab WebhookTriggerVerificationScheme = "gitlab"
+
+	// WebhookTriggerVerificationSchemeStripe checks the timestamped
+	// HMAC-SHA256 digest in the Stripe-Signature header.
+	WebhookTriggerVerificationSchemeStripe WebhookTriggerVerificationScheme = "stripe"
+
+	// WebhookTriggerVerificationSchemeHMAC checks an HMAC digest of the
+	// request body in an arbitrary header.
+	WebhookTriggerVerificationSchemeHMAC WebhookTriggerVerificationScheme = "hmac"
+)
+
+type WebhookTriggerVerification struct {
+	// Scheme is the signature format used by the sender.
+	//
+	// +kubebuilder:validation:Enum=github;gitlab;stripe;hmac
+	Scheme WebhookTriggerVerificationScheme `json:"scheme"`
+
+	// SecretName is the name of the Relay secret that contains the shared
+	// signing key or token.
+	SecretName string `json:"secretName"`
+
+	// HMAC configures the generic HMAC scheme.
+	//
+	// +optional
+	HMAC *HMACWebhookTriggerVerification `json:"hmac,omitempty"`
+}
+
+type HMACWebhookTriggerVerification struct {
+	// Header is the name of the request header that contains the digest.
+	Header string `json:"header"`
+
+	// Algorithm is the hash function used to compute the digest. If not
+	// specified, sha256 is used.
+	//
+	// +kubebuilder:validation:Enum=sha1;sha256;sha512
+	// +optional
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// Prefix is removed from the header value before the digest is decoded,
+	// for example "sha256=".
+	//
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Encoding is the encoding of the digest in the header. If not specified,
+	// hex is used.
+	//
+	// +kubebuilder:validation:Enum=hex;base64
+	// +optional
+	Encoding string `json:"encoding,omitempty"`
 }
 
 type WebhookTriggerStatus struct {
@@ -46,11 +224,17 @@ type WebhookTriggerStatus struct {
 	// +optional
 	Namespace string `json:"namespace,omitempty"`
 
-	// URL is the endpoint for the webhook once provisioned.
+	// URL is the endpoint for the webhook once provisioned. If a custom
+	// domain is configured, this is the URL using the domain once it is ready.
 	//
 	// +optional
 	URL string `json:"url,omitempty"`
 
+	// Events summarizes the events emitted by the webhook.
+	//
+	// +optional
+	Events *WebhookTriggerEventStatus `json:"events,omitempty"`
+
 	// Conditions are the observations of this resource's tate.
 	//
 	// +optional
@@ -59,6 +243,56 @@ type WebhookTriggerStatus struct {
 	Conditions []WebhookTriggerCondition `json:"conditions,omitempty"`
 }
 
+type WebhookTriggerEventStatus struct {
+	// Accepted is the number of events accepted for delivery to the event
+	// sink of the tenant.
+	//
+	// +optional
+	Accepted int64 `json:"accepted,omitempty"`
+
+	// Rejected is the number of events the event sink refused or that could
+	// not be delivered.
+	//
+	// +optional
+	Rejected int64 `json:"rejected,omitempty"`
+
+	// LastEventTime is the time the webhook most recently emitted an event.
+	//
+	// +optional
+	LastEventTime *metav1.Time `json:"lastEventTime,omitempty"`
+
+	// LastError is the reason the most recently rejected event was not
+	// delivered.
+	//
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+
+	// Recent lists the events most recently emitted by the webhook, newest
+	// first. An event can be sent to the event sink again by annotating the
+	// webhook trigger with relay.sh/replay-event set to its ID.
+	//
+	// +optional
+	Recent []WebhookTriggerRecordedEvent `json:"recent,omitempty"`
+}
+
+type WebhookTriggerRecordedEvent struct {
+	// ID identifies the event for replay.
+	ID string `json:"id"`
+
+	// Time is when the event was emitted.
+	Time metav1.Time `json:"time"`
+
+	// Outcome is the result of delivering the event to the event sink.
+	//
+	// +kubebuilder:validation:Enum=delivered;pending;rejected
+	Outcome string `json:"outcome"`
+
+	// Error is the reason the event has not been delivered, if any.
+	//
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
 type WebhookTriggerConditionType string
 
 const (
@@ -32,6 +32,13 @@ type WorkflowSpec struct {
 	// +listType=map
 	// +listMapKey=name
 	Steps []*Step `json:"steps,omitempty"`
+
+	// Outputs are values computed from the outputs of the steps of this
+	// workflow once a run completes. Each value may be any Relay
+	// specification expression.
+	//
+	// +optional
+	Outputs UnstructuredObject `json:"outputs,omitempty"`
 }
 
 type Parameter struct {
@@ -61,6 +68,44 @@ type Step struct {
 	//
 	// +optional
 	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// Outputs declares the outputs this step provides. If any outputs are
+	// declared, the step may only set the outputs listed here.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Outputs []*StepOutputDeclaration `json:"outputs,omitempty"`
+
+	// ServiceAccountName is the name of a Kubernetes service account in the
+	// tenant namespace to run this step as. The tenant must allow the service
+	// account. If not specified, the step runs as a service account with no
+	// permissions.
+	//
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+type StepOutputDeclaration struct {
+	// Name is the name of the output.
+	Name string `json:"name"`
+
+	// Description is a human-readable explanation of the output.
+	//
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Schema is a JSON Schema that values set for this output must conform
+	// to.
+	//
+	// +optional
+	Schema *Unstructured `json:"schema,omitempty"`
+
+	// Sensitive is whether values set for this output contain sensitive or
+	// privileged data.
+	//
+	// +optional
+	Sensitive bool `json:"sensitive,omitempty"`
 }
 
 // WorkflowList enumerates many Workflow resources.
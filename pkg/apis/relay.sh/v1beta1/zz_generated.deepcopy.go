@@ -7,6 +7,8 @@ package v1beta1
 
 import (
 	"k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -70,6 +72,21 @@ func (in *APIWorkflowExecutionSink) DeepCopy() *APIWorkflowExecutionSink {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventsTriggerEventSinkOptions) DeepCopyInto(out *CloudEventsTriggerEventSinkOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventsTriggerEventSinkOptions.
+func (in *CloudEventsTriggerEventSinkOptions) DeepCopy() *CloudEventsTriggerEventSinkOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventsTriggerEventSinkOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
@@ -160,6 +177,21 @@ func (in *DecoratorLink) DeepCopy() *DecoratorLink {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HMACWebhookTriggerVerification) DeepCopyInto(out *HMACWebhookTriggerVerification) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HMACWebhookTriggerVerification.
+func (in *HMACWebhookTriggerVerification) DeepCopy() *HMACWebhookTriggerVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(HMACWebhookTriggerVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Log) DeepCopyInto(out *Log) {
 	*out = *in
@@ -206,6 +238,26 @@ func (in *NamespaceTemplate) DeepCopy() *NamespaceTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationSink) DeepCopyInto(out *NotificationSink) {
+	*out = *in
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(WebhookNotificationSink)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationSink.
+func (in *NotificationSink) DeepCopy() *NotificationSink {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Parameter) DeepCopyInto(out *Parameter) {
 	*out = *in
@@ -300,6 +352,44 @@ func (in *RunList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunNotificationStatus) DeepCopyInto(out *RunNotificationStatus) {
+	*out = *in
+	if in.LastAttemptTime != nil {
+		in, out := &in.LastAttemptTime, &out.LastAttemptTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunNotificationStatus.
+func (in *RunNotificationStatus) DeepCopy() *RunNotificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RunNotificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunOutput) DeepCopyInto(out *RunOutput) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunOutput.
+func (in *RunOutput) DeepCopy() *RunOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(RunOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RunSpec) DeepCopyInto(out *RunSpec) {
 	*out = *in
@@ -377,6 +467,22 @@ func (in *RunStatus) DeepCopyInto(out *RunStatus) {
 			}
 		}
 	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]*RunOutput, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RunOutput)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Notification != nil {
+		in, out := &in.Notification, &out.Notification
+		*out = new(RunNotificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.StartTime != nil {
 		in, out := &in.StartTime, &out.StartTime
 		*out = (*in).DeepCopy()
@@ -456,6 +562,17 @@ func (in *Step) DeepCopyInto(out *Step) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]*StepOutputDeclaration, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(StepOutputDeclaration)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Step.
@@ -468,6 +585,21 @@ func (in *Step) DeepCopy() *Step {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepArtifact) DeepCopyInto(out *StepArtifact) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepArtifact.
+func (in *StepArtifact) DeepCopy() *StepArtifact {
+	if in == nil {
+		return nil
+	}
+	out := new(StepArtifact)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StepCondition) DeepCopyInto(out *StepCondition) {
 	*out = *in
@@ -550,6 +682,25 @@ func (in *StepOutput) DeepCopy() *StepOutput {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepOutputDeclaration) DeepCopyInto(out *StepOutputDeclaration) {
+	*out = *in
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepOutputDeclaration.
+func (in *StepOutputDeclaration) DeepCopy() *StepOutputDeclaration {
+	if in == nil {
+		return nil
+	}
+	out := new(StepOutputDeclaration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StepStatus) DeepCopyInto(out *StepStatus) {
 	*out = *in
@@ -564,6 +715,17 @@ func (in *StepStatus) DeepCopyInto(out *StepStatus) {
 			}
 		}
 	}
+	if in.Artifacts != nil {
+		in, out := &in.Artifacts, &out.Artifacts
+		*out = make([]*StepArtifact, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(StepArtifact)
+				**out = **in
+			}
+		}
+	}
 	if in.Decorators != nil {
 		in, out := &in.Decorators, &out.Decorators
 		*out = make([]*Decorator, len(*in))
@@ -671,6 +833,78 @@ func (in *TenantCondition) DeepCopy() *TenantCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantDefaults) DeepCopyInto(out *TenantDefaults) {
+	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make(UnstructuredObject, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantDefaults.
+func (in *TenantDefaults) DeepCopy() *TenantDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantLimitRange) DeepCopyInto(out *TenantLimitRange) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.DefaultRequest != nil {
+		in, out := &in.DefaultRequest, &out.DefaultRequest
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantLimitRange.
+func (in *TenantLimitRange) DeepCopy() *TenantLimitRange {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantLimitRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantList) DeepCopyInto(out *TenantList) {
 	*out = *in
@@ -703,10 +937,193 @@ func (in *TenantList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantNetworkPolicy) DeepCopyInto(out *TenantNetworkPolicy) {
+	*out = *in
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = make([]networkingv1.NetworkPolicyEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantNetworkPolicy.
+func (in *TenantNetworkPolicy) DeepCopy() *TenantNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantPodSecurity) DeepCopyInto(out *TenantPodSecurity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantPodSecurity.
+func (in *TenantPodSecurity) DeepCopy() *TenantPodSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantPodSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantQuota) DeepCopyInto(out *TenantQuota) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.EphemeralStorage != nil {
+		in, out := &in.EphemeralStorage, &out.EphemeralStorage
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantQuota.
+func (in *TenantQuota) DeepCopy() *TenantQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantQuotaStatus) DeepCopyInto(out *TenantQuotaStatus) {
+	*out = *in
+	if in.Hard != nil {
+		in, out := &in.Hard, &out.Hard
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Used != nil {
+		in, out := &in.Used, &out.Used
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantQuotaStatus.
+func (in *TenantQuotaStatus) DeepCopy() *TenantQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantRunCounts) DeepCopyInto(out *TenantRunCounts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantRunCounts.
+func (in *TenantRunCounts) DeepCopy() *TenantRunCounts {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantRunCounts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantSinkDelivery) DeepCopyInto(out *TenantSinkDelivery) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantSinkDelivery.
+func (in *TenantSinkDelivery) DeepCopy() *TenantSinkDelivery {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantSinkDelivery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantSinkStatus) DeepCopyInto(out *TenantSinkStatus) {
+	*out = *in
+	if in.LastDelivery != nil {
+		in, out := &in.LastDelivery, &out.LastDelivery
+		*out = new(TenantSinkDelivery)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantSinkStatus.
+func (in *TenantSinkStatus) DeepCopy() *TenantSinkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantSinkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantSpec) DeepCopyInto(out *TenantSpec) {
 	*out = *in
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = new(TenantDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LimitRange != nil {
+		in, out := &in.LimitRange, &out.LimitRange
+		*out = new(TenantLimitRange)
+		(*in).DeepCopyInto(*out)
+	}
 	in.NamespaceTemplate.DeepCopyInto(&out.NamespaceTemplate)
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(TenantNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	in.NotificationSink.DeepCopyInto(&out.NotificationSink)
+	if in.PodSecurity != nil {
+		in, out := &in.PodSecurity, &out.PodSecurity
+		*out = new(TenantPodSecurity)
+		**out = **in
+	}
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		*out = new(TenantQuota)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StepServiceAccountNames != nil {
+		in, out := &in.StepServiceAccountNames, &out.StepServiceAccountNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.ToolInjection.DeepCopyInto(&out.ToolInjection)
 	in.TriggerEventSink.DeepCopyInto(&out.TriggerEventSink)
 	in.WorkflowExecutionSink.DeepCopyInto(&out.WorkflowExecutionSink)
@@ -725,6 +1142,28 @@ func (in *TenantSpec) DeepCopy() *TenantSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantStatus) DeepCopyInto(out *TenantStatus) {
 	*out = *in
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		*out = new(TenantQuotaStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sinks != nil {
+		in, out := &in.Sinks, &out.Sinks
+		*out = make([]TenantSinkStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Runs != nil {
+		in, out := &in.Runs, &out.Runs
+		*out = new(TenantRunCounts)
+		**out = **in
+	}
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = new(TenantTriggerCounts)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]TenantCondition, len(*in))
@@ -744,6 +1183,21 @@ func (in *TenantStatus) DeepCopy() *TenantStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantTriggerCounts) DeepCopyInto(out *TenantTriggerCounts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantTriggerCounts.
+func (in *TenantTriggerCounts) DeepCopy() *TenantTriggerCounts {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantTriggerCounts)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ToolInjection) DeepCopyInto(out *ToolInjection) {
 	*out = *in
@@ -764,6 +1218,28 @@ func (in *ToolInjection) DeepCopy() *ToolInjection {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerBinding) DeepCopyInto(out *TriggerBinding) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(UnstructuredObject, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerBinding.
+func (in *TriggerBinding) DeepCopy() *TriggerBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TriggerEventSink) DeepCopyInto(out *TriggerEventSink) {
 	*out = *in
@@ -772,6 +1248,21 @@ func (in *TriggerEventSink) DeepCopyInto(out *TriggerEventSink) {
 		*out = new(APITriggerEventSink)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CloudEvents != nil {
+		in, out := &in.CloudEvents, &out.CloudEvents
+		*out = new(CloudEventsTriggerEventSinkOptions)
+		**out = **in
+	}
+	if in.WorkflowRef != nil {
+		in, out := &in.WorkflowRef, &out.WorkflowRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Binding != nil {
+		in, out := &in.Binding, &out.Binding
+		*out = new(TriggerBinding)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerEventSink.
@@ -811,6 +1302,26 @@ func (in UnstructuredObject) DeepCopy() UnstructuredObject {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookNotificationSink) DeepCopyInto(out *WebhookNotificationSink) {
+	*out = *in
+	if in.TokenFrom != nil {
+		in, out := &in.TokenFrom, &out.TokenFrom
+		*out = new(APITokenSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookNotificationSink.
+func (in *WebhookNotificationSink) DeepCopy() *WebhookNotificationSink {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookNotificationSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookTrigger) DeepCopyInto(out *WebhookTrigger) {
 	*out = *in
@@ -854,6 +1365,97 @@ func (in *WebhookTriggerCondition) DeepCopy() *WebhookTriggerCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookTriggerDomain) DeepCopyInto(out *WebhookTriggerDomain) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(WebhookTriggerDomainTLS)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookTriggerDomain.
+func (in *WebhookTriggerDomain) DeepCopy() *WebhookTriggerDomain {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookTriggerDomain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookTriggerDomainTLS) DeepCopyInto(out *WebhookTriggerDomainTLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookTriggerDomainTLS.
+func (in *WebhookTriggerDomainTLS) DeepCopy() *WebhookTriggerDomainTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookTriggerDomainTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookTriggerEventStatus) DeepCopyInto(out *WebhookTriggerEventStatus) {
+	*out = *in
+	if in.LastEventTime != nil {
+		in, out := &in.LastEventTime, &out.LastEventTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Recent != nil {
+		in, out := &in.Recent, &out.Recent
+		*out = make([]WebhookTriggerRecordedEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookTriggerEventStatus.
+func (in *WebhookTriggerEventStatus) DeepCopy() *WebhookTriggerEventStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookTriggerEventStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookTriggerLimits) DeepCopyInto(out *WebhookTriggerLimits) {
+	*out = *in
+	if in.RequestsPerSecond != nil {
+		in, out := &in.RequestsPerSecond, &out.RequestsPerSecond
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxBodySize != nil {
+		in, out := &in.MaxBodySize, &out.MaxBodySize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookTriggerLimits.
+func (in *WebhookTriggerLimits) DeepCopy() *WebhookTriggerLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookTriggerLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookTriggerList) DeepCopyInto(out *WebhookTriggerList) {
 	*out = *in
@@ -886,11 +1488,87 @@ func (in *WebhookTriggerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookTriggerRecordedEvent) DeepCopyInto(out *WebhookTriggerRecordedEvent) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookTriggerRecordedEvent.
+func (in *WebhookTriggerRecordedEvent) DeepCopy() *WebhookTriggerRecordedEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookTriggerRecordedEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookTriggerScaling) DeepCopyInto(out *WebhookTriggerScaling) {
+	*out = *in
+	if in.MinScale != nil {
+		in, out := &in.MinScale, &out.MinScale
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxScale != nil {
+		in, out := &in.MaxScale, &out.MaxScale
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetConcurrency != nil {
+		in, out := &in.TargetConcurrency, &out.TargetConcurrency
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleDownDelay != nil {
+		in, out := &in.ScaleDownDelay, &out.ScaleDownDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookTriggerScaling.
+func (in *WebhookTriggerScaling) DeepCopy() *WebhookTriggerScaling {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookTriggerScaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookTriggerSpec) DeepCopyInto(out *WebhookTriggerSpec) {
 	*out = *in
 	out.TenantRef = in.TenantRef
 	in.Container.DeepCopyInto(&out.Container)
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(WebhookTriggerVerification)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Domain != nil {
+		in, out := &in.Domain, &out.Domain
+		*out = new(WebhookTriggerDomain)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scaling != nil {
+		in, out := &in.Scaling, &out.Scaling
+		*out = new(WebhookTriggerScaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(WebhookTriggerLimits)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookTriggerSpec.
@@ -906,6 +1584,11 @@ func (in *WebhookTriggerSpec) DeepCopy() *WebhookTriggerSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookTriggerStatus) DeepCopyInto(out *WebhookTriggerStatus) {
 	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = new(WebhookTriggerEventStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]WebhookTriggerCondition, len(*in))
@@ -925,6 +1608,26 @@ func (in *WebhookTriggerStatus) DeepCopy() *WebhookTriggerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookTriggerVerification) DeepCopyInto(out *WebhookTriggerVerification) {
+	*out = *in
+	if in.HMAC != nil {
+		in, out := &in.HMAC, &out.HMAC
+		*out = new(HMACWebhookTriggerVerification)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookTriggerVerification.
+func (in *WebhookTriggerVerification) DeepCopy() *WebhookTriggerVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookTriggerVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WhenEvaluationStepMessageSource) DeepCopyInto(out *WhenEvaluationStepMessageSource) {
 	*out = *in
@@ -1048,6 +1751,13 @@ func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
 			}
 		}
 	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make(UnstructuredObject, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowSpec.
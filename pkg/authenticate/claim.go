@@ -30,6 +30,8 @@ type Claims struct {
 	RelayKubernetesImmutableConfigMapName string `json:"relay.sh/k8s/immutable-config-map-name,omitempty"`
 	RelayKubernetesMutableConfigMapName   string `json:"relay.sh/k8s/mutable-config-map-name,omitempty"`
 
+	RelayArtifactKeyPrefix string `json:"relay.sh/artifact/key-prefix,omitempty"`
+
 	RelayVaultEnginePath     string `json:"relay.sh/vault/engine-path,omitempty"`
 	RelayVaultSecretPath     string `json:"relay.sh/vault/secret-path,omitempty"`
 	RelayVaultConnectionPath string `json:"relay.sh/vault/connection-path,omitempty"`
@@ -37,6 +39,25 @@ type Claims struct {
 	RelayEventAPIURL   *types.URL `json:"relay.sh/event/api/url,omitempty"`
 	RelayEventAPIToken string     `json:"relay.sh/event/api/token,omitempty"`
 
+	RelayEventAPIFormat                string `json:"relay.sh/event/api/format,omitempty"`
+	RelayEventAPICloudEventsMode       string `json:"relay.sh/event/api/cloudevents/mode,omitempty"`
+	RelayEventAPICloudEventsDataSchema string `json:"relay.sh/event/api/cloudevents/data-schema,omitempty"`
+
+	RelayEventWorkflowNamespace  string                 `json:"relay.sh/event/workflow/namespace,omitempty"`
+	RelayEventWorkflowName       string                 `json:"relay.sh/event/workflow/name,omitempty"`
+	RelayEventWorkflowParameters map[string]interface{} `json:"relay.sh/event/workflow/parameters,omitempty"`
+
+	RelayWebhookVerificationScheme     string `json:"relay.sh/webhook/verification/scheme,omitempty"`
+	RelayWebhookVerificationSecretName string `json:"relay.sh/webhook/verification/secret-name,omitempty"`
+	RelayWebhookVerificationHeader     string `json:"relay.sh/webhook/verification/header,omitempty"`
+	RelayWebhookVerificationAlgorithm  string `json:"relay.sh/webhook/verification/algorithm,omitempty"`
+	RelayWebhookVerificationPrefix     string `json:"relay.sh/webhook/verification/prefix,omitempty"`
+	RelayWebhookVerificationEncoding   string `json:"relay.sh/webhook/verification/encoding,omitempty"`
+
+	RelayWebhookLimitsRequestsPerSecond int32 `json:"relay.sh/webhook/limits/requests-per-second,omitempty"`
+	RelayWebhookLimitsBurst             int32 `json:"relay.sh/webhook/limits/burst,omitempty"`
+	RelayWebhookLimitsMaxBodySize       int64 `json:"relay.sh/webhook/limits/max-body-size,omitempty"`
+
 	RelayWorkflowExecutionAPIURL   *types.URL `json:"relay.sh/workflow-execution/api/url,omitempty"`
 	RelayWorkflowExecutionAPIToken string     `json:"relay.sh/workflow-execution/api/token,omitempty"`
 }
@@ -0,0 +1,132 @@
+package entrypoint
+
+import (
+	"encoding/base64"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const (
+	// MaskReplacement is written to logs in place of a masked value.
+	MaskReplacement = "***"
+)
+
+// Masker redacts sensitive values from the output of a step. Besides each
+// value itself, the common base64 and URL encodings of the value are also
+// redacted.
+type Masker struct {
+	replacer *strings.Replacer
+	lines    int
+}
+
+// Mask replaces every sensitive value in the given text.
+func (m *Masker) Mask(s string) string {
+	if m == nil {
+		return s
+	}
+
+	return m.replacer.Replace(s)
+}
+
+// NewLineBuffer returns a buffer that masks a stream of lines. Values that
+// span multiple lines are only masked if the lines pass through the same
+// buffer.
+func (m *Masker) NewLineBuffer() *MaskedLineBuffer {
+	return &MaskedLineBuffer{masker: m}
+}
+
+// MaskedLineBuffer holds back as many lines as necessary to mask values that
+// span lines. If no value contains a line break, lines are returned as soon as
+// they are pushed.
+type MaskedLineBuffer struct {
+	masker  *Masker
+	pending []string
+}
+
+// Push adds a line to the buffer and returns the masked lines that can no
+// longer be part of a sensitive value.
+func (b *MaskedLineBuffer) Push(line string) []string {
+	if b.masker == nil {
+		return []string{line}
+	}
+
+	lines := strings.Split(b.masker.Mask(strings.Join(append(b.pending, line), "\n")), "\n")
+
+	keep := b.masker.lines - 1
+	if keep > len(lines) {
+		keep = len(lines)
+	}
+
+	ready := lines[:len(lines)-keep]
+	b.pending = append([]string(nil), lines[len(lines)-keep:]...)
+
+	return ready
+}
+
+// Flush returns the lines held back by the buffer.
+func (b *MaskedLineBuffer) Flush() []string {
+	lines := b.pending
+	b.pending = nil
+	return lines
+}
+
+// NewMasker creates a masker for the given values. It returns nil if there
+// are no values to mask.
+func NewMasker(values []string) *Masker {
+	forms := make(map[string]struct{})
+	for _, value := range values {
+		// Line endings are normalized by the scanners that read the output of
+		// the step, and a trailing line break may not be written at all.
+		value = strings.TrimRight(strings.ReplaceAll(value, "\r\n", "\n"), "\n")
+		if value == "" {
+			continue
+		}
+
+		for _, form := range []string{
+			value,
+			base64.StdEncoding.EncodeToString([]byte(value)),
+			base64.RawStdEncoding.EncodeToString([]byte(value)),
+			base64.URLEncoding.EncodeToString([]byte(value)),
+			base64.RawURLEncoding.EncodeToString([]byte(value)),
+			url.QueryEscape(value),
+			url.PathEscape(value),
+		} {
+			forms[form] = struct{}{}
+		}
+	}
+
+	if len(forms) == 0 {
+		return nil
+	}
+
+	// The replacer prefers earlier arguments when several values match at the
+	// same position, so longer values must come first to be replaced
+	// entirely.
+	sorted := make([]string, 0, len(forms))
+	for form := range forms {
+		sorted = append(sorted, form)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if len(sorted[i]) != len(sorted[j]) {
+			return len(sorted[i]) > len(sorted[j])
+		}
+
+		return sorted[i] < sorted[j]
+	})
+
+	m := &Masker{lines: 1}
+
+	oldnew := make([]string, 0, len(sorted)*2)
+	for _, form := range sorted {
+		oldnew = append(oldnew, form, MaskReplacement)
+
+		if n := strings.Count(form, "\n") + 1; n > m.lines {
+			m.lines = n
+		}
+	}
+
+	m.replacer = strings.NewReplacer(oldnew...)
+
+	return m
+}
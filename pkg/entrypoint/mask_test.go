@@ -0,0 +1,73 @@
+package entrypoint_test
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/puppetlabs/relay-core/pkg/entrypoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func pushAll(buf *entrypoint.MaskedLineBuffer, lines ...string) []string {
+	var out []string
+	for _, line := range lines {
+		out = append(out, buf.Push(line)...)
+	}
+	return append(out, buf.Flush()...)
+}
+
+func TestMaskerEncodings(t *testing.T) {
+	secret := "s3cr3t/value?"
+	m := entrypoint.NewMasker([]string{secret, ""})
+
+	for _, form := range []string{
+		secret,
+		base64.StdEncoding.EncodeToString([]byte(secret)),
+		base64.RawURLEncoding.EncodeToString([]byte(secret)),
+		url.QueryEscape(secret),
+		url.PathEscape(secret),
+	} {
+		assert.Equal(t, "token=***;", m.Mask("token="+form+";"), form)
+	}
+
+	assert.Equal(t, "nothing to see", m.Mask("nothing to see"))
+}
+
+func TestMaskerPrefersLongerValues(t *testing.T) {
+	m := entrypoint.NewMasker([]string{"abc", "abcdef"})
+	assert.Equal(t, "*** ***", m.Mask("abcdef abc"))
+}
+
+func TestMaskerWithoutValues(t *testing.T) {
+	m := entrypoint.NewMasker(nil)
+	assert.Nil(t, m)
+	assert.Equal(t, "secret", m.Mask("secret"))
+	assert.Equal(t, []string{"a", "b"}, pushAll(m.NewLineBuffer(), "a", "b"))
+}
+
+func TestMaskedLineBufferSingleLine(t *testing.T) {
+	buf := entrypoint.NewMasker([]string{"hunter2"}).NewLineBuffer()
+
+	// Lines are not held back if no value spans lines.
+	assert.Equal(t, []string{"password: ***"}, buf.Push("password: hunter2"))
+	assert.Equal(t, []string{"done"}, buf.Push("done"))
+	assert.Empty(t, buf.Flush())
+}
+
+func TestMaskedLineBufferMultipleLines(t *testing.T) {
+	key := "-----BEGIN KEY-----\r\nAAAA\r\nBBBB\r\n-----END KEY-----\r\n"
+	m := entrypoint.NewMasker([]string{key})
+
+	lines := strings.Split("before\n-----BEGIN KEY-----\nAAAA\nBBBB\n-----END KEY-----\nafter", "\n")
+	assert.Equal(t, []string{"before", "***", "after"}, pushAll(m.NewLineBuffer(), lines...))
+
+	// The value is masked even if the output ends without a line break.
+	lines = strings.Split("-----BEGIN KEY-----\nAAAA\nBBBB\n-----END KEY-----", "\n")
+	assert.Equal(t, []string{"***"}, pushAll(m.NewLineBuffer(), lines...))
+
+	// Partial values are written as they are.
+	lines = []string{"AAAA", "BBBB"}
+	assert.Equal(t, lines, pushAll(m.NewLineBuffer(), lines...))
+}
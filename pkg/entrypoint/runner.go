@@ -142,11 +142,18 @@ func (rr *RealRunner) Run(args ...string) error {
 		}
 	}
 
+	var masker *Masker
+
 	if mu != nil {
 		if err := rr.getEnvironmentVariables(ctx, mu); err != nil {
 			log.Println(err)
 		}
 
+		masker, err = rr.getMasker(ctx, mu)
+		if err != nil {
+			log.Println(err)
+		}
+
 		if name != path.Join(model.InputScriptMountPath, model.InputScriptName) {
 			if err := rr.validateSchemas(ctx, mu); err != nil {
 				log.Println(err)
@@ -185,8 +192,8 @@ func (rr *RealRunner) Run(args ...string) error {
 		return err
 	}
 
-	go rr.scan(ctx, mu, scannerOut, os.Stdout, logOut, doneOut)
-	go rr.scan(ctx, mu, scannerErr, os.Stderr, logErr, doneErr)
+	go rr.scan(ctx, mu, scannerOut, masker.NewLineBuffer(), os.Stdout, logOut, doneOut)
+	go rr.scan(ctx, mu, scannerErr, masker.NewLineBuffer(), os.Stderr, logErr, doneErr)
 
 	<-doneOut
 	<-doneErr
@@ -208,25 +215,31 @@ func (rr *RealRunner) Run(args ...string) error {
 	return nil
 }
 
-func (rr *RealRunner) scan(ctx context.Context, mu *url.URL, scanner *bufio.Scanner, out *os.File, lcr *plspb.LogCreateResponse, done chan<- bool) {
-	for scanner.Scan() {
-		line := scanner.Text()
+func (rr *RealRunner) scan(ctx context.Context, mu *url.URL, scanner *bufio.Scanner, buf *MaskedLineBuffer, out *os.File, lcr *plspb.LogCreateResponse, done chan<- bool) {
+	write := func(lines []string) {
+		for _, line := range lines {
+			if mu != nil && lcr != nil {
+				message := &plspb.LogMessageAppendRequest{
+					LogId:     lcr.GetLogId(),
+					Payload:   []byte(line),
+					Timestamp: timestamppb.New(time.Now().UTC()),
+				}
 
-		if mu != nil && lcr != nil {
-			message := &plspb.LogMessageAppendRequest{
-				LogId:     lcr.GetLogId(),
-				Payload:   []byte(line),
-				Timestamp: timestamppb.New(time.Now().UTC()),
+				if _, err := rr.postLogMessage(ctx, mu, message); err != nil {
+					log.Println(err)
+				}
 			}
 
-			if _, err := rr.postLogMessage(ctx, mu, message); err != nil {
-				log.Println(err)
-			}
+			_, _ = out.WriteString(line)
+			_, _ = out.WriteString("\n")
 		}
+	}
 
-		_, _ = out.WriteString(line)
-		_, _ = out.WriteString("\n")
+	for scanner.Scan() {
+		write(buf.Push(scanner.Text()))
 	}
+	write(buf.Flush())
+
 	done <- true
 }
 
@@ -339,6 +352,35 @@ func (rr *RealRunner) getEnvironmentVariables(ctx context.Context, mu *url.URL)
 	return nil
 }
 
+// getMasker retrieves the sensitive values known to the metadata API that the
+// output of the step must not reveal.
+func (rr *RealRunner) getMasker(ctx context.Context, mu *url.URL) (*Masker, error) {
+	me := &url.URL{Path: "/masks"}
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodGet, mu.ResolveReference(me).String(), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := rr.getResponse(ctx, req, []retry.WaitOption{})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d retrieving masks", resp.StatusCode)
+	}
+
+	var r api.GetMasksResponseEnvelope
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	return NewMasker(r.Masks), nil
+}
+
 func (rr *RealRunner) postLog(ctx context.Context, mu *url.URL, request *plspb.LogCreateRequest) (*plspb.LogCreateResponse, error) {
 	le := &url.URL{Path: "/logs"}
 
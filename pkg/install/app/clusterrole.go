@@ -15,9 +15,14 @@ func ConfigureOperatorClusterRole(cr *rbacv1obj.ClusterRole) {
 		},
 		{
 			APIGroups: []string{""},
-			Resources: []string{"configmaps", "pods", "serviceaccounts", "secrets", "limitranges"},
+			Resources: []string{"configmaps", "pods", "serviceaccounts", "secrets", "limitranges", "resourcequotas"},
 			Verbs:     []string{"get", "list", "watch"},
 		},
+		{
+			APIGroups: []string{""},
+			Resources: []string{"events"},
+			Verbs:     []string{"create", "patch"},
+		},
 		{
 			APIGroups: []string{"tekton.dev"},
 			Resources: []string{"pipelineruns", "taskruns", "pipelines", "tasks", "conditions"},
@@ -38,9 +43,14 @@ func ConfigureOperatorClusterRole(cr *rbacv1obj.ClusterRole) {
 			Resources: []string{"runs", "runs/status", "tenants", "tenants/status", "webhooktriggers", "webhooktriggers/status", "workflows", "workflows/status"},
 			Verbs:     []string{"get", "list", "watch", "update", "patch"},
 		},
+		{
+			APIGroups: []string{"relay.sh"},
+			Resources: []string{"runs"},
+			Verbs:     []string{"create"},
+		},
 		{
 			APIGroups: []string{"serving.knative.dev"},
-			Resources: []string{"revisions", "services"},
+			Resources: []string{"domainmappings", "revisions", "services"},
 			Verbs:     []string{"get", "list", "watch"},
 		},
 	}
@@ -55,7 +65,7 @@ func ConfigureOperatorDelegateClusterRole(cr *rbacv1obj.ClusterRole) {
 		},
 		{
 			APIGroups: []string{""},
-			Resources: []string{"configmaps", "serviceaccounts", "secrets", "limitranges"},
+			Resources: []string{"configmaps", "serviceaccounts", "secrets", "limitranges", "resourcequotas"},
 			Verbs:     []string{"create", "update", "patch", "delete"},
 		},
 		{
@@ -75,7 +85,7 @@ func ConfigureOperatorDelegateClusterRole(cr *rbacv1obj.ClusterRole) {
 		},
 		{
 			APIGroups: []string{"serving.knative.dev"},
-			Resources: []string{"revisions", "services"},
+			Resources: []string{"domainmappings", "revisions", "services"},
 			Verbs:     []string{"create", "update", "patch", "delete"},
 		},
 	}
@@ -86,6 +96,7 @@ func ConfigureMetadataAPIClusterRole(cr *rbacv1obj.ClusterRole) {
 		{APIGroups: []string{""}, Resources: []string{"namespaces"}, Verbs: []string{"get"}},
 		{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list"}},
 		{APIGroups: []string{"tekton.dev"}, Resources: []string{"conditions"}, Verbs: []string{"get", "list"}},
+		{APIGroups: []string{"relay.sh"}, Resources: []string{"runs"}, Verbs: []string{"get", "create"}},
 	}
 }
 
@@ -88,6 +88,12 @@ func (d *metadataAPIDeployment) configureContainer(c *corev1.Container) {
 		env = append(env, corev1.EnvVar{Name: "RELAY_METADATA_API_DEBUG", Value: "true"})
 	}
 
+	// Artifacts share the operator's blob storage so that the operator can
+	// clean them up when a run is deleted.
+	if addr := core.Spec.Operator.StorageAddr; addr != nil && *addr != "" {
+		env = append(env, corev1.EnvVar{Name: "RELAY_METADATA_API_ARTIFACT_STORAGE_URL", Value: *addr})
+	}
+
 	if core.Spec.SentryDSNSecretName != nil {
 		env = append(env, corev1.EnvVar{
 			Name: "RELAY_METADATA_API_SENTRY_DSN",
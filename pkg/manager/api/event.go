@@ -6,10 +6,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"path"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/puppetlabs/errawr-go/v2/pkg/errawr"
 	"github.com/puppetlabs/leg/encoding/transfer"
 	utilapi "github.com/puppetlabs/leg/httputil/api"
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
 	"github.com/puppetlabs/relay-core/pkg/model"
 )
 
@@ -22,81 +26,197 @@ func (e *UnexpectedResponseError) Error() string {
 	return fmt.Sprintf("received HTTP %d from API: %+v", e.StatusCode, e.Cause)
 }
 
+const (
+	// CloudEventTypeTriggerEvent is the CloudEvents type attribute of events
+	// emitted by triggers.
+	CloudEventTypeTriggerEvent = "sh.relay.trigger.event"
+
+	cloudEventsSpecVersion = "1.0"
+)
+
 // TODO: Support event sources other than triggers?
 
 type EventManager struct {
 	me    model.Action
 	url   string
 	token string
+
+	cloudEvents  bool
+	ceSource     string
+	ceMode       relayv1beta1.CloudEventsMode
+	ceDataSchema string
 }
 
 var _ model.EventManager = &EventManager{}
 
 func (m *EventManager) Emit(ctx context.Context, data map[string]interface{}, key string) (*model.Event, error) {
-	switch at := m.me.(type) {
-	case *model.Trigger:
-		encoded := make(map[string]transfer.JSONInterface, len(data))
-		for k, v := range data {
-			encoded[k] = transfer.JSONInterface{Data: v}
+	at, ok := m.me.(*model.Trigger)
+	if !ok {
+		return nil, model.ErrRejected
+	}
+
+	var req *http.Request
+	var err error
+	if m.cloudEvents {
+		req, err = m.newCloudEventRequest(ctx, data, key)
+	} else {
+		req, err = m.newRelayEventRequest(ctx, at, data, key)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", m.token))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, &model.EventDeliveryError{Cause: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		err := &UnexpectedResponseError{
+			StatusCode: resp.StatusCode,
 		}
 
-		env := &postEventRequestEnvelope{
-			Source: &triggerEventSourceEnvelope{
-				Type: "trigger",
-				Trigger: &triggerIdentifierEnvelope{
-					Name: at.Name,
-				},
-			},
-			Data: encoded,
-			Key:  key,
+		// Try to decode an error.
+		var env utilapi.ErrorEnvelope
+		if derr := json.NewDecoder(resp.Body).Decode(&env); derr == nil && env.Error != nil {
+			err.Cause = env.Error.AsError()
 		}
 
-		b, err := json.Marshal(env)
-		if err != nil {
-			return nil, err
+		// The sink may recover from these, so the event can be sent again.
+		if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+			return nil, &model.EventDeliveryError{Cause: err}
 		}
 
-		req, err := http.NewRequest(http.MethodPost, m.url, bytes.NewBuffer(b))
+		return nil, err
+	}
+
+	return &model.Event{
+		Data: data,
+		Key:  key,
+	}, nil
+}
+
+func (m *EventManager) newRelayEventRequest(ctx context.Context, at *model.Trigger, data map[string]interface{}, key string) (*http.Request, error) {
+	env := &postEventRequestEnvelope{
+		Source: &triggerEventSourceEnvelope{
+			Type: "trigger",
+			Trigger: &triggerIdentifierEnvelope{
+				Name: at.Name,
+			},
+		},
+		Data: encodeEventData(data),
+		Key:  key,
+	}
+
+	b, err := json.Marshal(env)
+	if err != nil {
+		return nil, err
+	}
+
+	return http.NewRequestWithContext(ctx, http.MethodPost, m.url, bytes.NewBuffer(b))
+}
+
+func (m *EventManager) newCloudEventRequest(ctx context.Context, data map[string]interface{}, key string) (*http.Request, error) {
+	id := key
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	ts := time.Now().UTC().Format(time.RFC3339Nano)
+
+	if m.ceMode == relayv1beta1.CloudEventsModeBinary {
+		b, err := json.Marshal(encodeEventData(data))
 		if err != nil {
 			return nil, err
 		}
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", m.token))
 
-		resp, err := http.DefaultClient.Do(req)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.url, bytes.NewBuffer(b))
 		if err != nil {
 			return nil, err
 		}
-		defer resp.Body.Close()
 
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			err := &UnexpectedResponseError{
-				StatusCode: resp.StatusCode,
-			}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("ce-specversion", cloudEventsSpecVersion)
+		req.Header.Set("ce-id", id)
+		req.Header.Set("ce-source", m.ceSource)
+		req.Header.Set("ce-type", CloudEventTypeTriggerEvent)
+		req.Header.Set("ce-time", ts)
+		if m.ceDataSchema != "" {
+			req.Header.Set("ce-dataschema", m.ceDataSchema)
+		}
 
-			// Try to decode an error.
-			var env utilapi.ErrorEnvelope
-			if derr := json.NewDecoder(resp.Body).Decode(&env); derr == nil && env.Error != nil {
-				err.Cause = env.Error.AsError()
-			}
+		return req, nil
+	}
 
-			return nil, err
-		}
+	env := &cloudEventEnvelope{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              id,
+		Source:          m.ceSource,
+		Type:            CloudEventTypeTriggerEvent,
+		Time:            ts,
+		DataContentType: "application/json",
+		DataSchema:      m.ceDataSchema,
+		Data:            encodeEventData(data),
+	}
 
-		return &model.Event{
-			Data: data,
-			Key:  key,
-		}, nil
-	default:
-		return nil, model.ErrRejected
+	b, err := json.Marshal(env)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.url, bytes.NewBuffer(b))
+	if err != nil {
+		return nil, err
 	}
+
+	req.Header.Set("Content-Type", "application/cloudevents+json")
+
+	return req, nil
+}
+
+// TriggerCloudEventsSource returns the CloudEvents source attribute for
+// events emitted by the given trigger in a tenant namespace.
+func TriggerCloudEventsSource(namespace, name string) string {
+	return path.Join("/namespaces", namespace, model.ActionTypeTrigger.Plural, name)
 }
 
-func NewEventManager(action model.Action, url, token string) *EventManager {
-	return &EventManager{
+type EventManagerOption func(m *EventManager)
+
+// EventManagerWithCloudEvents configures the event manager to send events
+// as CloudEvents with the given source attribute using the given content mode.
+func EventManagerWithCloudEvents(source string, mode relayv1beta1.CloudEventsMode, dataSchema string) EventManagerOption {
+	return func(m *EventManager) {
+		m.cloudEvents = true
+		m.ceSource = source
+		m.ceMode = mode
+		m.ceDataSchema = dataSchema
+	}
+}
+
+func NewEventManager(action model.Action, url, token string, opts ...EventManagerOption) *EventManager {
+	m := &EventManager{
 		me:    action,
 		url:   url,
 		token: token,
 	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+func encodeEventData(data map[string]interface{}) map[string]transfer.JSONInterface {
+	encoded := make(map[string]transfer.JSONInterface, len(data))
+	for k, v := range data {
+		encoded[k] = transfer.JSONInterface{Data: v}
+	}
+
+	return encoded
 }
 
 type triggerIdentifierEnvelope struct {
@@ -113,3 +233,14 @@ type postEventRequestEnvelope struct {
 	Data   map[string]transfer.JSONInterface `json:"data"`
 	Key    string                            `json:"key,omitempty"`
 }
+
+type cloudEventEnvelope struct {
+	SpecVersion     string                            `json:"specversion"`
+	ID              string                            `json:"id"`
+	Source          string                            `json:"source"`
+	Type            string                            `json:"type"`
+	Time            string                            `json:"time"`
+	DataContentType string                            `json:"datacontenttype"`
+	DataSchema      string                            `json:"dataschema,omitempty"`
+	Data            map[string]transfer.JSONInterface `json:"data"`
+}
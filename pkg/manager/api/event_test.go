@@ -7,8 +7,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
 	"github.com/puppetlabs/relay-core/pkg/manager/api"
 	"github.com/puppetlabs/relay-core/pkg/model"
 	"github.com/stretchr/testify/assert"
@@ -60,3 +62,87 @@ func TestEventManager(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, data, ev.Data)
 }
+
+func TestEventManagerCloudEventsStructured(t *testing.T) {
+	ctx := context.Background()
+
+	trigger := &model.Trigger{
+		Name: "foo",
+	}
+
+	data := map[string]interface{}{
+		"foo": "bar",
+	}
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/cloudevents+json", r.Header.Get("content-type"))
+		assert.Equal(t, "Bearer token", r.Header.Get("authorization"))
+
+		var env struct {
+			SpecVersion     string                 `json:"specversion"`
+			ID              string                 `json:"id"`
+			Source          string                 `json:"source"`
+			Type            string                 `json:"type"`
+			Time            time.Time              `json:"time"`
+			DataContentType string                 `json:"datacontenttype"`
+			DataSchema      string                 `json:"dataschema"`
+			Data            map[string]interface{} `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&env))
+
+		assert.Equal(t, "1.0", env.SpecVersion)
+		assert.Equal(t, "my-key", env.ID)
+		assert.Equal(t, "/namespaces/default/triggers/foo", env.Source)
+		assert.Equal(t, api.CloudEventTypeTriggerEvent, env.Type)
+		assert.False(t, env.Time.IsZero())
+		assert.Equal(t, "application/json", env.DataContentType)
+		assert.Equal(t, "https://example.com/schema.json", env.DataSchema)
+		assert.Equal(t, data, env.Data)
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer s.Close()
+
+	em := api.NewEventManager(trigger, s.URL, "token",
+		api.EventManagerWithCloudEvents("/namespaces/default/triggers/foo", relayv1beta1.CloudEventsModeStructured, "https://example.com/schema.json"),
+	)
+
+	_, err := em.Emit(ctx, data, "my-key")
+	require.NoError(t, err)
+}
+
+func TestEventManagerCloudEventsBinary(t *testing.T) {
+	ctx := context.Background()
+
+	trigger := &model.Trigger{
+		Name: "foo",
+	}
+
+	data := map[string]interface{}{
+		"foo": "bar",
+	}
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("content-type"))
+		assert.Equal(t, "1.0", r.Header.Get("ce-specversion"))
+		assert.NotEmpty(t, r.Header.Get("ce-id"))
+		assert.Equal(t, "/namespaces/default/triggers/foo", r.Header.Get("ce-source"))
+		assert.Equal(t, api.CloudEventTypeTriggerEvent, r.Header.Get("ce-type"))
+		assert.NotEmpty(t, r.Header.Get("ce-time"))
+		assert.Empty(t, r.Header.Get("ce-dataschema"))
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, data, body)
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer s.Close()
+
+	em := api.NewEventManager(trigger, s.URL, "",
+		api.EventManagerWithCloudEvents("/namespaces/default/triggers/foo", relayv1beta1.CloudEventsModeBinary, ""),
+	)
+
+	_, err := em.Emit(ctx, data, "")
+	require.NoError(t, err)
+}
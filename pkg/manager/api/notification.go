@@ -0,0 +1,129 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/puppetlabs/leg/encoding/transfer"
+	"github.com/puppetlabs/relay-core/pkg/model"
+)
+
+const (
+	// NotificationSignatureHeader is the request header containing the HMAC
+	// of the request body computed using the notification sink token.
+	NotificationSignatureHeader = "X-Relay-Signature"
+
+	// NotificationDeliveryHeader is the request header containing the unique
+	// identifier of a notification.
+	NotificationDeliveryHeader = "X-Relay-Delivery"
+)
+
+// SignNotification computes the value of the signature header for the given
+// payload.
+func SignNotification(token string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(token))
+	mac.Write(payload)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type NotificationManager struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+var _ model.NotificationManager = &NotificationManager{}
+
+func (m *NotificationManager) Notify(ctx context.Context, n *model.RunNotification) error {
+	env := &runNotificationEnvelope{
+		Run: &runIdentifierEnvelope{
+			Name:      n.Run.ID,
+			Namespace: n.Namespace,
+			Workflow:  n.WorkflowName,
+		},
+		Outcome:        string(n.Outcome),
+		Message:        n.Message,
+		StartTime:      n.StartTime,
+		CompletionTime: n.CompletionTime,
+	}
+
+	if len(n.Outputs) > 0 {
+		env.Outputs = make(map[string]transfer.JSONInterface, len(n.Outputs))
+		for k, v := range n.Outputs {
+			env.Outputs[k] = transfer.JSONInterface{Data: v}
+		}
+	}
+
+	b, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.url, bytes.NewBuffer(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(NotificationDeliveryHeader, n.DeliveryID)
+	if m.token != "" {
+		req.Header.Set(NotificationSignatureHeader, SignNotification(m.token, b))
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &UnexpectedResponseError{
+			StatusCode: resp.StatusCode,
+		}
+	}
+
+	return nil
+}
+
+type NotificationManagerOption func(m *NotificationManager)
+
+func NotificationManagerWithHTTPClient(client *http.Client) NotificationManagerOption {
+	return func(m *NotificationManager) {
+		m.client = client
+	}
+}
+
+func NewNotificationManager(url, token string, opts ...NotificationManagerOption) *NotificationManager {
+	m := &NotificationManager{
+		url:    url,
+		token:  token,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+type runIdentifierEnvelope struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Workflow  string `json:"workflow"`
+}
+
+type runNotificationEnvelope struct {
+	Run            *runIdentifierEnvelope            `json:"run"`
+	Outcome        string                            `json:"outcome"`
+	Message        string                            `json:"message,omitempty"`
+	StartTime      *time.Time                        `json:"start_time,omitempty"`
+	CompletionTime *time.Time                        `json:"completion_time,omitempty"`
+	Outputs        map[string]transfer.JSONInterface `json:"outputs,omitempty"`
+}
@@ -0,0 +1,78 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/puppetlabs/relay-core/pkg/manager/api"
+	"github.com/puppetlabs/relay-core/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationManager(t *testing.T) {
+	ctx := context.Background()
+
+	completed := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	n := &model.RunNotification{
+		DeliveryID:     "delivery-id",
+		Run:            model.Run{ID: "my-run"},
+		Namespace:      "my-namespace",
+		WorkflowName:   "my-workflow",
+		Outcome:        model.RunOutcomeSucceeded,
+		CompletionTime: &completed,
+		Outputs: map[string]interface{}{
+			"url": "https://example.com",
+		},
+	}
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		assert.Equal(t, "delivery-id", r.Header.Get(api.NotificationDeliveryHeader))
+		assert.Equal(t, api.SignNotification("token", b), r.Header.Get(api.NotificationSignatureHeader))
+
+		var env struct {
+			Run struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+				Workflow  string `json:"workflow"`
+			} `json:"run"`
+			Outcome        string                 `json:"outcome"`
+			CompletionTime time.Time              `json:"completion_time"`
+			Outputs        map[string]interface{} `json:"outputs"`
+		}
+		require.NoError(t, json.Unmarshal(b, &env))
+
+		assert.Equal(t, "my-run", env.Run.Name)
+		assert.Equal(t, "my-namespace", env.Run.Namespace)
+		assert.Equal(t, "my-workflow", env.Run.Workflow)
+		assert.Equal(t, "succeeded", env.Outcome)
+		assert.True(t, completed.Equal(env.CompletionTime))
+		assert.Equal(t, n.Outputs, env.Outputs)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer s.Close()
+
+	require.NoError(t, api.NewNotificationManager(s.URL, "token").Notify(ctx, n))
+
+	f := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer f.Close()
+
+	err := api.NewNotificationManager(f.URL, "token").Notify(ctx, n)
+	require.Error(t, err)
+
+	uerr, ok := err.(*api.UnexpectedResponseError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusServiceUnavailable, uerr.StatusCode)
+}
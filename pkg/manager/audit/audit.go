@@ -0,0 +1,127 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/puppetlabs/leg/logging"
+	metricsmodel "github.com/puppetlabs/relay-core/pkg/metrics/model"
+	"github.com/puppetlabs/relay-core/pkg/model"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	logger = logging.Builder().At("relay-core", "pkg", "manager", "audit")
+)
+
+func log(ctx context.Context) logging.Logger {
+	return logger.With(ctx).Build()
+}
+
+type Kind string
+
+const (
+	KindSecret     Kind = "secret"
+	KindConnection Kind = "connection"
+	KindOutput     Kind = "output"
+)
+
+type Outcome string
+
+const (
+	OutcomeGranted  Outcome = "granted"
+	OutcomeNotFound Outcome = "not_found"
+	OutcomeRejected Outcome = "rejected"
+	OutcomeError    Outcome = "error"
+)
+
+func outcomeOf(err error) Outcome {
+	switch err {
+	case nil:
+		return OutcomeGranted
+	case model.ErrNotFound:
+		return OutcomeNotFound
+	case model.ErrRejected:
+		return OutcomeRejected
+	default:
+		return OutcomeError
+	}
+}
+
+// Subject identifies the action that accesses sensitive data.
+type Subject struct {
+	Namespace  string `json:"namespace,omitempty"`
+	TenantID   string `json:"tenant_id,omitempty"`
+	RunID      string `json:"run_id,omitempty"`
+	ActionType string `json:"action_type,omitempty"`
+	ActionName string `json:"action_name,omitempty"`
+}
+
+// Record describes a single attempt to read a secret, a connection or a
+// sensitive output.
+type Record struct {
+	Time time.Time `json:"time"`
+	Subject
+	Kind Kind `json:"kind"`
+
+	// Name identifies the data that was read. Connections are named by their
+	// type and name, and outputs by their step and name, separated by a slash.
+	Name    string  `json:"name"`
+	Outcome Outcome `json:"outcome"`
+}
+
+// Sink stores audit records.
+type Sink interface {
+	Write(ctx context.Context, r *Record) error
+}
+
+type AuditorOption func(a *Auditor)
+
+// AuditorWithMeter counts audit records using the given meter.
+func AuditorWithMeter(meter metric.Meter) AuditorOption {
+	return func(a *Auditor) {
+		a.meter = meter
+	}
+}
+
+// Auditor sends audit records to a sink. Records that cannot be written are
+// logged but do not prevent access.
+type Auditor struct {
+	sink    Sink
+	meter   metric.Meter
+	records metric.Int64Counter
+}
+
+func (a *Auditor) Record(ctx context.Context, subject Subject, kind Kind, name string, outcome Outcome) {
+	r := &Record{
+		Time:    time.Now().UTC(),
+		Subject: subject,
+		Kind:    kind,
+		Name:    name,
+		Outcome: outcome,
+	}
+
+	a.records.Add(ctx, 1,
+		attribute.String(metricsmodel.MetricAttributeKind, string(kind)),
+		attribute.String(metricsmodel.MetricAttributeOutcome, string(outcome)),
+	)
+
+	if err := a.sink.Write(ctx, r); err != nil {
+		log(ctx).Error("failed to write audit record", "kind", kind, "name", name, "error", err)
+	}
+}
+
+func NewAuditor(sink Sink, opts ...AuditorOption) *Auditor {
+	a := &Auditor{
+		sink: sink,
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	a.records = metric.Must(a.meter).NewInt64Counter(metricsmodel.MetricAuditRecords)
+
+	return a
+}
@@ -0,0 +1,167 @@
+package audit
+
+import (
+	"context"
+	"path"
+
+	"github.com/puppetlabs/relay-core/pkg/model"
+)
+
+// SecretManager records every secret read from the delegate.
+type SecretManager struct {
+	delegate model.SecretManager
+	auditor  *Auditor
+	subject  Subject
+}
+
+var _ model.SecretManager = &SecretManager{}
+
+func (m *SecretManager) List(ctx context.Context) ([]*model.Secret, error) {
+	l, err := m.delegate.List(ctx)
+	if err != nil {
+		m.auditor.Record(ctx, m.subject, KindSecret, "", outcomeOf(err))
+		return nil, err
+	}
+
+	for _, s := range l {
+		m.auditor.Record(ctx, m.subject, KindSecret, s.Name, OutcomeGranted)
+	}
+
+	return l, nil
+}
+
+func (m *SecretManager) Get(ctx context.Context, name string) (*model.Secret, error) {
+	s, err := m.delegate.Get(ctx, name)
+	m.auditor.Record(ctx, m.subject, KindSecret, name, outcomeOf(err))
+	return s, err
+}
+
+func NewSecretManager(delegate model.SecretManager, auditor *Auditor, subject Subject) *SecretManager {
+	return &SecretManager{
+		delegate: delegate,
+		auditor:  auditor,
+		subject:  subject,
+	}
+}
+
+// ConnectionManager records every connection read from the delegate.
+type ConnectionManager struct {
+	delegate model.ConnectionManager
+	auditor  *Auditor
+	subject  Subject
+}
+
+var _ model.ConnectionManager = &ConnectionManager{}
+
+func (m *ConnectionManager) List(ctx context.Context) ([]*model.Connection, error) {
+	l, err := m.delegate.List(ctx)
+	if err != nil {
+		m.auditor.Record(ctx, m.subject, KindConnection, "", outcomeOf(err))
+		return nil, err
+	}
+
+	for _, c := range l {
+		m.auditor.Record(ctx, m.subject, KindConnection, path.Join(c.Type, c.Name), OutcomeGranted)
+	}
+
+	return l, nil
+}
+
+func (m *ConnectionManager) Get(ctx context.Context, typ, name string) (*model.Connection, error) {
+	c, err := m.delegate.Get(ctx, typ, name)
+	m.auditor.Record(ctx, m.subject, KindConnection, path.Join(typ, name), outcomeOf(err))
+	return c, err
+}
+
+func NewConnectionManager(delegate model.ConnectionManager, auditor *Auditor, subject Subject) *ConnectionManager {
+	return &ConnectionManager{
+		delegate: delegate,
+		auditor:  auditor,
+		subject:  subject,
+	}
+}
+
+// StepOutputManager records every sensitive output read from the delegate.
+// Outputs that are not sensitive, and failed reads whose sensitivity is not
+// known, are not recorded.
+type StepOutputManager struct {
+	model.StepOutputManager
+	auditor *Auditor
+	subject Subject
+}
+
+var _ model.StepOutputManager = &StepOutputManager{}
+
+func (m *StepOutputManager) List(ctx context.Context) ([]*model.StepOutput, error) {
+	l, err := m.StepOutputManager.List(ctx)
+	m.recordSensitive(ctx, l)
+	return l, err
+}
+
+func (m *StepOutputManager) ListSelf(ctx context.Context) ([]*model.StepOutput, error) {
+	l, err := m.StepOutputManager.ListSelf(ctx)
+	m.recordSensitive(ctx, l)
+	return l, err
+}
+
+func (m *StepOutputManager) Get(ctx context.Context, stepName, name string) (*model.StepOutput, error) {
+	o, err := m.StepOutputManager.Get(ctx, stepName, name)
+	if err == nil {
+		m.recordSensitive(ctx, []*model.StepOutput{o})
+	}
+	return o, err
+}
+
+func (m *StepOutputManager) recordSensitive(ctx context.Context, l []*model.StepOutput) {
+	for _, o := range l {
+		if o.Metadata == nil || !o.Metadata.Sensitive {
+			continue
+		}
+
+		var stepName string
+		if o.Step != nil {
+			stepName = o.Step.Name
+		}
+
+		m.auditor.Record(ctx, m.subject, KindOutput, path.Join(stepName, o.Name), OutcomeGranted)
+	}
+}
+
+func NewStepOutputManager(delegate model.StepOutputManager, auditor *Auditor, subject Subject) *StepOutputManager {
+	return &StepOutputManager{
+		StepOutputManager: delegate,
+		auditor:           auditor,
+		subject:           subject,
+	}
+}
+
+type metadataManagers struct {
+	model.MetadataManagers
+	secrets     model.SecretManager
+	connections model.ConnectionManager
+	stepOutputs model.StepOutputManager
+}
+
+func (mm *metadataManagers) Secrets() model.SecretManager {
+	return mm.secrets
+}
+
+func (mm *metadataManagers) Connections() model.ConnectionManager {
+	return mm.connections
+}
+
+func (mm *metadataManagers) StepOutputs() model.StepOutputManager {
+	return mm.stepOutputs
+}
+
+// NewMetadataManagers wraps the secret, connection and step output managers of
+// the given managers so that every read of sensitive data is recorded for the
+// given subject.
+func NewMetadataManagers(delegate model.MetadataManagers, auditor *Auditor, subject Subject) model.MetadataManagers {
+	return &metadataManagers{
+		MetadataManagers: delegate,
+		secrets:          NewSecretManager(delegate.Secrets(), auditor, subject),
+		connections:      NewConnectionManager(delegate.Connections(), auditor, subject),
+		stepOutputs:      NewStepOutputManager(delegate.StepOutputs(), auditor, subject),
+	}
+}
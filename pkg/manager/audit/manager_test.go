@@ -0,0 +1,183 @@
+package audit_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/puppetlabs/relay-core/pkg/manager/audit"
+	"github.com/puppetlabs/relay-core/pkg/manager/memory"
+	"github.com/puppetlabs/relay-core/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSink struct {
+	records []*audit.Record
+}
+
+func (s *recordingSink) Write(ctx context.Context, r *audit.Record) error {
+	s.records = append(s.records, r)
+	return nil
+}
+
+func (s *recordingSink) entries() [][3]string {
+	var entries [][3]string
+	for _, r := range s.records {
+		entries = append(entries, [3]string{string(r.Kind), r.Name, string(r.Outcome)})
+	}
+	return entries
+}
+
+var subject = audit.Subject{
+	Namespace:  "my-tenant",
+	TenantID:   "tenant-1",
+	RunID:      "run-1",
+	ActionType: "step",
+	ActionName: "deploy",
+}
+
+func TestSecretManager(t *testing.T) {
+	ctx := context.Background()
+
+	sink := &recordingSink{}
+	sm := audit.NewSecretManager(memory.NewSecretManager(map[string]string{"foo": "bar"}), audit.NewAuditor(sink), subject)
+
+	_, err := sm.Get(ctx, "foo")
+	require.NoError(t, err)
+
+	_, err = sm.Get(ctx, "nonexistent")
+	require.Equal(t, model.ErrNotFound, err)
+
+	_, err = sm.List(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, [][3]string{
+		{"secret", "foo", "granted"},
+		{"secret", "nonexistent", "not_found"},
+		{"secret", "foo", "granted"},
+	}, sink.entries())
+
+	for _, r := range sink.records {
+		assert.Equal(t, subject, r.Subject)
+		assert.False(t, r.Time.IsZero())
+	}
+}
+
+func TestConnectionManager(t *testing.T) {
+	ctx := context.Background()
+
+	sink := &recordingSink{}
+	cm := audit.NewConnectionManager(memory.NewConnectionManager(map[memory.ConnectionKey]map[string]interface{}{
+		{Type: "aws", Name: "test"}: {"accessKeyID": "AKIA"},
+	}), audit.NewAuditor(sink), subject)
+
+	_, err := cm.Get(ctx, "aws", "test")
+	require.NoError(t, err)
+
+	_, err = cm.Get(ctx, "gcp", "test")
+	require.Equal(t, model.ErrNotFound, err)
+
+	assert.Equal(t, [][3]string{
+		{"connection", "aws/test", "granted"},
+		{"connection", "gcp/test", "not_found"},
+	}, sink.entries())
+}
+
+func TestStepOutputManager(t *testing.T) {
+	ctx := context.Background()
+
+	run := model.Run{ID: "run-1"}
+	outputs := memory.NewStepOutputMap()
+
+	previous := memory.NewStepOutputManager(&model.Step{Run: run, Name: "previous"}, outputs)
+	require.NoError(t, previous.Set(ctx, "password", "hunter2"))
+	require.NoError(t, previous.SetMetadata(ctx, "password", &model.StepOutputMetadata{Sensitive: true}))
+	require.NoError(t, previous.Set(ctx, "greeting", "hello"))
+
+	sink := &recordingSink{}
+	om := audit.NewStepOutputManager(memory.NewStepOutputManager(&model.Step{Run: run, Name: "current"}, outputs), audit.NewAuditor(sink), subject)
+
+	_, err := om.Get(ctx, "previous", "password")
+	require.NoError(t, err)
+
+	_, err = om.Get(ctx, "previous", "greeting")
+	require.NoError(t, err)
+
+	_, err = om.List(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, [][3]string{
+		{"output", "previous/password", "granted"},
+		{"output", "previous/password", "granted"},
+	}, sink.entries())
+}
+
+func TestWriterSink(t *testing.T) {
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	sm := audit.NewSecretManager(memory.NewSecretManager(nil), audit.NewAuditor(audit.NewWriterSink(&buf)), subject)
+
+	_, err := sm.Get(ctx, "foo")
+	require.Equal(t, model.ErrNotFound, err)
+
+	var r map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &r))
+	assert.Equal(t, "tenant-1", r["tenant_id"])
+	assert.Equal(t, "run-1", r["run_id"])
+	assert.Equal(t, "step", r["action_type"])
+	assert.Equal(t, "deploy", r["action_name"])
+	assert.Equal(t, "secret", r["kind"])
+	assert.Equal(t, "foo", r["name"])
+	assert.Equal(t, "not_found", r["outcome"])
+	assert.Contains(t, r, "time")
+}
+
+func TestEventSink(t *testing.T) {
+	ctx := context.Background()
+
+	var records []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer my-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var record map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		records = append(records, record)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	r := &audit.Record{
+		Subject: subject,
+		Kind:    audit.KindSecret,
+		Name:    "foo",
+		Outcome: audit.OutcomeGranted,
+	}
+
+	require.NoError(t, audit.NewEventSink(srv.URL, audit.EventSinkWithToken("my-token")).Write(ctx, r))
+	require.Len(t, records, 1)
+	assert.Equal(t, "run-1", records[0]["run_id"])
+	assert.Equal(t, "secret", records[0]["kind"])
+	assert.Equal(t, "foo", records[0]["name"])
+	assert.Equal(t, "granted", records[0]["outcome"])
+
+	err := audit.NewEventSink(srv.URL).Write(ctx, r)
+	require.Equal(t, &audit.UnexpectedResponseError{StatusCode: http.StatusUnauthorized}, err)
+
+	// Records still reach the other sinks when one of them fails.
+	rs := &recordingSink{}
+	err = audit.NewMultiSink(audit.NewEventSink(srv.URL), rs).Write(ctx, r)
+	require.Error(t, err)
+	assert.Len(t, rs.records, 1)
+}
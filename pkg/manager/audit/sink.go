@@ -0,0 +1,139 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const DefaultEventSinkTimeout = 10 * time.Second
+
+// WriterSink writes audit records to a stream as JSON lines.
+type WriterSink struct {
+	mut sync.Mutex
+	enc *json.Encoder
+}
+
+var _ Sink = &WriterSink{}
+
+func (s *WriterSink) Write(ctx context.Context, r *Record) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	return s.enc.Encode(r)
+}
+
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{
+		enc: json.NewEncoder(w),
+	}
+}
+
+// UnexpectedResponseError is returned by an EventSink when the receiving
+// service does not accept a record.
+type UnexpectedResponseError struct {
+	StatusCode int
+}
+
+func (e *UnexpectedResponseError) Error() string {
+	return fmt.Sprintf("received HTTP %d from audit event sink", e.StatusCode)
+}
+
+type EventSinkOption func(s *EventSink)
+
+// EventSinkWithToken authenticates to the receiving service using the given
+// bearer token.
+func EventSinkWithToken(token string) EventSinkOption {
+	return func(s *EventSink) {
+		s.token = token
+	}
+}
+
+// EventSinkWithHTTPClient sends records using the given HTTP client instead
+// of one with DefaultEventSinkTimeout.
+func EventSinkWithHTTPClient(client *http.Client) EventSinkOption {
+	return func(s *EventSink) {
+		s.client = client
+	}
+}
+
+// EventSink sends each audit record to an HTTP service as a JSON object.
+type EventSink struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+var _ Sink = &EventSink{}
+
+func (s *EventSink) Write(ctx context.Context, r *Record) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.token))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &UnexpectedResponseError{StatusCode: resp.StatusCode}
+	}
+
+	return nil
+}
+
+func NewEventSink(url string, opts ...EventSinkOption) *EventSink {
+	s := &EventSink{
+		url:    url,
+		client: &http.Client{Timeout: DefaultEventSinkTimeout},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// MultiSink writes audit records to each of a list of sinks. It returns the
+// first error encountered, but always writes to every sink.
+type MultiSink struct {
+	sinks []Sink
+}
+
+var _ Sink = &MultiSink{}
+
+func (s *MultiSink) Write(ctx context.Context, r *Record) error {
+	var first error
+	for _, sink := range s.sinks {
+		if err := sink.Write(ctx, r); err != nil && first == nil {
+			first = err
+		}
+	}
+
+	return first
+}
+
+func NewMultiSink(sinks ...Sink) *MultiSink {
+	return &MultiSink{
+		sinks: sinks,
+	}
+}
@@ -6,21 +6,25 @@ import (
 )
 
 type metadataManagers struct {
-	actionMetadata model.ActionMetadataManager
-	actionStatus   model.ActionStatusManager
-	connections    model.ConnectionManager
-	conditions     model.ConditionGetterManager
-	events         model.EventManager
-	environment    model.EnvironmentGetterManager
-	logs           model.LogManager
-	parameters     model.ParameterGetterManager
-	secrets        model.SecretManager
-	spec           model.SpecGetterManager
-	state          model.StateGetterManager
-	stepDecorators model.StepDecoratorManager
-	stepMessages   model.StepMessageManager
-	stepOutputs    model.StepOutputManager
-	workflowRuns   model.WorkflowRunManager
+	actionMetadata  model.ActionMetadataManager
+	actionStatus    model.ActionStatusManager
+	artifacts       model.ArtifactManager
+	connections     model.ConnectionManager
+	conditions      model.ConditionGetterManager
+	events          model.EventManager
+	eventLimits     model.EventLimitsGetterManager
+	environment     model.EnvironmentGetterManager
+	logs            model.LogManager
+	parameters      model.ParameterGetterManager
+	secrets         model.SecretManager
+	spec            model.SpecGetterManager
+	state           model.StateManager
+	stepDecorators  model.StepDecoratorManager
+	stepMessages    model.StepMessageManager
+	stepOutputs     model.StepOutputManager
+	stepOutputDecls model.StepOutputDeclarationGetterManager
+	verification    model.WebhookVerificationGetterManager
+	workflowRuns    model.WorkflowRunManager
 }
 
 var _ model.MetadataManagers = &metadataManagers{}
@@ -33,6 +37,10 @@ func (mm *metadataManagers) ActionStatus() model.ActionStatusManager {
 	return mm.actionStatus
 }
 
+func (mm *metadataManagers) Artifacts() model.ArtifactManager {
+	return mm.artifacts
+}
+
 func (mm *metadataManagers) Connections() model.ConnectionManager {
 	return mm.connections
 }
@@ -45,6 +53,10 @@ func (mm *metadataManagers) Events() model.EventManager {
 	return mm.events
 }
 
+func (mm *metadataManagers) EventLimits() model.EventLimitsGetterManager {
+	return mm.eventLimits
+}
+
 func (mm *metadataManagers) Environment() model.EnvironmentGetterManager {
 	return mm.environment
 }
@@ -65,7 +77,7 @@ func (mm *metadataManagers) Spec() model.SpecGetterManager {
 	return mm.spec
 }
 
-func (mm *metadataManagers) State() model.StateGetterManager {
+func (mm *metadataManagers) State() model.StateManager {
 	return mm.state
 }
 
@@ -81,26 +93,38 @@ func (mm *metadataManagers) StepOutputs() model.StepOutputManager {
 	return mm.stepOutputs
 }
 
+func (mm *metadataManagers) StepOutputDeclarations() model.StepOutputDeclarationGetterManager {
+	return mm.stepOutputDecls
+}
+
+func (mm *metadataManagers) WebhookVerification() model.WebhookVerificationGetterManager {
+	return mm.verification
+}
+
 func (mm *metadataManagers) WorkflowRuns() model.WorkflowRunManager {
 	return mm.workflowRuns
 }
 
 type MetadataBuilder struct {
-	actionMetadata model.ActionMetadataManager
-	actionStatus   model.ActionStatusManager
-	connections    model.ConnectionManager
-	conditions     model.ConditionGetterManager
-	events         model.EventManager
-	environment    model.EnvironmentGetterManager
-	logs           model.LogManager
-	parameters     model.ParameterGetterManager
-	secrets        model.SecretManager
-	spec           model.SpecGetterManager
-	state          model.StateGetterManager
-	stepDecorators model.StepDecoratorManager
-	stepMessages   model.StepMessageManager
-	stepOutputs    model.StepOutputManager
-	workflowRuns   model.WorkflowRunManager
+	actionMetadata  model.ActionMetadataManager
+	actionStatus    model.ActionStatusManager
+	artifacts       model.ArtifactManager
+	connections     model.ConnectionManager
+	conditions      model.ConditionGetterManager
+	events          model.EventManager
+	eventLimits     model.EventLimitsGetterManager
+	environment     model.EnvironmentGetterManager
+	logs            model.LogManager
+	parameters      model.ParameterGetterManager
+	secrets         model.SecretManager
+	spec            model.SpecGetterManager
+	state           model.StateManager
+	stepDecorators  model.StepDecoratorManager
+	stepMessages    model.StepMessageManager
+	stepOutputs     model.StepOutputManager
+	stepOutputDecls model.StepOutputDeclarationGetterManager
+	verification    model.WebhookVerificationGetterManager
+	workflowRuns    model.WorkflowRunManager
 }
 
 func (mb *MetadataBuilder) SetActionMetadata(m model.ActionMetadataManager) *MetadataBuilder {
@@ -113,6 +137,11 @@ func (mb *MetadataBuilder) SetActionStatus(m model.ActionStatusManager) *Metadat
 	return mb
 }
 
+func (mb *MetadataBuilder) SetArtifacts(m model.ArtifactManager) *MetadataBuilder {
+	mb.artifacts = m
+	return mb
+}
+
 func (mb *MetadataBuilder) SetConnections(m model.ConnectionManager) *MetadataBuilder {
 	mb.connections = m
 	return mb
@@ -128,6 +157,11 @@ func (mb *MetadataBuilder) SetEvents(m model.EventManager) *MetadataBuilder {
 	return mb
 }
 
+func (mb *MetadataBuilder) SetEventLimits(m model.EventLimitsGetterManager) *MetadataBuilder {
+	mb.eventLimits = m
+	return mb
+}
+
 func (mb *MetadataBuilder) SetEnvironment(m model.EnvironmentGetterManager) *MetadataBuilder {
 	mb.environment = m
 	return mb
@@ -153,7 +187,7 @@ func (mb *MetadataBuilder) SetSpec(m model.SpecGetterManager) *MetadataBuilder {
 	return mb
 }
 
-func (mb *MetadataBuilder) SetState(m model.StateGetterManager) *MetadataBuilder {
+func (mb *MetadataBuilder) SetState(m model.StateManager) *MetadataBuilder {
 	mb.state = m
 	return mb
 }
@@ -173,6 +207,16 @@ func (mb *MetadataBuilder) SetStepOutputs(m model.StepOutputManager) *MetadataBu
 	return mb
 }
 
+func (mb *MetadataBuilder) SetStepOutputDeclarations(m model.StepOutputDeclarationGetterManager) *MetadataBuilder {
+	mb.stepOutputDecls = m
+	return mb
+}
+
+func (mb *MetadataBuilder) SetWebhookVerification(m model.WebhookVerificationGetterManager) *MetadataBuilder {
+	mb.verification = m
+	return mb
+}
+
 func (mb *MetadataBuilder) SetWorkflowRuns(m model.WorkflowRunManager) *MetadataBuilder {
 	mb.workflowRuns = m
 	return mb
@@ -180,40 +224,48 @@ func (mb *MetadataBuilder) SetWorkflowRuns(m model.WorkflowRunManager) *Metadata
 
 func (mb *MetadataBuilder) Build() model.MetadataManagers {
 	return &metadataManagers{
-		actionMetadata: mb.actionMetadata,
-		actionStatus:   mb.actionStatus,
-		connections:    mb.connections,
-		conditions:     mb.conditions,
-		events:         mb.events,
-		environment:    mb.environment,
-		logs:           mb.logs,
-		parameters:     mb.parameters,
-		secrets:        mb.secrets,
-		spec:           mb.spec,
-		state:          mb.state,
-		stepDecorators: mb.stepDecorators,
-		stepMessages:   mb.stepMessages,
-		stepOutputs:    mb.stepOutputs,
-		workflowRuns:   mb.workflowRuns,
+		actionMetadata:  mb.actionMetadata,
+		actionStatus:    mb.actionStatus,
+		artifacts:       mb.artifacts,
+		connections:     mb.connections,
+		conditions:      mb.conditions,
+		events:          mb.events,
+		eventLimits:     mb.eventLimits,
+		environment:     mb.environment,
+		logs:            mb.logs,
+		parameters:      mb.parameters,
+		secrets:         mb.secrets,
+		spec:            mb.spec,
+		state:           mb.state,
+		stepDecorators:  mb.stepDecorators,
+		stepMessages:    mb.stepMessages,
+		stepOutputs:     mb.stepOutputs,
+		stepOutputDecls: mb.stepOutputDecls,
+		verification:    mb.verification,
+		workflowRuns:    mb.workflowRuns,
 	}
 }
 
 func NewMetadataBuilder() *MetadataBuilder {
 	return &MetadataBuilder{
-		actionStatus:   reject.ActionStatusManager,
-		actionMetadata: reject.ActionMetadataManager,
-		connections:    reject.ConnectionManager,
-		conditions:     reject.ConditionManager,
-		events:         reject.EventManager,
-		environment:    reject.EnvironmentManager,
-		logs:           reject.LogManager,
-		parameters:     reject.ParameterManager,
-		secrets:        reject.SecretManager,
-		spec:           reject.SpecManager,
-		state:          reject.StateManager,
-		stepDecorators: reject.StepDecoratorManager,
-		stepMessages:   reject.StepMessageManager,
-		stepOutputs:    reject.StepOutputManager,
-		workflowRuns:   reject.WorkflowRunManager,
+		actionStatus:    reject.ActionStatusManager,
+		actionMetadata:  reject.ActionMetadataManager,
+		artifacts:       reject.ArtifactManager,
+		connections:     reject.ConnectionManager,
+		conditions:      reject.ConditionManager,
+		events:          reject.EventManager,
+		eventLimits:     reject.EventLimitsManager,
+		environment:     reject.EnvironmentManager,
+		logs:            reject.LogManager,
+		parameters:      reject.ParameterManager,
+		secrets:         reject.SecretManager,
+		spec:            reject.SpecManager,
+		state:           reject.StateManager,
+		stepDecorators:  reject.StepDecoratorManager,
+		stepMessages:    reject.StepMessageManager,
+		stepOutputs:     reject.StepOutputManager,
+		stepOutputDecls: reject.StepOutputDeclarationManager,
+		verification:    reject.WebhookVerificationManager,
+		workflowRuns:    reject.WorkflowRunManager,
 	}
 }
@@ -0,0 +1,275 @@
+package configmap
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/puppetlabs/leg/storage"
+	"github.com/puppetlabs/relay-core/pkg/model"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// artifactIndexEntry is the record of an uploaded artifact kept in the
+// configuration map. The blob itself lives in the blob store under Key.
+type artifactIndexEntry struct {
+	ContentType string `json:"contentType,omitempty"`
+	Size        int64  `json:"size"`
+	Digest      string `json:"digest"`
+	Key         string `json:"key"`
+}
+
+type ArtifactManagerOption func(am *ArtifactManager)
+
+// ArtifactManagerWithKeyPrefix sets the prefix used for blob store keys of
+// newly uploaded artifacts.
+func ArtifactManagerWithKeyPrefix(prefix string) ArtifactManagerOption {
+	return func(am *ArtifactManager) {
+		am.prefix = prefix
+	}
+}
+
+// ArtifactManager indexes artifacts in a configuration map and stores their
+// content in a blob store. If no blob store is configured, the manager can
+// only list artifacts.
+type ArtifactManager struct {
+	me     *model.Step
+	kcm    *KVConfigMap
+	bs     storage.BlobStore
+	prefix string
+}
+
+var _ model.ArtifactManager = &ArtifactManager{}
+
+func (m *ArtifactManager) List(ctx context.Context) ([]*model.Artifact, error) {
+	am, err := m.kcm.List(ctx, fmt.Sprintf("%s.", model.ActionTypeStep.Plural))
+	if err != nil {
+		return nil, err
+	}
+
+	var l []*model.Artifact
+
+	for key, value := range am {
+		parts := strings.SplitN(key, ".", 3)
+		if len(parts) != 3 || parts[1] != "artifact" {
+			continue
+		}
+
+		stepHash, name := parts[0], parts[2]
+
+		stepNameRaw, err := m.kcm.Get(ctx, fmt.Sprintf("%s.%s.name", model.ActionTypeStep.Plural, stepHash))
+		if err == model.ErrNotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		stepName, ok := stepNameRaw.(string)
+		if !ok {
+			continue
+		}
+
+		entry, err := decodeArtifactIndexEntry(value)
+		if err != nil {
+			return nil, err
+		}
+
+		l = append(l, entry.artifact(&model.Step{Run: m.me.Run, Name: stepName}, name))
+	}
+
+	return l, nil
+}
+
+func (m *ArtifactManager) ListSelf(ctx context.Context) ([]*model.Artifact, error) {
+	am, err := m.kcm.List(ctx, fmt.Sprintf("%s.%s.artifact.", model.ActionTypeStep.Plural, m.me.Hash().HexEncoding()))
+	if err != nil {
+		return nil, err
+	}
+
+	var l []*model.Artifact
+
+	for name, value := range am {
+		entry, err := decodeArtifactIndexEntry(value)
+		if err != nil {
+			return nil, err
+		}
+
+		l = append(l, entry.artifact(m.me, name))
+	}
+
+	return l, nil
+}
+
+func (m *ArtifactManager) Get(ctx context.Context, stepName, name string, fn func(a *model.Artifact, r io.Reader) error) error {
+	if m.bs == nil {
+		return model.ErrRejected
+	}
+
+	step := &model.Step{
+		Run:  m.me.Run,
+		Name: stepName,
+	}
+
+	entry, err := m.lookupArtifactIndexEntry(ctx, step, name)
+	if err != nil {
+		return err
+	}
+
+	err = m.bs.Get(ctx, entry.Key, func(meta *storage.Meta, r io.Reader) error {
+		return fn(entry.artifact(step, name), r)
+	}, storage.GetOptions{})
+	if storage.IsNotFoundError(err) {
+		return model.ErrNotFound
+	}
+
+	return err
+}
+
+func (m *ArtifactManager) Put(ctx context.Context, name, contentType string, r io.Reader) (*model.Artifact, error) {
+	if m.bs == nil {
+		return nil, model.ErrRejected
+	}
+
+	// The name becomes part of a configuration map key, so we have to check
+	// it before we write anything to the blob store.
+	if errs := validation.IsConfigMapKey(stepArtifactKey(m.me, name)); len(errs) > 0 {
+		return nil, &model.ArtifactNameError{Name: name, Reason: strings.Join(errs, "; ")}
+	}
+
+	entry := &artifactIndexEntry{
+		ContentType: contentType,
+		Key:         ArtifactBlobKey(m.prefix, m.me, name),
+	}
+
+	h := sha256.New()
+	cw := &artifactCountingWriter{h: h}
+
+	if err := m.bs.Put(ctx, entry.Key, func(w io.Writer) error {
+		_, err := io.Copy(io.MultiWriter(w, cw), r)
+		return err
+	}, storage.PutOptions{ContentType: contentType}); err != nil {
+		return nil, err
+	}
+
+	entry.Size = cw.n
+	entry.Digest = "sha256:" + hex.EncodeToString(h.Sum(nil))
+
+	if err := m.setIndexEntry(ctx, name, entry); err != nil {
+		// Without an index entry, nothing would ever clean up the blob.
+		if derr := m.bs.Delete(ctx, entry.Key, storage.DeleteOptions{}); derr != nil && !storage.IsNotFoundError(derr) {
+			return nil, fmt.Errorf("%w (additionally, the artifact content could not be removed: %v)", err, derr)
+		}
+
+		return nil, err
+	}
+
+	return entry.artifact(m.me, name), nil
+}
+
+// DeleteAll removes the content of every artifact uploaded by this step from
+// the blob store.
+func (m *ArtifactManager) DeleteAll(ctx context.Context) error {
+	if m.bs == nil {
+		return model.ErrRejected
+	}
+
+	am, err := m.kcm.List(ctx, fmt.Sprintf("%s.%s.artifact.", model.ActionTypeStep.Plural, m.me.Hash().HexEncoding()))
+	if err != nil {
+		return err
+	}
+
+	for _, value := range am {
+		entry, err := decodeArtifactIndexEntry(value)
+		if err != nil {
+			return err
+		}
+
+		if err := m.bs.Delete(ctx, entry.Key, storage.DeleteOptions{}); err != nil && !storage.IsNotFoundError(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *ArtifactManager) setIndexEntry(ctx context.Context, name string, entry *artifactIndexEntry) error {
+	if err := m.kcm.Set(ctx, stepNameKey(m.me), m.me.Name); err != nil {
+		return err
+	}
+
+	return m.kcm.Set(ctx, stepArtifactKey(m.me, name), entry)
+}
+
+func (m *ArtifactManager) lookupArtifactIndexEntry(ctx context.Context, step *model.Step, name string) (*artifactIndexEntry, error) {
+	value, err := m.kcm.Get(ctx, stepArtifactKey(step, name))
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeArtifactIndexEntry(value)
+}
+
+func NewArtifactManager(step *model.Step, cm ConfigMap, bs storage.BlobStore, opts ...ArtifactManagerOption) *ArtifactManager {
+	am := &ArtifactManager{
+		me:  step,
+		kcm: NewKVConfigMap(cm),
+		bs:  bs,
+	}
+
+	for _, opt := range opts {
+		opt(am)
+	}
+
+	return am
+}
+
+func (e *artifactIndexEntry) artifact(step *model.Step, name string) *model.Artifact {
+	return &model.Artifact{
+		Step:        step,
+		Name:        name,
+		ContentType: e.ContentType,
+		Size:        e.Size,
+		Digest:      e.Digest,
+	}
+}
+
+func decodeArtifactIndexEntry(value interface{}) (*artifactIndexEntry, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &artifactIndexEntry{}
+	if err := json.Unmarshal(b, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+type artifactCountingWriter struct {
+	h hash.Hash
+	n int64
+}
+
+func (w *artifactCountingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return w.h.Write(p)
+}
+
+// ArtifactBlobKey returns the blob store key for the content of the given
+// artifact uploaded by a step.
+func ArtifactBlobKey(prefix string, step *model.Step, name string) string {
+	return path.Join(prefix, step.Hash().HexEncoding(), url.PathEscape(name))
+}
+
+func stepArtifactKey(step *model.Step, name string) string {
+	return fmt.Sprintf("%s.%s.artifact.%s", step.Type().Plural, step.Hash(), name)
+}
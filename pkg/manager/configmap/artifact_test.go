@@ -0,0 +1,135 @@
+package configmap_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/puppetlabs/leg/storage"
+	_ "github.com/puppetlabs/leg/storage/file"
+	"github.com/puppetlabs/relay-core/pkg/manager/configmap"
+	"github.com/puppetlabs/relay-core/pkg/model"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type failingConfigMap struct {
+	*configmap.LocalConfigMap
+}
+
+func (failingConfigMap) CreateOrUpdate(ctx context.Context, cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	return nil, errors.New("configmap is too large")
+}
+
+func TestArtifactManager(t *testing.T) {
+	ctx := context.Background()
+
+	bs, err := storage.NewBlobStore(url.URL{Scheme: "file", Path: t.TempDir()})
+	require.NoError(t, err)
+
+	step1 := &model.Step{
+		Run:  model.Run{ID: "foo"},
+		Name: "bar",
+	}
+	step2 := &model.Step{
+		Run:  model.Run{ID: "foo"},
+		Name: "baz",
+	}
+
+	obj := &corev1.ConfigMap{}
+	am1 := configmap.NewArtifactManager(step1, configmap.NewLocalConfigMap(obj), bs, configmap.ArtifactManagerWithKeyPrefix("artifacts/foo"))
+	am2 := configmap.NewArtifactManager(step2, configmap.NewLocalConfigMap(obj), bs, configmap.ArtifactManagerWithKeyPrefix("artifacts/foo"))
+
+	a, err := am1.Put(ctx, "report.txt", "text/plain", strings.NewReader("hello"))
+	require.NoError(t, err)
+	require.Equal(t, &model.Artifact{
+		Step:        step1,
+		Name:        "report.txt",
+		ContentType: "text/plain",
+		Size:        5,
+		Digest:      "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+	}, a)
+
+	as, err := am1.ListSelf(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*model.Artifact{a}, as)
+
+	as, err = am2.ListSelf(ctx)
+	require.NoError(t, err)
+	require.Empty(t, as)
+
+	as, err = am2.List(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*model.Artifact{a}, as)
+
+	require.NoError(t, am2.Get(ctx, step1.Name, "report.txt", func(ga *model.Artifact, r io.Reader) error {
+		require.Equal(t, a, ga)
+
+		b, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, "hello", string(b))
+		return nil
+	}))
+
+	err = am2.Get(ctx, step2.Name, "report.txt", func(ga *model.Artifact, r io.Reader) error { return nil })
+	require.Equal(t, model.ErrNotFound, err)
+
+	require.NoError(t, am1.DeleteAll(ctx))
+
+	err = am2.Get(ctx, step1.Name, "report.txt", func(ga *model.Artifact, r io.Reader) error { return nil })
+	require.Equal(t, model.ErrNotFound, err)
+
+	// Without a blob store, artifacts can be listed but not transferred.
+	lam := configmap.NewArtifactManager(step1, configmap.NewLocalConfigMap(obj), nil)
+
+	as, err = lam.ListSelf(ctx)
+	require.NoError(t, err)
+	require.Len(t, as, 1)
+
+	_, err = lam.Put(ctx, "other.txt", "", strings.NewReader(""))
+	require.Equal(t, model.ErrRejected, err)
+}
+
+func TestArtifactManagerPutCleansUp(t *testing.T) {
+	ctx := context.Background()
+
+	bs, err := storage.NewBlobStore(url.URL{Scheme: "file", Path: t.TempDir()})
+	require.NoError(t, err)
+
+	step := &model.Step{
+		Run:  model.Run{ID: "foo"},
+		Name: "bar",
+	}
+
+	blobExists := func(name string) bool {
+		err := bs.Get(ctx, configmap.ArtifactBlobKey("artifacts/foo", step, name), func(meta *storage.Meta, r io.Reader) error {
+			return nil
+		}, storage.GetOptions{})
+		if storage.IsNotFoundError(err) {
+			return false
+		}
+		require.NoError(t, err)
+		return true
+	}
+
+	// Names that cannot be stored in the index are rejected before any content
+	// is written.
+	am := configmap.NewArtifactManager(step, configmap.NewLocalConfigMap(&corev1.ConfigMap{}), bs, configmap.ArtifactManagerWithKeyPrefix("artifacts/foo"))
+
+	_, err = am.Put(ctx, "my report.txt", "text/plain", strings.NewReader("hello"))
+	var ane *model.ArtifactNameError
+	require.ErrorAs(t, err, &ane)
+	require.Equal(t, "my report.txt", ane.Name)
+	require.False(t, blobExists("my report.txt"))
+
+	// If the index cannot be written, the content is removed again.
+	fam := configmap.NewArtifactManager(step, failingConfigMap{configmap.NewLocalConfigMap(&corev1.ConfigMap{})}, bs, configmap.ArtifactManagerWithKeyPrefix("artifacts/foo"))
+
+	_, err = fam.Put(ctx, "report.txt", "text/plain", strings.NewReader("hello"))
+	require.EqualError(t, err, "configmap is too large")
+	require.False(t, blobExists("report.txt"))
+}
@@ -0,0 +1,527 @@
+package configmap
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/puppetlabs/leg/encoding/transfer"
+	metricsmodel "github.com/puppetlabs/relay-core/pkg/metrics/model"
+	"github.com/puppetlabs/relay-core/pkg/model"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	DefaultEventDeduplicationWindow = time.Hour
+	DefaultEventMaxPending          = 100
+	DefaultEventMaxAttempts         = 10
+
+	// DefaultEventMaxSize and DefaultEventMaxPendingSize, together with
+	// DefaultEventMaxHistorySize, keep the events of a trigger well within
+	// the 1 MiB limit on the size of a configuration map.
+	DefaultEventMaxSize        = 64 * 1024
+	DefaultEventMaxPendingSize = 256 * 1024
+
+	eventRedeliveryInitialBackoff = 10 * time.Second
+	eventRedeliveryMaxBackoff     = 10 * time.Minute
+)
+
+// EventRedeliveryBackoff returns the amount of time to wait after the given
+// number of failed delivery attempts before sending a pending event again.
+func EventRedeliveryBackoff(attempts int) time.Duration {
+	backoff := eventRedeliveryInitialBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= eventRedeliveryMaxBackoff {
+			return eventRedeliveryMaxBackoff
+		}
+	}
+
+	return backoff
+}
+
+// EventRedelivery is the outcome of an attempt to send a pending event again.
+type EventRedelivery struct {
+	Event *model.PendingEvent
+	Error error
+
+	// Dropped is true if the event failed and will not be sent again.
+	Dropped bool
+}
+
+type pendingEventEntry struct {
+	Data            map[string]interface{} `json:"data"`
+	Key             string                 `json:"key,omitempty"`
+	AcceptTime      time.Time              `json:"acceptTime"`
+	Attempts        int                    `json:"attempts"`
+	LastAttemptTime time.Time              `json:"lastAttemptTime"`
+	LastError       string                 `json:"lastError,omitempty"`
+}
+
+type EventManagerOption func(em *EventManager)
+
+// EventManagerWithDeduplicationWindow sets how long the key of an accepted
+// event is remembered. Events with a key seen within the window are discarded.
+// A window of zero disables deduplication.
+func EventManagerWithDeduplicationWindow(window time.Duration) EventManagerOption {
+	return func(em *EventManager) {
+		em.window = window
+	}
+}
+
+// EventManagerWithMaxPending sets the number of undelivered events to keep
+// before new events are rejected.
+func EventManagerWithMaxPending(n int) EventManagerOption {
+	return func(em *EventManager) {
+		em.maxPending = n
+	}
+}
+
+// EventManagerWithMaxPendingSize sets the number of bytes the undelivered
+// events may take up in the configuration map before new events are
+// rejected.
+func EventManagerWithMaxPendingSize(n int) EventManagerOption {
+	return func(em *EventManager) {
+		em.maxPendingSize = n
+	}
+}
+
+// EventManagerWithMaxSize sets the largest encoded event data, in bytes, to
+// keep in the configuration map. Larger events cannot be kept for
+// redelivery, and only their outcome is recorded in the history.
+func EventManagerWithMaxSize(n int) EventManagerOption {
+	return func(em *EventManager) {
+		em.maxSize = n
+	}
+}
+
+// EventManagerWithMaxAttempts sets the number of times to try to deliver an
+// event before giving up on it.
+func EventManagerWithMaxAttempts(n int) EventManagerOption {
+	return func(em *EventManager) {
+		em.maxAttempts = n
+	}
+}
+
+// EventManagerWithMeter records delivery metrics using the given meter.
+func EventManagerWithMeter(meter metric.Meter) EventManagerOption {
+	return func(em *EventManager) {
+		em.meter = meter
+	}
+}
+
+// EventManager deduplicates the events emitted by a trigger and hands them to
+// a delegate for delivery. Events the delegate could not deliver because of a
+// transient failure are kept in the configuration map until they are sent
+// again by RedeliverPending.
+type EventManager struct {
+	me       model.Action
+	cm       ConfigMap
+	kcm      *KVConfigMap
+	delegate model.EventManager
+	meter    metric.Meter
+
+	window         time.Duration
+	maxSize        int
+	maxPending     int
+	maxPendingSize int
+	maxAttempts    int
+	maxHistory     int
+	maxHistorySize int
+}
+
+var _ model.EventManager = &EventManager{}
+
+func (m *EventManager) Emit(ctx context.Context, data map[string]interface{}, key string) (*model.Event, error) {
+	if _, ok := m.me.(*model.Trigger); !ok {
+		return nil, model.ErrRejected
+	}
+
+	ev := &model.Event{
+		Data: data,
+		Key:  key,
+	}
+
+	dedup := key != "" && m.window > 0
+	if dedup {
+		if dup, err := m.markSeen(ctx, key); err != nil {
+			return nil, err
+		} else if dup {
+			metric.Must(m.meter).NewInt64Counter(metricsmodel.MetricTriggerEventDuplicates).Add(ctx, 1)
+			return ev, nil
+		}
+	}
+
+	if _, err := m.deliver(ctx, ev); err != nil {
+		// The event was not accepted, so the caller should be able to send it
+		// again with the same key.
+		if dedup {
+			if ferr := m.forgetSeen(ctx, key); ferr != nil {
+				return nil, fmt.Errorf("%w (additionally, the event key could not be released: %v)", err, ferr)
+			}
+		}
+
+		return nil, err
+	}
+
+	return ev, nil
+}
+
+// deliver sends an event to the delegate and records it in the history. If
+// the delegate fails transiently, the event is kept for redelivery and no
+// error is returned.
+func (m *EventManager) deliver(ctx context.Context, ev *model.Event) (*model.RecordedEvent, error) {
+	re := m.newRecordedEvent(ev)
+
+	_, err := m.delegate.Emit(ctx, ev.Data, ev.Key)
+	m.recordAttempt(ctx, err)
+	if err == nil {
+		re.Outcome = model.EventOutcomeDelivered
+
+		// The event reached the sink, so failing to record it must not cause
+		// the caller to send it again.
+		_, _ = MutateConfigMap(ctx, m.cm, func(cm *corev1.ConfigMap) {
+			m.recordEvent(cm, re)
+		})
+
+		return re, nil
+	}
+
+	re.Error = err.Error()
+
+	var de *model.EventDeliveryError
+	if errors.As(err, &de) {
+		if err := m.enqueue(ctx, re, de); err != nil {
+			return re, err
+		}
+
+		return re, nil
+	}
+
+	m.recordFailure(ctx)
+
+	re.Outcome = model.EventOutcomeRejected
+	if _, rerr := MutateConfigMap(ctx, m.cm, func(cm *corev1.ConfigMap) {
+		m.recordEvent(cm, re)
+	}); rerr != nil {
+		return re, fmt.Errorf("%w (additionally, the event could not be recorded: %v)", err, rerr)
+	}
+
+	return re, err
+}
+
+// RedeliverPending sends each pending event whose backoff has elapsed to the
+// delegate again, stopping at the first transient failure. It returns the
+// outcome of each attempt and, if any events remain pending, how long to wait
+// until the next one is due.
+func (m *EventManager) RedeliverPending(ctx context.Context) ([]*EventRedelivery, time.Duration, error) {
+	entries, err := m.kcm.List(ctx, pendingEventKeyPrefix(m.me))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pending := make([]*model.PendingEvent, 0, len(entries))
+	for id, value := range entries {
+		pe, err := decodePendingEvent(id, value)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		pending = append(pending, pe)
+	}
+
+	// Deliver in the order the events were originally accepted.
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].AcceptTime.Before(pending[j].AcceptTime)
+	})
+
+	var rs []*EventRedelivery
+	var next time.Duration
+
+	due := func(wait time.Duration) {
+		if next == 0 || wait < next {
+			next = wait
+		}
+	}
+
+	for _, pe := range pending {
+		if wait := time.Until(pe.LastAttemptTime.Add(EventRedeliveryBackoff(pe.Attempts))); wait > 0 {
+			due(wait)
+			continue
+		}
+
+		_, err := m.delegate.Emit(ctx, pe.Event.Data, pe.Event.Key)
+		m.recordAttempt(ctx, err)
+
+		pe.Attempts++
+		pe.LastAttemptTime = time.Now().UTC()
+
+		r := &EventRedelivery{Event: pe, Error: err}
+		rs = append(rs, r)
+
+		var de *model.EventDeliveryError
+		if err != nil && errors.As(err, &de) && pe.Attempts < m.maxAttempts {
+			pe.LastError = err.Error()
+
+			if err := m.kcm.Set(ctx, pendingEventKey(m.me, pe.ID), pendingEventEntryFor(pe)); err != nil {
+				return rs, 0, err
+			}
+
+			// The sink is probably still unavailable, so there is no point in
+			// trying the rest of the events right now.
+			due(EventRedeliveryBackoff(pe.Attempts))
+			break
+		}
+
+		outcome := model.EventOutcomeDelivered
+		if err != nil {
+			r.Dropped = true
+			m.recordFailure(ctx)
+
+			outcome = model.EventOutcomeRejected
+		}
+
+		if _, merr := MutateConfigMap(ctx, m.cm, func(cm *corev1.ConfigMap) {
+			delete(cm.Data, pendingEventKey(m.me, pe.ID))
+			m.updateRecordedEvent(cm, pe.ID, outcome, err)
+		}); merr != nil {
+			return rs, 0, merr
+		}
+	}
+
+	return rs, next, nil
+}
+
+func (m *EventManager) markSeen(ctx context.Context, key string) (bool, error) {
+	now := time.Now().UTC()
+
+	encoded, err := json.Marshal(transfer.JSONInterface{Data: now.Format(time.RFC3339Nano)})
+	if err != nil {
+		return false, err
+	}
+
+	prefix := seenEventKeyPrefix(m.me)
+	target := seenEventKey(m.me, key)
+
+	var dup bool
+	if _, err := MutateConfigMap(ctx, m.cm, func(cm *corev1.ConfigMap) {
+		dup = false
+
+		// Take the opportunity to forget any keys that have expired.
+		for k, v := range cm.Data {
+			if !strings.HasPrefix(k, prefix) {
+				continue
+			}
+
+			if seen, ok := decodeSeenTime(v); !ok || now.Sub(seen) >= m.window {
+				delete(cm.Data, k)
+			}
+		}
+
+		if _, found := cm.Data[target]; found {
+			dup = true
+			return
+		}
+
+		cm.Data[target] = string(encoded)
+	}); err != nil {
+		return false, err
+	}
+
+	return dup, nil
+}
+
+func (m *EventManager) forgetSeen(ctx context.Context, key string) error {
+	_, err := MutateConfigMap(ctx, m.cm, func(cm *corev1.ConfigMap) {
+		delete(cm.Data, seenEventKey(m.me, key))
+	})
+	return err
+}
+
+func (m *EventManager) enqueue(ctx context.Context, re *model.RecordedEvent, cause error) error {
+	pe := &model.PendingEvent{
+		ID:              re.ID,
+		Event:           re.Event,
+		AcceptTime:      re.Time,
+		Attempts:        1,
+		LastAttemptTime: re.Time,
+		LastError:       cause.Error(),
+	}
+
+	encoded, err := json.Marshal(transfer.JSONInterface{Data: pendingEventEntryFor(pe)})
+	if err != nil {
+		return err
+	}
+
+	key := pendingEventKey(m.me, pe.ID)
+	prefix := pendingEventKeyPrefix(m.me)
+
+	var reason string
+	if _, err := MutateConfigMap(ctx, m.cm, func(cm *corev1.ConfigMap) {
+		var n, size int
+		for k, v := range cm.Data {
+			if strings.HasPrefix(k, prefix) {
+				n++
+				size += len(k) + len(v)
+			}
+		}
+
+		switch {
+		case len(encoded) > m.maxSize:
+			reason = "the event is too large to keep for redelivery"
+		case n >= m.maxPending:
+			reason = "too many events are already waiting for redelivery"
+		case size+len(key)+len(encoded) > m.maxPendingSize:
+			reason = "the events already waiting for redelivery are too large"
+		default:
+			reason = ""
+		}
+
+		if reason != "" {
+			re.Outcome = model.EventOutcomeRejected
+		} else {
+			re.Outcome = model.EventOutcomePending
+			cm.Data[key] = string(encoded)
+		}
+
+		m.recordEvent(cm, re)
+	}); err != nil {
+		return fmt.Errorf("%w (additionally, the event could not be kept for redelivery: %v)", cause, err)
+	} else if reason != "" {
+		return fmt.Errorf("%w (additionally, %s)", cause, reason)
+	}
+
+	return nil
+}
+
+func (m *EventManager) recordAttempt(ctx context.Context, err error) {
+	outcome := metricsmodel.TriggerEventDeliveryOutcomeDelivered
+	if err != nil {
+		outcome = metricsmodel.TriggerEventDeliveryOutcomeFailed
+	}
+
+	metric.Must(m.meter).NewInt64Counter(metricsmodel.MetricTriggerEventDeliveryAttempts).Add(ctx, 1,
+		attribute.String(metricsmodel.MetricAttributeOutcome, outcome),
+	)
+}
+
+func (m *EventManager) recordFailure(ctx context.Context) {
+	metric.Must(m.meter).NewInt64Counter(metricsmodel.MetricTriggerEventDeliveryFailures).Add(ctx, 1)
+}
+
+func NewEventManager(action model.Action, cm ConfigMap, delegate model.EventManager, opts ...EventManagerOption) *EventManager {
+	em := &EventManager{
+		me:             action,
+		cm:             cm,
+		kcm:            NewKVConfigMap(cm),
+		delegate:       delegate,
+		window:         DefaultEventDeduplicationWindow,
+		maxSize:        DefaultEventMaxSize,
+		maxPending:     DefaultEventMaxPending,
+		maxPendingSize: DefaultEventMaxPendingSize,
+		maxAttempts:    DefaultEventMaxAttempts,
+		maxHistory:     DefaultEventMaxHistory,
+		maxHistorySize: DefaultEventMaxHistorySize,
+	}
+
+	for _, opt := range opts {
+		opt(em)
+	}
+
+	return em
+}
+
+// HasPendingEvents returns true if the given configuration map contains any
+// events waiting to be redelivered.
+func HasPendingEvents(cm *corev1.ConfigMap) bool {
+	for k := range cm.Data {
+		if strings.Contains(k, ".event.pending.") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func pendingEventEntryFor(pe *model.PendingEvent) *pendingEventEntry {
+	return &pendingEventEntry{
+		Data:            pe.Event.Data,
+		Key:             pe.Event.Key,
+		AcceptTime:      pe.AcceptTime,
+		Attempts:        pe.Attempts,
+		LastAttemptTime: pe.LastAttemptTime,
+		LastError:       pe.LastError,
+	}
+}
+
+func decodePendingEvent(id string, value interface{}) (*model.PendingEvent, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &pendingEventEntry{}
+	if err := json.Unmarshal(b, entry); err != nil {
+		return nil, err
+	}
+
+	// Entries written before the accept time was recorded fall back to the
+	// time of their last attempt.
+	if entry.AcceptTime.IsZero() {
+		entry.AcceptTime = entry.LastAttemptTime
+	}
+
+	return &model.PendingEvent{
+		ID: id,
+		Event: &model.Event{
+			Data: entry.Data,
+			Key:  entry.Key,
+		},
+		AcceptTime:      entry.AcceptTime,
+		Attempts:        entry.Attempts,
+		LastAttemptTime: entry.LastAttemptTime,
+		LastError:       entry.LastError,
+	}, nil
+}
+
+func decodeSeenTime(encoded string) (time.Time, bool) {
+	var value transfer.JSONInterface
+	if err := json.Unmarshal([]byte(encoded), &value); err != nil {
+		return time.Time{}, false
+	}
+
+	s, ok := value.Data.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+func pendingEventKeyPrefix(action model.Action) string {
+	return fmt.Sprintf("%s.%s.event.pending.", action.Type().Plural, action.Hash())
+}
+
+func pendingEventKey(action model.Action, id string) string {
+	return pendingEventKeyPrefix(action) + id
+}
+
+func seenEventKeyPrefix(action model.Action) string {
+	return fmt.Sprintf("%s.%s.event.seen.", action.Type().Plural, action.Hash())
+}
+
+func seenEventKey(action model.Action, key string) string {
+	return fmt.Sprintf("%s%x", seenEventKeyPrefix(action), sha256.Sum256([]byte(key)))
+}
@@ -0,0 +1,323 @@
+package configmap_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/puppetlabs/leg/encoding/transfer"
+	"github.com/puppetlabs/relay-core/pkg/manager/configmap"
+	"github.com/puppetlabs/relay-core/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type mockEventManager struct {
+	errs    []error
+	emitted []*model.Event
+}
+
+func (m *mockEventManager) Emit(ctx context.Context, data map[string]interface{}, key string) (*model.Event, error) {
+	if len(m.errs) > 0 {
+		err := m.errs[0]
+		m.errs = m.errs[1:]
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ev := &model.Event{Data: data, Key: key}
+	m.emitted = append(m.emitted, ev)
+
+	return ev, nil
+}
+
+func countKeys(cm *corev1.ConfigMap, infix string) (n int) {
+	for k := range cm.Data {
+		if strings.Contains(k, infix) {
+			n++
+		}
+	}
+
+	return
+}
+
+// rewindPendingEvents makes every pending event due for redelivery.
+func rewindPendingEvents(t *testing.T, cm *corev1.ConfigMap) {
+	for k, v := range cm.Data {
+		if !strings.Contains(k, ".event.pending.") {
+			continue
+		}
+
+		var value transfer.JSONInterface
+		require.NoError(t, json.Unmarshal([]byte(v), &value))
+
+		entry := value.Data.(map[string]interface{})
+		entry["lastAttemptTime"] = time.Now().Add(-24 * time.Hour).Format(time.RFC3339Nano)
+
+		b, err := json.Marshal(transfer.JSONInterface{Data: entry})
+		require.NoError(t, err)
+
+		cm.Data[k] = string(b)
+	}
+}
+
+func TestEventManagerDeduplicates(t *testing.T) {
+	ctx := context.Background()
+
+	trigger := &model.Trigger{Name: "foo"}
+	obj := &corev1.ConfigMap{}
+	delegate := &mockEventManager{}
+
+	em := configmap.NewEventManager(trigger, configmap.NewLocalConfigMap(obj), delegate)
+
+	for i := 0; i < 3; i++ {
+		ev, err := em.Emit(ctx, map[string]interface{}{"i": i}, "my-key")
+		require.NoError(t, err)
+		assert.Equal(t, "my-key", ev.Key)
+	}
+
+	// Events without a key are never deduplicated.
+	for i := 0; i < 2; i++ {
+		_, err := em.Emit(ctx, map[string]interface{}{"i": i}, "")
+		require.NoError(t, err)
+	}
+
+	require.Len(t, delegate.emitted, 3)
+	assert.Equal(t, map[string]interface{}{"i": 0}, delegate.emitted[0].Data)
+
+	// Once the window passes, the key may be used again.
+	em = configmap.NewEventManager(trigger, configmap.NewLocalConfigMap(obj), delegate, configmap.EventManagerWithDeduplicationWindow(time.Nanosecond))
+
+	_, err := em.Emit(ctx, nil, "my-key")
+	require.NoError(t, err)
+	require.Len(t, delegate.emitted, 4)
+	assert.Equal(t, 1, countKeys(obj, ".event.seen."))
+}
+
+func TestEventManagerReleasesKeyOnRejection(t *testing.T) {
+	ctx := context.Background()
+
+	obj := &corev1.ConfigMap{}
+	delegate := &mockEventManager{
+		errs: []error{errors.New("bad request")},
+	}
+
+	em := configmap.NewEventManager(&model.Trigger{Name: "foo"}, configmap.NewLocalConfigMap(obj), delegate)
+
+	_, err := em.Emit(ctx, nil, "my-key")
+	require.EqualError(t, err, "bad request")
+	assert.Equal(t, 0, countKeys(obj, ".event.pending."))
+
+	_, err = em.Emit(ctx, nil, "my-key")
+	require.NoError(t, err)
+	require.Len(t, delegate.emitted, 1)
+}
+
+func TestEventManagerRedelivers(t *testing.T) {
+	ctx := context.Background()
+
+	obj := &corev1.ConfigMap{}
+	unavailable := &model.EventDeliveryError{Cause: errors.New("503 service unavailable")}
+	delegate := &mockEventManager{
+		errs: []error{unavailable, unavailable, nil},
+	}
+
+	em := configmap.NewEventManager(&model.Trigger{Name: "foo"}, configmap.NewLocalConfigMap(obj), delegate)
+
+	// The first attempt fails, but the event is accepted for redelivery.
+	ev, err := em.Emit(ctx, map[string]interface{}{"foo": "bar"}, "my-key")
+	require.NoError(t, err)
+	assert.Equal(t, "my-key", ev.Key)
+	assert.Equal(t, 1, countKeys(obj, ".event.pending."))
+
+	// Nothing is due yet.
+	rs, next, err := em.RedeliverPending(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, rs)
+	assert.True(t, next > 0 && next <= configmap.EventRedeliveryBackoff(1))
+
+	// The second attempt fails again.
+	rewindPendingEvents(t, obj)
+
+	rs, next, err = em.RedeliverPending(ctx)
+	require.NoError(t, err)
+	require.Len(t, rs, 1)
+	assert.Equal(t, unavailable, rs[0].Error)
+	assert.False(t, rs[0].Dropped)
+	assert.Equal(t, 2, rs[0].Event.Attempts)
+	assert.Equal(t, configmap.EventRedeliveryBackoff(2), next)
+	assert.Equal(t, 1, countKeys(obj, ".event.pending."))
+
+	// The third attempt succeeds.
+	rewindPendingEvents(t, obj)
+
+	rs, next, err = em.RedeliverPending(ctx)
+	require.NoError(t, err)
+	require.Len(t, rs, 1)
+	assert.NoError(t, rs[0].Error)
+	assert.Equal(t, time.Duration(0), next)
+	assert.Equal(t, 0, countKeys(obj, ".event.pending."))
+
+	require.Len(t, delegate.emitted, 1)
+	assert.Equal(t, map[string]interface{}{"foo": "bar"}, delegate.emitted[0].Data)
+	assert.Equal(t, "my-key", delegate.emitted[0].Key)
+}
+
+func TestEventManagerRedeliversInAcceptOrder(t *testing.T) {
+	ctx := context.Background()
+
+	obj := &corev1.ConfigMap{}
+	unavailable := &model.EventDeliveryError{Cause: errors.New("503 service unavailable")}
+	delegate := &mockEventManager{
+		errs: []error{unavailable, unavailable},
+	}
+
+	em := configmap.NewEventManager(&model.Trigger{Name: "foo"}, configmap.NewLocalConfigMap(obj), delegate)
+
+	for i := 0; i < 2; i++ {
+		_, err := em.Emit(ctx, map[string]interface{}{"i": i}, "")
+		require.NoError(t, err)
+	}
+
+	// Make the first event look like it was retried after the second.
+	for k, v := range obj.Data {
+		if !strings.Contains(k, ".event.pending.") {
+			continue
+		}
+
+		var value transfer.JSONInterface
+		require.NoError(t, json.Unmarshal([]byte(v), &value))
+
+		entry := value.Data.(map[string]interface{})
+		ago := 2 * time.Hour
+		if entry["data"].(map[string]interface{})["i"] == float64(0) {
+			ago = time.Hour
+		}
+		entry["lastAttemptTime"] = time.Now().Add(-ago).Format(time.RFC3339Nano)
+
+		b, err := json.Marshal(transfer.JSONInterface{Data: entry})
+		require.NoError(t, err)
+
+		obj.Data[k] = string(b)
+	}
+
+	rs, _, err := em.RedeliverPending(ctx)
+	require.NoError(t, err)
+	require.Len(t, rs, 2)
+
+	require.Len(t, delegate.emitted, 2)
+	for i, ev := range delegate.emitted {
+		assert.Equal(t, float64(i), ev.Data["i"])
+	}
+}
+
+func TestEventManagerDropsAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+
+	obj := &corev1.ConfigMap{}
+	unavailable := &model.EventDeliveryError{Cause: errors.New("503 service unavailable")}
+	delegate := &mockEventManager{
+		errs: []error{unavailable, unavailable},
+	}
+
+	em := configmap.NewEventManager(
+		&model.Trigger{Name: "foo"},
+		configmap.NewLocalConfigMap(obj),
+		delegate,
+		configmap.EventManagerWithMaxAttempts(2),
+	)
+
+	_, err := em.Emit(ctx, nil, "")
+	require.NoError(t, err)
+
+	rewindPendingEvents(t, obj)
+
+	rs, _, err := em.RedeliverPending(ctx)
+	require.NoError(t, err)
+	require.Len(t, rs, 1)
+	assert.True(t, rs[0].Dropped)
+	assert.Equal(t, 0, countKeys(obj, ".event.pending."))
+}
+
+func TestEventManagerMaxPending(t *testing.T) {
+	ctx := context.Background()
+
+	obj := &corev1.ConfigMap{}
+	unavailable := &model.EventDeliveryError{Cause: errors.New("503 service unavailable")}
+	delegate := &mockEventManager{
+		errs: []error{unavailable, unavailable},
+	}
+
+	em := configmap.NewEventManager(
+		&model.Trigger{Name: "foo"},
+		configmap.NewLocalConfigMap(obj),
+		delegate,
+		configmap.EventManagerWithMaxPending(1),
+	)
+
+	_, err := em.Emit(ctx, nil, "")
+	require.NoError(t, err)
+
+	_, err = em.Emit(ctx, nil, "")
+	require.Error(t, err)
+
+	var de *model.EventDeliveryError
+	require.True(t, errors.As(err, &de))
+	assert.Equal(t, 1, countKeys(obj, ".event.pending."))
+}
+
+func TestEventManagerMaxPendingSize(t *testing.T) {
+	ctx := context.Background()
+
+	obj := &corev1.ConfigMap{}
+	unavailable := &model.EventDeliveryError{Cause: errors.New("503 service unavailable")}
+	delegate := &mockEventManager{
+		errs: []error{unavailable, unavailable, unavailable},
+	}
+
+	em := configmap.NewEventManager(
+		&model.Trigger{Name: "foo"},
+		configmap.NewLocalConfigMap(obj),
+		delegate,
+		configmap.EventManagerWithMaxSize(512),
+		configmap.EventManagerWithMaxPendingSize(768),
+	)
+
+	data := map[string]interface{}{"foo": strings.Repeat("a", 256)}
+
+	_, err := em.Emit(ctx, data, "")
+	require.NoError(t, err)
+
+	// There is no room for a second event.
+	_, err = em.Emit(ctx, data, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "too large")
+
+	// Events that are too large on their own are never kept.
+	_, err = em.Emit(ctx, map[string]interface{}{"foo": strings.Repeat("a", 1024)}, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the event is too large to keep for redelivery")
+
+	var de *model.EventDeliveryError
+	require.True(t, errors.As(err, &de))
+	assert.Equal(t, 1, countKeys(obj, ".event.pending."))
+}
+
+func TestEventManagerRejectsSteps(t *testing.T) {
+	ctx := context.Background()
+
+	em := configmap.NewEventManager(
+		&model.Step{Run: model.Run{ID: "foo"}, Name: "bar"},
+		configmap.NewLocalConfigMap(&corev1.ConfigMap{}),
+		&mockEventManager{},
+	)
+
+	_, err := em.Emit(ctx, nil, "")
+	require.Equal(t, model.ErrRejected, err)
+}
@@ -0,0 +1,319 @@
+package configmap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/puppetlabs/leg/encoding/transfer"
+	"github.com/puppetlabs/relay-core/pkg/model"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	DefaultEventMaxHistory     = 10
+	DefaultEventMaxHistorySize = 256 * 1024
+)
+
+// ErrEventTruncated is returned when replaying an event whose data was too
+// large to keep in the history.
+var ErrEventTruncated = errors.New("configmap: event data was not kept")
+
+// EventManagerWithMaxHistory sets the number of emitted events to keep for
+// inspection and replay. A value of zero disables the history, although
+// event statistics are still recorded.
+func EventManagerWithMaxHistory(n int) EventManagerOption {
+	return func(em *EventManager) {
+		em.maxHistory = n
+	}
+}
+
+// EventManagerWithMaxHistorySize sets the number of bytes the history may take
+// up in the configuration map. The oldest events are discarded to stay within
+// the limit.
+func EventManagerWithMaxHistorySize(n int) EventManagerOption {
+	return func(em *EventManager) {
+		em.maxHistorySize = n
+	}
+}
+
+type recordedEventEntry struct {
+	Data    map[string]interface{} `json:"data"`
+	Key     string                 `json:"key,omitempty"`
+	Time    time.Time              `json:"time"`
+	Outcome model.EventOutcome     `json:"outcome"`
+	Error   string                 `json:"error,omitempty"`
+
+	// Truncated is true if the data of the event was too large to keep.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+type eventStatsEntry struct {
+	Accepted      int64     `json:"accepted"`
+	Rejected      int64     `json:"rejected"`
+	LastEventTime time.Time `json:"lastEventTime"`
+	LastError     string    `json:"lastError,omitempty"`
+}
+
+// History returns the events recently emitted by the action, newest first.
+func (m *EventManager) History(ctx context.Context) ([]*model.RecordedEvent, error) {
+	entries, err := m.kcm.List(ctx, recordedEventKeyPrefix(m.me))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*model.RecordedEvent, 0, len(entries))
+	for id, value := range entries {
+		re, err := decodeRecordedEvent(id, value)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, re)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Time.After(res[j].Time)
+	})
+
+	return res, nil
+}
+
+// Stats returns a summary of the events emitted by the action.
+func (m *EventManager) Stats(ctx context.Context) (*model.EventStats, error) {
+	value, err := m.kcm.Get(ctx, eventStatsKey(m.me))
+	if err == model.ErrNotFound {
+		return &model.EventStats{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	entry := &eventStatsEntry{}
+	if err := remarshal(value, entry); err != nil {
+		return nil, err
+	}
+
+	return &model.EventStats{
+		Accepted:      entry.Accepted,
+		Rejected:      entry.Rejected,
+		LastEventTime: entry.LastEventTime,
+		LastError:     entry.LastError,
+	}, nil
+}
+
+// Replay sends the recorded event with the given ID to the delegate again,
+// regardless of whether its key has been seen. The replay is recorded as a
+// new event in the history, which is returned. If the event is not in the
+// history, model.ErrNotFound is returned, and if its data was not kept,
+// ErrEventTruncated is returned.
+func (m *EventManager) Replay(ctx context.Context, id string) (*model.RecordedEvent, error) {
+	value, err := m.kcm.Get(ctx, recordedEventKey(m.me, id))
+	if err != nil {
+		return nil, err
+	}
+
+	orig, err := decodeRecordedEvent(id, value)
+	if err != nil {
+		return nil, err
+	} else if orig.Truncated {
+		return nil, ErrEventTruncated
+	}
+
+	return m.deliver(ctx, orig.Event)
+}
+
+func (m *EventManager) newRecordedEvent(ev *model.Event) *model.RecordedEvent {
+	return &model.RecordedEvent{
+		ID:    uuid.New().String(),
+		Event: ev,
+		Time:  time.Now().UTC(),
+	}
+}
+
+// recordEvent adds the given event to the history in the configuration map,
+// discarding the oldest events if the history is full, and updates the event
+// statistics for the new outcome. The data of events that are too large is not
+// kept.
+func (m *EventManager) recordEvent(cm *corev1.ConfigMap, re *model.RecordedEvent) {
+	stats := decodeEventStatsData(cm.Data[eventStatsKey(m.me)])
+	switch re.Outcome {
+	case model.EventOutcomeRejected:
+		stats.Rejected++
+		stats.LastError = re.Error
+	default:
+		stats.Accepted++
+	}
+	stats.LastEventTime = re.Time
+	setEntryData(cm, eventStatsKey(m.me), stats)
+
+	if m.maxHistory <= 0 {
+		return
+	}
+
+	entry := &recordedEventEntry{
+		Data:    re.Event.Data,
+		Key:     re.Event.Key,
+		Time:    re.Time,
+		Outcome: re.Outcome,
+		Error:   re.Error,
+	}
+	if encoded, _ := json.Marshal(entry.Data); len(encoded) > m.maxSize {
+		entry.Data = nil
+		entry.Truncated = true
+	}
+
+	key := recordedEventKey(m.me, re.ID)
+	prefix := recordedEventKeyPrefix(m.me)
+
+	type recorded struct {
+		key  string
+		time time.Time
+		size int
+	}
+
+	var existing []recorded
+	var size int
+	for k, v := range cm.Data {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+
+		entry := &recordedEventEntry{}
+		if err := decodeEntryData(v, entry); err != nil {
+			// Unreadable entries are not useful to anyone.
+			delete(cm.Data, k)
+			continue
+		}
+
+		existing = append(existing, recorded{key: k, time: entry.Time, size: len(k) + len(v)})
+		size += len(k) + len(v)
+	}
+
+	setEntryData(cm, key, entry)
+	size += len(key) + len(cm.Data[key])
+
+	sort.Slice(existing, func(i, j int) bool {
+		return existing[i].time.Before(existing[j].time)
+	})
+
+	for len(existing) > 0 && (len(existing)+1 > m.maxHistory || size > m.maxHistorySize) {
+		delete(cm.Data, existing[0].key)
+		size -= existing[0].size
+		existing = existing[1:]
+	}
+}
+
+// updateRecordedEvent changes the outcome of an event in the history after
+// redelivery. If the event was rejected, the statistics are updated too.
+func (m *EventManager) updateRecordedEvent(cm *corev1.ConfigMap, id string, outcome model.EventOutcome, cause error) {
+	if outcome == model.EventOutcomeRejected {
+		stats := decodeEventStatsData(cm.Data[eventStatsKey(m.me)])
+		stats.Rejected++
+		stats.LastError = cause.Error()
+		setEntryData(cm, eventStatsKey(m.me), stats)
+	}
+
+	key := recordedEventKey(m.me, id)
+
+	entry := &recordedEventEntry{}
+	if v, found := cm.Data[key]; !found {
+		// Already pushed out of the history by newer events.
+		return
+	} else if err := decodeEntryData(v, entry); err != nil {
+		delete(cm.Data, key)
+		return
+	}
+
+	entry.Outcome = outcome
+	entry.Error = ""
+	if cause != nil {
+		entry.Error = cause.Error()
+	}
+
+	setEntryData(cm, key, entry)
+}
+
+// HasEventChanges returns true if the event statistics in the given
+// configuration maps differ.
+func HasEventChanges(old, new *corev1.ConfigMap) bool {
+	for k, v := range new.Data {
+		if strings.HasSuffix(k, ".event.stats") && old.Data[k] != v {
+			return true
+		}
+	}
+
+	return false
+}
+
+func decodeRecordedEvent(id string, value interface{}) (*model.RecordedEvent, error) {
+	entry := &recordedEventEntry{}
+	if err := remarshal(value, entry); err != nil {
+		return nil, err
+	}
+
+	return &model.RecordedEvent{
+		ID: id,
+		Event: &model.Event{
+			Data: entry.Data,
+			Key:  entry.Key,
+		},
+		Time:      entry.Time,
+		Outcome:   entry.Outcome,
+		Error:     entry.Error,
+		Truncated: entry.Truncated,
+	}, nil
+}
+
+func decodeEventStatsData(encoded string) *eventStatsEntry {
+	entry := &eventStatsEntry{}
+	if encoded != "" {
+		// Start over if the statistics are unreadable.
+		if err := decodeEntryData(encoded, entry); err != nil {
+			return &eventStatsEntry{}
+		}
+	}
+
+	return entry
+}
+
+func decodeEntryData(encoded string, target interface{}) error {
+	var value transfer.JSONInterface
+	if err := json.Unmarshal([]byte(encoded), &value); err != nil {
+		return err
+	}
+
+	return remarshal(value.Data, target)
+}
+
+func setEntryData(cm *corev1.ConfigMap, key string, entry interface{}) {
+	// The entries only contain data that was decoded from JSON, so encoding
+	// them cannot fail.
+	encoded, _ := json.Marshal(transfer.JSONInterface{Data: entry})
+	cm.Data[key] = string(encoded)
+}
+
+func remarshal(value, target interface{}) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, target)
+}
+
+func recordedEventKeyPrefix(action model.Action) string {
+	return fmt.Sprintf("%s.%s.event.history.", action.Type().Plural, action.Hash())
+}
+
+func recordedEventKey(action model.Action, id string) string {
+	return recordedEventKeyPrefix(action) + id
+}
+
+func eventStatsKey(action model.Action) string {
+	return fmt.Sprintf("%s.%s.event.stats", action.Type().Plural, action.Hash())
+}
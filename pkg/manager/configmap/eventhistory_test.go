@@ -0,0 +1,183 @@
+package configmap_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/puppetlabs/relay-core/pkg/manager/configmap"
+	"github.com/puppetlabs/relay-core/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestEventManagerHistory(t *testing.T) {
+	ctx := context.Background()
+
+	obj := &corev1.ConfigMap{}
+	delegate := &mockEventManager{
+		errs: []error{nil, errors.New("bad request")},
+	}
+
+	em := configmap.NewEventManager(
+		&model.Trigger{Name: "foo"},
+		configmap.NewLocalConfigMap(obj),
+		delegate,
+		configmap.EventManagerWithMaxHistory(3),
+	)
+
+	_, err := em.Emit(ctx, map[string]interface{}{"i": 0}, "")
+	require.NoError(t, err)
+
+	_, err = em.Emit(ctx, map[string]interface{}{"i": 1}, "")
+	require.EqualError(t, err, "bad request")
+
+	for i := 2; i < 5; i++ {
+		_, err := em.Emit(ctx, map[string]interface{}{"i": i}, "")
+		require.NoError(t, err)
+	}
+
+	// Only the newest events are kept.
+	history, err := em.History(ctx)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	for i, re := range history {
+		assert.Equal(t, float64(4-i), re.Event.Data["i"])
+		assert.Equal(t, model.EventOutcomeDelivered, re.Outcome)
+	}
+
+	stats, err := em.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), stats.Accepted)
+	assert.Equal(t, int64(1), stats.Rejected)
+	assert.Equal(t, "bad request", stats.LastError)
+	assert.Equal(t, history[0].Time, stats.LastEventTime)
+}
+
+func TestEventManagerHistoryRedelivery(t *testing.T) {
+	ctx := context.Background()
+
+	obj := &corev1.ConfigMap{}
+	unavailable := &model.EventDeliveryError{Cause: errors.New("503 service unavailable")}
+	delegate := &mockEventManager{
+		errs: []error{unavailable, unavailable},
+	}
+
+	em := configmap.NewEventManager(
+		&model.Trigger{Name: "foo"},
+		configmap.NewLocalConfigMap(obj),
+		delegate,
+		configmap.EventManagerWithMaxAttempts(2),
+	)
+
+	_, err := em.Emit(ctx, map[string]interface{}{"foo": "bar"}, "")
+	require.NoError(t, err)
+
+	history, err := em.History(ctx)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, model.EventOutcomePending, history[0].Outcome)
+
+	rewindPendingEvents(t, obj)
+
+	rs, _, err := em.RedeliverPending(ctx)
+	require.NoError(t, err)
+	require.Len(t, rs, 1)
+	require.True(t, rs[0].Dropped)
+
+	history, err = em.History(ctx)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, model.EventOutcomeRejected, history[0].Outcome)
+	assert.Equal(t, rs[0].Error.Error(), history[0].Error)
+
+	stats, err := em.Stats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), stats.Accepted)
+	assert.Equal(t, int64(1), stats.Rejected)
+}
+
+func TestEventManagerReplay(t *testing.T) {
+	ctx := context.Background()
+
+	obj := &corev1.ConfigMap{}
+	delegate := &mockEventManager{}
+
+	em := configmap.NewEventManager(&model.Trigger{Name: "foo"}, configmap.NewLocalConfigMap(obj), delegate)
+
+	_, err := em.Emit(ctx, map[string]interface{}{"foo": "bar"}, "my-key")
+	require.NoError(t, err)
+
+	history, err := em.History(ctx)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+
+	// Replaying ignores deduplication.
+	re, err := em.Replay(ctx, history[0].ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, history[0].ID, re.ID)
+	assert.Equal(t, model.EventOutcomeDelivered, re.Outcome)
+
+	require.Len(t, delegate.emitted, 2)
+	assert.Equal(t, delegate.emitted[0], delegate.emitted[1])
+
+	history, err = em.History(ctx)
+	require.NoError(t, err)
+	assert.Len(t, history, 2)
+
+	_, err = em.Replay(ctx, "missing")
+	assert.Equal(t, model.ErrNotFound, err)
+}
+
+func TestEventManagerHistorySize(t *testing.T) {
+	ctx := context.Background()
+
+	obj := &corev1.ConfigMap{}
+	delegate := &mockEventManager{}
+
+	em := configmap.NewEventManager(
+		&model.Trigger{Name: "foo"},
+		configmap.NewLocalConfigMap(obj),
+		delegate,
+		configmap.EventManagerWithMaxSize(1024),
+		configmap.EventManagerWithMaxHistorySize(2048),
+	)
+
+	for i := 0; i < 5; i++ {
+		_, err := em.Emit(ctx, map[string]interface{}{"i": i, "foo": strings.Repeat("a", 512)}, "")
+		require.NoError(t, err)
+	}
+
+	// Only as many events as fit are kept.
+	history, err := em.History(ctx)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	for i, re := range history {
+		assert.Equal(t, float64(4-i), re.Event.Data["i"])
+	}
+
+	var size int
+	for k, v := range obj.Data {
+		if strings.Contains(k, ".event.history.") {
+			size += len(k) + len(v)
+		}
+	}
+	assert.LessOrEqual(t, size, 2048)
+
+	// Events that are too large are recorded without their data and cannot be
+	// replayed.
+	_, err = em.Emit(ctx, map[string]interface{}{"foo": strings.Repeat("a", 2048)}, "")
+	require.NoError(t, err)
+
+	history, err = em.History(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, history)
+	assert.True(t, history[0].Truncated)
+	assert.Empty(t, history[0].Event.Data)
+	assert.Equal(t, model.EventOutcomeDelivered, history[0].Outcome)
+
+	_, err = em.Replay(ctx, history[0].ID)
+	assert.Equal(t, configmap.ErrEventTruncated, err)
+}
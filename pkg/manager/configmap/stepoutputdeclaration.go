@@ -0,0 +1,52 @@
+package configmap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/puppetlabs/relay-core/pkg/model"
+)
+
+type StepOutputDeclarationManager struct {
+	me  *model.Step
+	kcm *KVConfigMap
+}
+
+var _ model.StepOutputDeclarationManager = &StepOutputDeclarationManager{}
+
+func (m *StepOutputDeclarationManager) List(ctx context.Context) ([]*model.StepOutputDeclaration, error) {
+	value, err := m.kcm.Get(ctx, stepOutputDeclarationsKey(m.me))
+	if err == model.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var decls []*model.StepOutputDeclaration
+	if err := json.Unmarshal(b, &decls); err != nil {
+		return nil, err
+	}
+
+	return decls, nil
+}
+
+func (m *StepOutputDeclarationManager) Set(ctx context.Context, decls []*model.StepOutputDeclaration) error {
+	return m.kcm.Set(ctx, stepOutputDeclarationsKey(m.me), decls)
+}
+
+func NewStepOutputDeclarationManager(step *model.Step, cm ConfigMap) *StepOutputDeclarationManager {
+	return &StepOutputDeclarationManager{
+		me:  step,
+		kcm: NewKVConfigMap(cm),
+	}
+}
+
+func stepOutputDeclarationsKey(step *model.Step) string {
+	return fmt.Sprintf("%s.%s.outputs", step.Type().Plural, step.Hash())
+}
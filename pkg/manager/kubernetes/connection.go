@@ -0,0 +1,78 @@
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/puppetlabs/relay-core/pkg/model"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConnectionManager reads the connections of a tenant from Kubernetes secrets
+// in the tenant namespace. Only Kubernetes secrets labeled with
+// model.RelayConnectionLabel set to "true" and annotated with the connection
+// type are considered. Each key of the secret data is an attribute of the
+// connection.
+type ConnectionManager struct {
+	client    client.Client
+	namespace string
+}
+
+var _ model.ConnectionManager = &ConnectionManager{}
+
+func (m *ConnectionManager) List(ctx context.Context) ([]*model.Connection, error) {
+	secrets, err := listLabeledSecrets(ctx, m.client, m.namespace, model.RelayConnectionLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	var l []*model.Connection
+
+	for i := range secrets {
+		if c, ok := connectionFromKubernetes(&secrets[i]); ok {
+			l = append(l, c)
+		}
+	}
+
+	return l, nil
+}
+
+func (m *ConnectionManager) Get(ctx context.Context, typ, name string) (*model.Connection, error) {
+	secrets, err := listLabeledSecrets(ctx, m.client, m.namespace, model.RelayConnectionLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range secrets {
+		if c, ok := connectionFromKubernetes(&secrets[i]); ok && c.Type == typ && c.Name == name {
+			return c, nil
+		}
+	}
+
+	return nil, model.ErrNotFound
+}
+
+func connectionFromKubernetes(secret *corev1.Secret) (*model.Connection, bool) {
+	typ := secret.GetAnnotations()[model.RelayConnectionTypeAnnotation]
+	if typ == "" {
+		return nil, false
+	}
+
+	attrs := make(map[string]interface{}, len(secret.Data))
+	for key, value := range secret.Data {
+		attrs[key] = string(value)
+	}
+
+	return &model.Connection{
+		Type:       typ,
+		Name:       nameFromAnnotation(secret, model.RelayConnectionNameAnnotation),
+		Attributes: attrs,
+	}, true
+}
+
+func NewConnectionManager(client client.Client, namespace string) *ConnectionManager {
+	return &ConnectionManager{
+		client:    client,
+		namespace: namespace,
+	}
+}
@@ -0,0 +1,62 @@
+package kubernetes_test
+
+import (
+	"context"
+	"testing"
+
+	kubernetesmgr "github.com/puppetlabs/relay-core/pkg/manager/kubernetes"
+	"github.com/puppetlabs/relay-core/pkg/model"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConnectionManager(t *testing.T) {
+	ctx := context.Background()
+
+	c := newClient(t,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "my-tenant",
+				Name:      "relay-connection-1",
+				Labels:    map[string]string{model.RelayConnectionLabel: "true"},
+				Annotations: map[string]string{
+					model.RelayConnectionTypeAnnotation: "aws",
+					model.RelayConnectionNameAnnotation: "test",
+				},
+			},
+			Data: map[string][]byte{
+				"accessKeyID":     []byte("AKIA"),
+				"secretAccessKey": []byte("wh\nup"),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "my-tenant",
+				Name:      "untyped",
+				Labels:    map[string]string{model.RelayConnectionLabel: "true"},
+			},
+			Data: map[string][]byte{"foo": []byte("bar")},
+		},
+	)
+
+	cm := kubernetesmgr.NewConnectionManager(c, "my-tenant")
+
+	attrs := map[string]interface{}{
+		"accessKeyID":     "AKIA",
+		"secretAccessKey": "wh\nup",
+	}
+
+	conn, err := cm.Get(ctx, "aws", "test")
+	require.NoError(t, err)
+	require.Equal(t, "aws", conn.Type)
+	require.Equal(t, "test", conn.Name)
+	require.Equal(t, attrs, conn.Attributes)
+
+	_, err = cm.Get(ctx, "gcp", "test")
+	require.Equal(t, model.ErrNotFound, err)
+
+	conns, err := cm.List(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*model.Connection{{Type: "aws", Name: "test", Attributes: attrs}}, conns)
+}
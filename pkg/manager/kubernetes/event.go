@@ -0,0 +1,176 @@
+package kubernetes
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/puppetlabs/leg/relspec/pkg/evaluate"
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"github.com/puppetlabs/relay-core/pkg/model"
+	"github.com/puppetlabs/relay-core/pkg/spec"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// EventDataName is the name of the data source that provides the content
+	// of an event to the expressions of a trigger binding.
+	EventDataName = "event"
+
+	// RunNamePrefix is prepended to the name of every run created from an
+	// event.
+	RunNamePrefix = "trigger-"
+)
+
+// EventManager creates a run of a workflow for each event emitted by a
+// trigger.
+type EventManager struct {
+	me           model.Action
+	client       client.Client
+	namespace    string
+	workflowName string
+	parameters   map[string]interface{}
+}
+
+var _ model.EventManager = &EventManager{}
+
+func (m *EventManager) Emit(ctx context.Context, data map[string]interface{}, key string) (*model.Event, error) {
+	at, ok := m.me.(*model.Trigger)
+	if !ok {
+		return nil, model.ErrRejected
+	}
+
+	params, err := m.evaluateParameters(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &relayv1beta1.Run{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: m.namespace,
+			Annotations: map[string]string{
+				model.RelayTriggerNameAnnotation: at.Name,
+			},
+		},
+		Spec: relayv1beta1.RunSpec{
+			Parameters: relayv1beta1.NewUnstructuredObject(params),
+			WorkflowRef: corev1.LocalObjectReference{
+				Name: m.workflowName,
+			},
+		},
+	}
+
+	// If the caller provides a key, we derive the name of the run from it so
+	// that repeated deliveries of the same event only create a single run.
+	if key != "" {
+		run.Name = m.runName(at, key)
+		run.Annotations[model.RelayEventKeyAnnotation] = key
+	} else {
+		run.GenerateName = RunNamePrefix
+	}
+
+	if err := m.client.Create(ctx, run); errors.IsAlreadyExists(err) && key != "" {
+		if err := m.checkExistingRun(ctx, at, run.Name, key); err != nil {
+			return nil, err
+		}
+	} else if isTransientError(err) {
+		return nil, &model.EventDeliveryError{Cause: err}
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &model.Event{
+		Data: data,
+		Key:  key,
+	}, nil
+}
+
+// runName computes a deterministic name for the run created for an event
+// with the given key. The trigger and workflow are part of the hashed input
+// so that unrelated triggers in the same tenant cannot collide.
+func (m *EventManager) runName(at *model.Trigger, key string) string {
+	h := sha256.Sum256([]byte(strings.Join([]string{m.workflowName, at.Name, key}, "\x00")))
+
+	return fmt.Sprintf("%s%x", RunNamePrefix, h[:8])
+}
+
+// checkExistingRun makes sure that a run with the name we computed for an
+// event was actually created for the same event.
+func (m *EventManager) checkExistingRun(ctx context.Context, at *model.Trigger, name, key string) error {
+	existing := &relayv1beta1.Run{}
+	if err := m.client.Get(ctx, client.ObjectKey{Namespace: m.namespace, Name: name}, existing); err != nil {
+		return err
+	}
+
+	annotations := existing.GetAnnotations()
+	if annotations[model.RelayTriggerNameAnnotation] != at.Name || annotations[model.RelayEventKeyAnnotation] != key {
+		return fmt.Errorf("run %q already exists but was not created for this event", name)
+	}
+
+	return nil
+}
+
+func (m *EventManager) evaluateParameters(ctx context.Context, data map[string]interface{}) (map[string]interface{}, error) {
+	if len(m.parameters) == 0 {
+		return nil, nil
+	}
+
+	ev := spec.NewEvaluator(
+		spec.WithDataTypeResolver{
+			Name:             EventDataName,
+			Default:          true,
+			DataTypeResolver: spec.NewMemoryDataTypeResolver(data),
+		},
+	)
+
+	r, err := evaluate.EvaluateAll(ctx, ev, m.parameters)
+	if err != nil {
+		return nil, err
+	} else if r.References != nil && !r.References.OK() {
+		return nil, r.References.ToError()
+	}
+
+	params, ok := r.Value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("trigger binding evaluated to %T, expected an object", r.Value)
+	}
+
+	return params, nil
+}
+
+func isTransientError(err error) bool {
+	return errors.IsServerTimeout(err) ||
+		errors.IsTimeout(err) ||
+		errors.IsTooManyRequests(err) ||
+		errors.IsInternalError(err) ||
+		errors.IsServiceUnavailable(err)
+}
+
+type EventManagerOption func(em *EventManager)
+
+// EventManagerWithParameters sets the trigger binding expressions used to
+// compute the parameters of each run from the event data.
+func EventManagerWithParameters(params map[string]interface{}) EventManagerOption {
+	return func(em *EventManager) {
+		em.parameters = params
+	}
+}
+
+func NewEventManager(action model.Action, client client.Client, namespace, workflowName string, opts ...EventManagerOption) *EventManager {
+	em := &EventManager{
+		me:           action,
+		client:       client,
+		namespace:    namespace,
+		workflowName: workflowName,
+	}
+
+	for _, opt := range opts {
+		opt(em)
+	}
+
+	return em
+}
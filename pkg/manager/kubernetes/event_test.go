@@ -0,0 +1,161 @@
+package kubernetes_test
+
+import (
+	"context"
+	"testing"
+
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	kubernetesmgr "github.com/puppetlabs/relay-core/pkg/manager/kubernetes"
+	"github.com/puppetlabs/relay-core/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, relayv1beta1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func listRuns(t *testing.T, c client.Client) []relayv1beta1.Run {
+	runs := &relayv1beta1.RunList{}
+	require.NoError(t, c.List(context.Background(), runs, client.InNamespace("my-tenant")))
+
+	return runs.Items
+}
+
+func TestEventManager(t *testing.T) {
+	ctx := context.Background()
+
+	c := newClient(t)
+
+	em := kubernetesmgr.NewEventManager(
+		&model.Trigger{Name: "my-trigger"},
+		c,
+		"my-tenant",
+		"my-workflow",
+		kubernetesmgr.EventManagerWithParameters(map[string]interface{}{
+			"ref":     map[string]interface{}{"$type": "Data", "query": "ref"},
+			"message": "Pushed to ${event.repository.name}",
+			"static":  "value",
+		}),
+	)
+
+	data := map[string]interface{}{
+		"ref": "refs/heads/main",
+		"repository": map[string]interface{}{
+			"name": "relay-core",
+		},
+	}
+
+	ev, err := em.Emit(ctx, data, "")
+	require.NoError(t, err)
+	assert.Equal(t, data, ev.Data)
+
+	runs := listRuns(t, c)
+	require.Len(t, runs, 1)
+	assert.Equal(t, kubernetesmgr.RunNamePrefix, runs[0].GenerateName)
+	assert.Equal(t, "my-workflow", runs[0].Spec.WorkflowRef.Name)
+	assert.Equal(t, "my-trigger", runs[0].Annotations[model.RelayTriggerNameAnnotation])
+	assert.Equal(t, map[string]interface{}{
+		"ref":     "refs/heads/main",
+		"message": "Pushed to relay-core",
+		"static":  "value",
+	}, runs[0].Spec.Parameters.Value())
+
+	// Events with the same key only create one run.
+	for i := 0; i < 2; i++ {
+		ev, err := em.Emit(ctx, data, "my-key")
+		require.NoError(t, err)
+		assert.Equal(t, "my-key", ev.Key)
+	}
+
+	runs = listRuns(t, c)
+	require.Len(t, runs, 2)
+
+	var keyed []relayv1beta1.Run
+	for _, run := range runs {
+		if run.Annotations[model.RelayEventKeyAnnotation] == "my-key" {
+			keyed = append(keyed, run)
+		}
+	}
+	require.Len(t, keyed, 1)
+
+	// The same key used by a different trigger creates a different run.
+	other := kubernetesmgr.NewEventManager(&model.Trigger{Name: "other-trigger"}, c, "my-tenant", "my-workflow")
+
+	_, err = other.Emit(ctx, data, "my-key")
+	require.NoError(t, err)
+
+	runs = listRuns(t, c)
+	require.Len(t, runs, 3)
+}
+
+func TestEventManagerNameConflict(t *testing.T) {
+	ctx := context.Background()
+
+	trigger := &model.Trigger{Name: "my-trigger"}
+
+	// Figure out the name that the event manager will use.
+	c := newClient(t)
+	_, err := kubernetesmgr.NewEventManager(trigger, c, "my-tenant", "my-workflow").Emit(ctx, nil, "my-key")
+	require.NoError(t, err)
+
+	runs := listRuns(t, c)
+	require.Len(t, runs, 1)
+
+	// Now squat on that name with an unrelated run.
+	c = newClient(t, &relayv1beta1.Run{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "my-tenant",
+			Name:      runs[0].Name,
+		},
+	})
+
+	_, err = kubernetesmgr.NewEventManager(trigger, c, "my-tenant", "my-workflow").Emit(ctx, nil, "my-key")
+	require.Error(t, err)
+}
+
+func TestEventManagerUnresolvableBinding(t *testing.T) {
+	ctx := context.Background()
+
+	c := newClient(t)
+
+	em := kubernetesmgr.NewEventManager(
+		&model.Trigger{Name: "my-trigger"},
+		c,
+		"my-tenant",
+		"my-workflow",
+		kubernetesmgr.EventManagerWithParameters(map[string]interface{}{
+			"token": map[string]interface{}{"$type": "Secret", "name": "token"},
+		}),
+	)
+
+	_, err := em.Emit(ctx, map[string]interface{}{}, "")
+	require.Error(t, err)
+	assert.Empty(t, listRuns(t, c))
+}
+
+func TestEventManagerRejectsSteps(t *testing.T) {
+	ctx := context.Background()
+
+	c := newClient(t)
+
+	em := kubernetesmgr.NewEventManager(
+		&model.Step{Run: model.Run{ID: "foo"}, Name: "bar"},
+		c,
+		"my-tenant",
+		"my-workflow",
+	)
+
+	_, err := em.Emit(ctx, map[string]interface{}{}, "")
+	require.Equal(t, model.ErrRejected, err)
+	assert.Empty(t, listRuns(t, c))
+}
@@ -0,0 +1,95 @@
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/puppetlabs/relay-core/pkg/model"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SecretValueKey is the key of the data of a Kubernetes secret that holds
+	// the value of a Relay secret.
+	SecretValueKey = "value"
+)
+
+// SecretManager reads the secrets of a tenant from Kubernetes secrets in the
+// tenant namespace. Only Kubernetes secrets labeled with
+// model.RelaySecretLabel set to "true" are considered. The name of the Relay
+// secret is taken from the model.RelaySecretNameAnnotation annotation, or from
+// the name of the Kubernetes secret if the annotation is not present.
+type SecretManager struct {
+	client    client.Client
+	namespace string
+}
+
+var _ model.SecretManager = &SecretManager{}
+
+func (m *SecretManager) List(ctx context.Context) ([]*model.Secret, error) {
+	secrets, err := listLabeledSecrets(ctx, m.client, m.namespace, model.RelaySecretLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	var l []*model.Secret
+
+	for i := range secrets {
+		if s, ok := secretFromKubernetes(&secrets[i]); ok {
+			l = append(l, s)
+		}
+	}
+
+	return l, nil
+}
+
+func (m *SecretManager) Get(ctx context.Context, name string) (*model.Secret, error) {
+	secrets, err := listLabeledSecrets(ctx, m.client, m.namespace, model.RelaySecretLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range secrets {
+		if s, ok := secretFromKubernetes(&secrets[i]); ok && s.Name == name {
+			return s, nil
+		}
+	}
+
+	return nil, model.ErrNotFound
+}
+
+func secretFromKubernetes(secret *corev1.Secret) (*model.Secret, bool) {
+	value, found := secret.Data[SecretValueKey]
+	if !found {
+		return nil, false
+	}
+
+	return &model.Secret{
+		Name:  nameFromAnnotation(secret, model.RelaySecretNameAnnotation),
+		Value: string(value),
+	}, true
+}
+
+func listLabeledSecrets(ctx context.Context, cl client.Client, namespace, label string) ([]corev1.Secret, error) {
+	secrets := &corev1.SecretList{}
+	if err := cl.List(ctx, secrets, client.InNamespace(namespace), client.MatchingLabels{label: "true"}); err != nil {
+		return nil, err
+	}
+
+	return secrets.Items, nil
+}
+
+func nameFromAnnotation(secret *corev1.Secret, annotation string) string {
+	if name := secret.GetAnnotations()[annotation]; name != "" {
+		return name
+	}
+
+	return secret.GetName()
+}
+
+func NewSecretManager(client client.Client, namespace string) *SecretManager {
+	return &SecretManager{
+		client:    client,
+		namespace: namespace,
+	}
+}
@@ -0,0 +1,71 @@
+package kubernetes_test
+
+import (
+	"context"
+	"testing"
+
+	kubernetesmgr "github.com/puppetlabs/relay-core/pkg/manager/kubernetes"
+	"github.com/puppetlabs/relay-core/pkg/model"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSecretManager(t *testing.T) {
+	ctx := context.Background()
+
+	c := newClient(t,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "my-tenant",
+				Name:      "foo",
+				Labels:    map[string]string{model.RelaySecretLabel: "true"},
+			},
+			Data: map[string][]byte{"value": []byte("bar")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "my-tenant",
+				Name:        "relay-secret-1",
+				Labels:      map[string]string{model.RelaySecretLabel: "true"},
+				Annotations: map[string]string{model.RelaySecretNameAnnotation: "My_Secret"},
+			},
+			Data: map[string][]byte{"value": []byte("baz")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "my-tenant",
+				Name:      "unlabeled",
+			},
+			Data: map[string][]byte{"value": []byte("nope")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "other-tenant",
+				Name:      "foo",
+				Labels:    map[string]string{model.RelaySecretLabel: "true"},
+			},
+			Data: map[string][]byte{"value": []byte("nope")},
+		},
+	)
+
+	sm := kubernetesmgr.NewSecretManager(c, "my-tenant")
+
+	sec, err := sm.Get(ctx, "foo")
+	require.NoError(t, err)
+	require.Equal(t, "bar", sec.Value)
+
+	sec, err = sm.Get(ctx, "My_Secret")
+	require.NoError(t, err)
+	require.Equal(t, "baz", sec.Value)
+
+	_, err = sm.Get(ctx, "unlabeled")
+	require.Equal(t, model.ErrNotFound, err)
+
+	secs, err := sm.List(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*model.Secret{
+		{Name: "foo", Value: "bar"},
+		{Name: "My_Secret", Value: "baz"},
+	}, secs)
+}
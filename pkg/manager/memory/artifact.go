@@ -0,0 +1,153 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"github.com/puppetlabs/relay-core/pkg/model"
+)
+
+type ArtifactKey struct {
+	StepName, Name string
+}
+
+type artifactEntry struct {
+	contentType string
+	data        []byte
+}
+
+type ArtifactMap struct {
+	mut       sync.RWMutex
+	artifacts map[ArtifactKey]*artifactEntry
+}
+
+func (m *ArtifactMap) Keys() []ArtifactKey {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	var l []ArtifactKey
+
+	for k := range m.artifacts {
+		l = append(l, k)
+	}
+
+	return l
+}
+
+func (m *ArtifactMap) Get(key ArtifactKey) (string, []byte, bool) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	entry, found := m.artifacts[key]
+	if !found {
+		return "", nil, false
+	}
+
+	return entry.contentType, entry.data, true
+}
+
+func (m *ArtifactMap) Set(key ArtifactKey, contentType string, data []byte) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	m.artifacts[key] = &artifactEntry{
+		contentType: contentType,
+		data:        data,
+	}
+}
+
+func NewArtifactMap() *ArtifactMap {
+	return &ArtifactMap{
+		artifacts: make(map[ArtifactKey]*artifactEntry),
+	}
+}
+
+type ArtifactManager struct {
+	me *model.Step
+	m  *ArtifactMap
+}
+
+var _ model.ArtifactManager = &ArtifactManager{}
+
+func (m *ArtifactManager) List(ctx context.Context) ([]*model.Artifact, error) {
+	var l []*model.Artifact
+
+	for _, key := range m.m.Keys() {
+		contentType, data, found := m.m.Get(key)
+		if !found {
+			continue
+		}
+
+		l = append(l, newArtifact(&model.Step{Run: m.me.Run, Name: key.StepName}, key.Name, contentType, data))
+	}
+
+	return l, nil
+}
+
+func (m *ArtifactManager) ListSelf(ctx context.Context) ([]*model.Artifact, error) {
+	var l []*model.Artifact
+
+	for _, key := range m.m.Keys() {
+		if key.StepName != m.me.Name {
+			continue
+		}
+
+		contentType, data, found := m.m.Get(key)
+		if !found {
+			continue
+		}
+
+		l = append(l, newArtifact(m.me, key.Name, contentType, data))
+	}
+
+	return l, nil
+}
+
+func (m *ArtifactManager) Get(ctx context.Context, stepName, name string, fn func(a *model.Artifact, r io.Reader) error) error {
+	step := &model.Step{
+		Run:  m.me.Run,
+		Name: stepName,
+	}
+
+	contentType, data, found := m.m.Get(ArtifactKey{StepName: stepName, Name: name})
+	if !found {
+		return model.ErrNotFound
+	}
+
+	return fn(newArtifact(step, name, contentType, data), bytes.NewReader(data))
+}
+
+func (m *ArtifactManager) Put(ctx context.Context, name, contentType string, r io.Reader) (*model.Artifact, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	m.m.Set(ArtifactKey{StepName: m.me.Name, Name: name}, contentType, data)
+
+	return newArtifact(m.me, name, contentType, data), nil
+}
+
+func NewArtifactManager(step *model.Step, backend *ArtifactMap) *ArtifactManager {
+	return &ArtifactManager{
+		me: step,
+		m:  backend,
+	}
+}
+
+func newArtifact(step *model.Step, name, contentType string, data []byte) *model.Artifact {
+	digest := sha256.Sum256(data)
+
+	return &model.Artifact{
+		Step:        step,
+		Name:        name,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Digest:      "sha256:" + hex.EncodeToString(digest[:]),
+	}
+}
@@ -0,0 +1,27 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/puppetlabs/relay-core/pkg/model"
+)
+
+type EventLimitsManager struct {
+	val *model.EventLimits
+}
+
+var _ model.EventLimitsGetterManager = &EventLimitsManager{}
+
+func (m *EventLimitsManager) Get(ctx context.Context) (*model.EventLimits, error) {
+	if m.val == nil {
+		return nil, model.ErrNotFound
+	}
+
+	return m.val, nil
+}
+
+func NewEventLimitsManager(el *model.EventLimits) *EventLimitsManager {
+	return &EventLimitsManager{
+		val: el,
+	}
+}
@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/puppetlabs/relay-core/pkg/model"
+)
+
+type StepOutputDeclarationManager struct {
+	mut   sync.RWMutex
+	decls []*model.StepOutputDeclaration
+}
+
+var _ model.StepOutputDeclarationManager = &StepOutputDeclarationManager{}
+
+func (m *StepOutputDeclarationManager) List(ctx context.Context) ([]*model.StepOutputDeclaration, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	return m.decls, nil
+}
+
+func (m *StepOutputDeclarationManager) Set(ctx context.Context, decls []*model.StepOutputDeclaration) error {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	m.decls = decls
+
+	return nil
+}
+
+type StepOutputDeclarationManagerOption func(sodm *StepOutputDeclarationManager)
+
+func StepOutputDeclarationManagerWithInitialDeclarations(decls []*model.StepOutputDeclaration) StepOutputDeclarationManagerOption {
+	return func(sodm *StepOutputDeclarationManager) {
+		sodm.decls = append(sodm.decls, decls...)
+	}
+}
+
+func NewStepOutputDeclarationManager(opts ...StepOutputDeclarationManagerOption) *StepOutputDeclarationManager {
+	sodm := &StepOutputDeclarationManager{}
+
+	for _, opt := range opts {
+		opt(sodm)
+	}
+
+	return sodm
+}
@@ -0,0 +1,27 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/puppetlabs/relay-core/pkg/model"
+)
+
+type WebhookVerificationManager struct {
+	val *model.WebhookVerification
+}
+
+var _ model.WebhookVerificationGetterManager = &WebhookVerificationManager{}
+
+func (m *WebhookVerificationManager) Get(ctx context.Context) (*model.WebhookVerification, error) {
+	if m.val == nil {
+		return nil, model.ErrNotFound
+	}
+
+	return m.val, nil
+}
+
+func NewWebhookVerificationManager(wv *model.WebhookVerification) *WebhookVerificationManager {
+	return &WebhookVerificationManager{
+		val: wv,
+	}
+}
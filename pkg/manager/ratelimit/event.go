@@ -0,0 +1,55 @@
+package ratelimit
+
+import (
+	"context"
+
+	metricsmodel "github.com/puppetlabs/relay-core/pkg/metrics/model"
+	"github.com/puppetlabs/relay-core/pkg/model"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"golang.org/x/time/rate"
+)
+
+type EventManagerOption func(em *EventManager)
+
+// EventManagerWithMeter records rejected events using the given meter.
+func EventManagerWithMeter(meter metric.Meter) EventManagerOption {
+	return func(em *EventManager) {
+		em.meter = meter
+	}
+}
+
+// EventManager rejects events with model.ErrRateLimited when the given limiter
+// has no tokens available. Otherwise, events are passed to the delegate.
+type EventManager struct {
+	delegate model.EventManager
+	limiter  *rate.Limiter
+	meter    metric.Meter
+}
+
+var _ model.EventManager = &EventManager{}
+
+func (m *EventManager) Emit(ctx context.Context, data map[string]interface{}, key string) (*model.Event, error) {
+	if !m.limiter.Allow() {
+		metric.Must(m.meter).NewInt64Counter(metricsmodel.MetricTriggerEventRejections).Add(ctx, 1,
+			attribute.String(metricsmodel.MetricAttributeReason, metricsmodel.TriggerEventRejectionReasonRateLimited),
+		)
+
+		return nil, model.ErrRateLimited
+	}
+
+	return m.delegate.Emit(ctx, data, key)
+}
+
+func NewEventManager(delegate model.EventManager, limiter *rate.Limiter, opts ...EventManagerOption) *EventManager {
+	em := &EventManager{
+		delegate: delegate,
+		limiter:  limiter,
+	}
+
+	for _, opt := range opts {
+		opt(em)
+	}
+
+	return em
+}
@@ -0,0 +1,61 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/puppetlabs/relay-core/pkg/manager/log"
+	"github.com/puppetlabs/relay-core/pkg/manager/ratelimit"
+	"github.com/puppetlabs/relay-core/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestEventManagerRateLimited(t *testing.T) {
+	ctx := context.Background()
+
+	limiters := ratelimit.NewLimiters()
+	em := ratelimit.NewEventManager(log.EventManager, limiters.Get("triggers/a", rate.Every(time.Hour), 2))
+
+	for i := 0; i < 2; i++ {
+		_, err := em.Emit(ctx, map[string]interface{}{"i": i}, "")
+		require.NoError(t, err)
+	}
+
+	_, err := em.Emit(ctx, map[string]interface{}{"i": 2}, "")
+	assert.Equal(t, model.ErrRateLimited, err)
+
+	// The bucket is shared between managers for the same key, but not with
+	// other keys.
+	em = ratelimit.NewEventManager(log.EventManager, limiters.Get("triggers/a", rate.Every(time.Hour), 2))
+	_, err = em.Emit(ctx, map[string]interface{}{"i": 3}, "")
+	assert.Equal(t, model.ErrRateLimited, err)
+
+	em = ratelimit.NewEventManager(log.EventManager, limiters.Get("triggers/b", rate.Every(time.Hour), 2))
+	_, err = em.Emit(ctx, map[string]interface{}{"i": 4}, "")
+	assert.NoError(t, err)
+}
+
+func TestLimitersUpdate(t *testing.T) {
+	limiters := ratelimit.NewLimiters()
+
+	l := limiters.Get("triggers/a", rate.Limit(5), 5)
+	assert.Equal(t, rate.Limit(5), l.Limit())
+	assert.Equal(t, 5, l.Burst())
+
+	updated := limiters.Get("triggers/a", rate.Limit(10), 20)
+	assert.Same(t, l, updated)
+	assert.Equal(t, rate.Limit(10), l.Limit())
+	assert.Equal(t, 20, l.Burst())
+}
+
+func TestLimitersIdleTimeout(t *testing.T) {
+	limiters := ratelimit.NewLimiters(ratelimit.LimitersWithIdleTimeout(time.Millisecond))
+
+	l := limiters.Get("triggers/a", rate.Limit(1), 1)
+	time.Sleep(5 * time.Millisecond)
+
+	assert.NotSame(t, l, limiters.Get("triggers/a", rate.Limit(1), 1))
+}
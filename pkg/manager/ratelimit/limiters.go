@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const DefaultLimiterIdleTimeout = 10 * time.Minute
+
+type LimitersOption func(l *Limiters)
+
+// LimitersWithIdleTimeout sets how long a limiter may go unused before it is
+// discarded. A discarded limiter is replaced by a full one the next time it is
+// requested, so the timeout should be longer than it takes for any limiter
+// to refill.
+func LimitersWithIdleTimeout(timeout time.Duration) LimitersOption {
+	return func(l *Limiters) {
+		l.idleTimeout = timeout
+	}
+}
+
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+// Limiters keeps a token bucket for each key it is asked about so that the
+// rate of requests for the key is tracked across requests.
+type Limiters struct {
+	idleTimeout time.Duration
+
+	mut       sync.Mutex
+	entries   map[string]*limiterEntry
+	lastSweep time.Time
+}
+
+// Get returns the limiter for the given key, creating it if needed. If the
+// limit or burst have changed since the limiter was created, the limiter is
+// updated to use the new values.
+func (l *Limiters) Get(key string, limit rate.Limit, burst int) *rate.Limiter {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.lastSweep) >= l.idleTimeout {
+		for k, entry := range l.entries {
+			if now.Sub(entry.lastUsed) >= l.idleTimeout {
+				delete(l.entries, k)
+			}
+		}
+
+		l.lastSweep = now
+	}
+
+	entry, found := l.entries[key]
+	if !found {
+		entry = &limiterEntry{limiter: rate.NewLimiter(limit, burst)}
+		l.entries[key] = entry
+	} else {
+		if entry.limiter.Limit() != limit {
+			entry.limiter.SetLimitAt(now, limit)
+		}
+
+		if entry.limiter.Burst() != burst {
+			entry.limiter.SetBurstAt(now, burst)
+		}
+	}
+
+	entry.lastUsed = now
+	return entry.limiter
+}
+
+func NewLimiters(opts ...LimitersOption) *Limiters {
+	l := &Limiters{
+		idleTimeout: DefaultLimiterIdleTimeout,
+		entries:     make(map[string]*limiterEntry),
+		lastSweep:   time.Now(),
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
@@ -0,0 +1,28 @@
+package reject
+
+import (
+	"context"
+	"io"
+
+	"github.com/puppetlabs/relay-core/pkg/model"
+)
+
+type artifactManager struct{}
+
+func (*artifactManager) List(ctx context.Context) ([]*model.Artifact, error) {
+	return nil, model.ErrRejected
+}
+
+func (*artifactManager) ListSelf(ctx context.Context) ([]*model.Artifact, error) {
+	return nil, model.ErrRejected
+}
+
+func (*artifactManager) Get(ctx context.Context, stepName, name string, fn func(a *model.Artifact, r io.Reader) error) error {
+	return model.ErrRejected
+}
+
+func (*artifactManager) Put(ctx context.Context, name, contentType string, r io.Reader) (*model.Artifact, error) {
+	return nil, model.ErrRejected
+}
+
+var ArtifactManager model.ArtifactManager = &artifactManager{}
@@ -0,0 +1,15 @@
+package reject
+
+import (
+	"context"
+
+	"github.com/puppetlabs/relay-core/pkg/model"
+)
+
+type eventLimitsManager struct{}
+
+func (*eventLimitsManager) Get(ctx context.Context) (*model.EventLimits, error) {
+	return nil, model.ErrRejected
+}
+
+var EventLimitsManager model.EventLimitsGetterManager = &eventLimitsManager{}
@@ -0,0 +1,19 @@
+package reject
+
+import (
+	"context"
+
+	"github.com/puppetlabs/relay-core/pkg/model"
+)
+
+type stepOutputDeclarationManager struct{}
+
+func (*stepOutputDeclarationManager) List(ctx context.Context) ([]*model.StepOutputDeclaration, error) {
+	return nil, model.ErrRejected
+}
+
+func (*stepOutputDeclarationManager) Set(ctx context.Context, decls []*model.StepOutputDeclaration) error {
+	return model.ErrRejected
+}
+
+var StepOutputDeclarationManager model.StepOutputDeclarationManager = &stepOutputDeclarationManager{}
@@ -0,0 +1,15 @@
+package reject
+
+import (
+	"context"
+
+	"github.com/puppetlabs/relay-core/pkg/model"
+)
+
+type webhookVerificationManager struct{}
+
+func (*webhookVerificationManager) Get(ctx context.Context) (*model.WebhookVerification, error) {
+	return nil, model.ErrRejected
+}
+
+var WebhookVerificationManager model.WebhookVerificationGetterManager = &webhookVerificationManager{}
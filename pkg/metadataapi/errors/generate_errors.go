@@ -240,6 +240,57 @@ func NewAPIObjectSerializationError() Error {
 	return NewAPIObjectSerializationErrorBuilder().Build()
 }
 
+// APIRequestTooLargeErrorCode is the code for an instance of "request_too_large_error".
+const APIRequestTooLargeErrorCode = "rma_api_request_too_large_error"
+
+// IsAPIRequestTooLargeError tests whether a given error is an instance of "request_too_large_error".
+func IsAPIRequestTooLargeError(err errawr.Error) bool {
+	return err != nil && err.Is(APIRequestTooLargeErrorCode)
+}
+
+// IsAPIRequestTooLargeError tests whether a given error is an instance of "request_too_large_error".
+func (External) IsAPIRequestTooLargeError(err errawr.Error) bool {
+	return IsAPIRequestTooLargeError(err)
+}
+
+// APIRequestTooLargeErrorBuilder is a builder for "request_too_large_error" errors.
+type APIRequestTooLargeErrorBuilder struct {
+	arguments impl.ErrorArguments
+}
+
+// Build creates the error for the code "request_too_large_error" from this builder.
+func (b *APIRequestTooLargeErrorBuilder) Build() Error {
+	description := &impl.ErrorDescription{
+		Friendly:  "The request body is larger than the maximum of {{maxSize}} bytes.",
+		Technical: "The request body is larger than the maximum of {{maxSize}} bytes.",
+	}
+
+	return &impl.Error{
+		ErrorArguments:   b.arguments,
+		ErrorCode:        "request_too_large_error",
+		ErrorDescription: description,
+		ErrorDomain:      Domain,
+		ErrorMetadata: &impl.ErrorMetadata{HTTPErrorMetadata: &impl.HTTPErrorMetadata{
+			ErrorHeaders: impl.HTTPErrorMetadataHeaders{},
+			ErrorStatus:  413,
+		}},
+		ErrorSection:     APISection,
+		ErrorSensitivity: errawr.ErrorSensitivityNone,
+		ErrorTitle:       "Request too large",
+		Version:          1,
+	}
+}
+
+// NewAPIRequestTooLargeErrorBuilder creates a new error builder for the code "request_too_large_error".
+func NewAPIRequestTooLargeErrorBuilder(maxSize int64) *APIRequestTooLargeErrorBuilder {
+	return &APIRequestTooLargeErrorBuilder{arguments: impl.ErrorArguments{"maxSize": impl.NewErrorArgument(maxSize, "the maximum size of the request body")}}
+}
+
+// NewAPIRequestTooLargeError creates a new error with the code "request_too_large_error".
+func NewAPIRequestTooLargeError(maxSize int64) Error {
+	return NewAPIRequestTooLargeErrorBuilder(maxSize).Build()
+}
+
 // APIUnknownRequestMediaTypeErrorCode is the code for an instance of "unknown_request_media_type_error".
 const APIUnknownRequestMediaTypeErrorCode = "rma_api_unknown_request_media_type_error"
 
@@ -291,6 +342,67 @@ func NewAPIUnknownRequestMediaTypeError(mediaType string) Error {
 	return NewAPIUnknownRequestMediaTypeErrorBuilder(mediaType).Build()
 }
 
+// ArtifactSection defines a section of errors with the following scope:
+// Artifact errors
+var ArtifactSection = &impl.ErrorSection{
+	Key:   "artifact",
+	Title: "Artifact errors",
+}
+
+// ArtifactInvalidNameErrorCode is the code for an instance of "invalid_name_error".
+const ArtifactInvalidNameErrorCode = "rma_artifact_invalid_name_error"
+
+// IsArtifactInvalidNameError tests whether a given error is an instance of "invalid_name_error".
+func IsArtifactInvalidNameError(err errawr.Error) bool {
+	return err != nil && err.Is(ArtifactInvalidNameErrorCode)
+}
+
+// IsArtifactInvalidNameError tests whether a given error is an instance of "invalid_name_error".
+func (External) IsArtifactInvalidNameError(err errawr.Error) bool {
+	return IsArtifactInvalidNameError(err)
+}
+
+// ArtifactInvalidNameErrorBuilder is a builder for "invalid_name_error" errors.
+type ArtifactInvalidNameErrorBuilder struct {
+	arguments impl.ErrorArguments
+}
+
+// Build creates the error for the code "invalid_name_error" from this builder.
+func (b *ArtifactInvalidNameErrorBuilder) Build() Error {
+	description := &impl.ErrorDescription{
+		Friendly:  "The name {{quote name}} cannot be used for an artifact: {{reason}}",
+		Technical: "The name {{quote name}} cannot be used for an artifact: {{reason}}",
+	}
+
+	return &impl.Error{
+		ErrorArguments:   b.arguments,
+		ErrorCode:        "invalid_name_error",
+		ErrorDescription: description,
+		ErrorDomain:      Domain,
+		ErrorMetadata: &impl.ErrorMetadata{HTTPErrorMetadata: &impl.HTTPErrorMetadata{
+			ErrorHeaders: impl.HTTPErrorMetadataHeaders{},
+			ErrorStatus:  422,
+		}},
+		ErrorSection:     ArtifactSection,
+		ErrorSensitivity: errawr.ErrorSensitivityNone,
+		ErrorTitle:       "Invalid name",
+		Version:          1,
+	}
+}
+
+// NewArtifactInvalidNameErrorBuilder creates a new error builder for the code "invalid_name_error".
+func NewArtifactInvalidNameErrorBuilder(name string, reason string) *ArtifactInvalidNameErrorBuilder {
+	return &ArtifactInvalidNameErrorBuilder{arguments: impl.ErrorArguments{
+		"name":   impl.NewErrorArgument(name, "the name of the artifact"),
+		"reason": impl.NewErrorArgument(reason, "why the name is not valid"),
+	}}
+}
+
+// NewArtifactInvalidNameError creates a new error with the code "invalid_name_error".
+func NewArtifactInvalidNameError(name string, reason string) Error {
+	return NewArtifactInvalidNameErrorBuilder(name, reason).Build()
+}
+
 // ConditionSection defines a section of errors with the following scope:
 // Condition errors
 var ConditionSection = &impl.ErrorSection{
@@ -669,6 +781,57 @@ func NewModelNotFoundError() Error {
 	return NewModelNotFoundErrorBuilder().Build()
 }
 
+// ModelRateLimitedErrorCode is the code for an instance of "rate_limited_error".
+const ModelRateLimitedErrorCode = "rma_model_rate_limited_error"
+
+// IsModelRateLimitedError tests whether a given error is an instance of "rate_limited_error".
+func IsModelRateLimitedError(err errawr.Error) bool {
+	return err != nil && err.Is(ModelRateLimitedErrorCode)
+}
+
+// IsModelRateLimitedError tests whether a given error is an instance of "rate_limited_error".
+func (External) IsModelRateLimitedError(err errawr.Error) bool {
+	return IsModelRateLimitedError(err)
+}
+
+// ModelRateLimitedErrorBuilder is a builder for "rate_limited_error" errors.
+type ModelRateLimitedErrorBuilder struct {
+	arguments impl.ErrorArguments
+}
+
+// Build creates the error for the code "rate_limited_error" from this builder.
+func (b *ModelRateLimitedErrorBuilder) Build() Error {
+	description := &impl.ErrorDescription{
+		Friendly:  "You have made too many requests for this resource. Try again later.",
+		Technical: "You have made too many requests for this resource. Try again later.",
+	}
+
+	return &impl.Error{
+		ErrorArguments:   b.arguments,
+		ErrorCode:        "rate_limited_error",
+		ErrorDescription: description,
+		ErrorDomain:      Domain,
+		ErrorMetadata: &impl.ErrorMetadata{HTTPErrorMetadata: &impl.HTTPErrorMetadata{
+			ErrorHeaders: impl.HTTPErrorMetadataHeaders{},
+			ErrorStatus:  429,
+		}},
+		ErrorSection:     ModelSection,
+		ErrorSensitivity: errawr.ErrorSensitivityNone,
+		ErrorTitle:       "Too many requests",
+		Version:          1,
+	}
+}
+
+// NewModelRateLimitedErrorBuilder creates a new error builder for the code "rate_limited_error".
+func NewModelRateLimitedErrorBuilder() *ModelRateLimitedErrorBuilder {
+	return &ModelRateLimitedErrorBuilder{arguments: impl.ErrorArguments{}}
+}
+
+// NewModelRateLimitedError creates a new error with the code "rate_limited_error".
+func NewModelRateLimitedError() Error {
+	return NewModelRateLimitedErrorBuilder().Build()
+}
+
 // ModelReadErrorCode is the code for an instance of "read_error".
 const ModelReadErrorCode = "rma_model_read_error"
 
@@ -764,3 +927,275 @@ func NewModelWriteErrorBuilder() *ModelWriteErrorBuilder {
 func NewModelWriteError() Error {
 	return NewModelWriteErrorBuilder().Build()
 }
+
+// OutputSection defines a section of errors with the following scope:
+// Output errors
+var OutputSection = &impl.ErrorSection{
+	Key:   "output",
+	Title: "Output errors",
+}
+
+// OutputInvalidSchemaErrorCode is the code for an instance of "invalid_schema_error".
+const OutputInvalidSchemaErrorCode = "rma_output_invalid_schema_error"
+
+// IsOutputInvalidSchemaError tests whether a given error is an instance of "invalid_schema_error".
+func IsOutputInvalidSchemaError(err errawr.Error) bool {
+	return err != nil && err.Is(OutputInvalidSchemaErrorCode)
+}
+
+// IsOutputInvalidSchemaError tests whether a given error is an instance of "invalid_schema_error".
+func (External) IsOutputInvalidSchemaError(err errawr.Error) bool {
+	return IsOutputInvalidSchemaError(err)
+}
+
+// OutputInvalidSchemaErrorBuilder is a builder for "invalid_schema_error" errors.
+type OutputInvalidSchemaErrorBuilder struct {
+	arguments impl.ErrorArguments
+}
+
+// Build creates the error for the code "invalid_schema_error" from this builder.
+func (b *OutputInvalidSchemaErrorBuilder) Build() Error {
+	description := &impl.ErrorDescription{
+		Friendly:  "The schema declared for the output {{quote name}} is not a valid JSON Schema, so values for it cannot be checked: {{error}}",
+		Technical: "The schema declared for the output {{quote name}} is not a valid JSON Schema, so values for it cannot be checked: {{error}}",
+	}
+
+	return &impl.Error{
+		ErrorArguments:   b.arguments,
+		ErrorCode:        "invalid_schema_error",
+		ErrorDescription: description,
+		ErrorDomain:      Domain,
+		ErrorMetadata:    &impl.ErrorMetadata{},
+		ErrorSection:     OutputSection,
+		ErrorSensitivity: errawr.ErrorSensitivityNone,
+		ErrorTitle:       "Invalid schema",
+		Version:          1,
+	}
+}
+
+// NewOutputInvalidSchemaErrorBuilder creates a new error builder for the code "invalid_schema_error".
+func NewOutputInvalidSchemaErrorBuilder(name string, error string) *OutputInvalidSchemaErrorBuilder {
+	return &OutputInvalidSchemaErrorBuilder{arguments: impl.ErrorArguments{
+		"error": impl.NewErrorArgument(error, "the problem with the schema"),
+		"name":  impl.NewErrorArgument(name, "the name of the output"),
+	}}
+}
+
+// NewOutputInvalidSchemaError creates a new error with the code "invalid_schema_error".
+func NewOutputInvalidSchemaError(name string, error string) Error {
+	return NewOutputInvalidSchemaErrorBuilder(name, error).Build()
+}
+
+// OutputMetadataDeclaredErrorCode is the code for an instance of "metadata_declared_error".
+const OutputMetadataDeclaredErrorCode = "rma_output_metadata_declared_error"
+
+// IsOutputMetadataDeclaredError tests whether a given error is an instance of "metadata_declared_error".
+func IsOutputMetadataDeclaredError(err errawr.Error) bool {
+	return err != nil && err.Is(OutputMetadataDeclaredErrorCode)
+}
+
+// IsOutputMetadataDeclaredError tests whether a given error is an instance of "metadata_declared_error".
+func (External) IsOutputMetadataDeclaredError(err errawr.Error) bool {
+	return IsOutputMetadataDeclaredError(err)
+}
+
+// OutputMetadataDeclaredErrorBuilder is a builder for "metadata_declared_error" errors.
+type OutputMetadataDeclaredErrorBuilder struct {
+	arguments impl.ErrorArguments
+}
+
+// Build creates the error for the code "metadata_declared_error" from this builder.
+func (b *OutputMetadataDeclaredErrorBuilder) Build() Error {
+	description := &impl.ErrorDescription{
+		Friendly:  "The metadata for the output {{quote name}} is provided by its declaration and cannot be changed.",
+		Technical: "The metadata for the output {{quote name}} is provided by its declaration and cannot be changed.",
+	}
+
+	return &impl.Error{
+		ErrorArguments:   b.arguments,
+		ErrorCode:        "metadata_declared_error",
+		ErrorDescription: description,
+		ErrorDomain:      Domain,
+		ErrorMetadata: &impl.ErrorMetadata{HTTPErrorMetadata: &impl.HTTPErrorMetadata{
+			ErrorHeaders: impl.HTTPErrorMetadataHeaders{},
+			ErrorStatus:  409,
+		}},
+		ErrorSection:     OutputSection,
+		ErrorSensitivity: errawr.ErrorSensitivityNone,
+		ErrorTitle:       "Metadata declared",
+		Version:          1,
+	}
+}
+
+// NewOutputMetadataDeclaredErrorBuilder creates a new error builder for the code "metadata_declared_error".
+func NewOutputMetadataDeclaredErrorBuilder(name string) *OutputMetadataDeclaredErrorBuilder {
+	return &OutputMetadataDeclaredErrorBuilder{arguments: impl.ErrorArguments{"name": impl.NewErrorArgument(name, "the name of the output")}}
+}
+
+// NewOutputMetadataDeclaredError creates a new error with the code "metadata_declared_error".
+func NewOutputMetadataDeclaredError(name string) Error {
+	return NewOutputMetadataDeclaredErrorBuilder(name).Build()
+}
+
+// OutputSchemaValidationErrorCode is the code for an instance of "schema_validation_error".
+const OutputSchemaValidationErrorCode = "rma_output_schema_validation_error"
+
+// IsOutputSchemaValidationError tests whether a given error is an instance of "schema_validation_error".
+func IsOutputSchemaValidationError(err errawr.Error) bool {
+	return err != nil && err.Is(OutputSchemaValidationErrorCode)
+}
+
+// IsOutputSchemaValidationError tests whether a given error is an instance of "schema_validation_error".
+func (External) IsOutputSchemaValidationError(err errawr.Error) bool {
+	return IsOutputSchemaValidationError(err)
+}
+
+// OutputSchemaValidationErrorBuilder is a builder for "schema_validation_error" errors.
+type OutputSchemaValidationErrorBuilder struct {
+	arguments impl.ErrorArguments
+}
+
+// Build creates the error for the code "schema_validation_error" from this builder.
+func (b *OutputSchemaValidationErrorBuilder) Build() Error {
+	description := &impl.ErrorDescription{
+		Friendly:  "The value for the output {{quote name}} does not conform to its declared schema: {{error}}",
+		Technical: "The value for the output {{quote name}} does not conform to its declared schema: {{error}}",
+	}
+
+	return &impl.Error{
+		ErrorArguments:   b.arguments,
+		ErrorCode:        "schema_validation_error",
+		ErrorDescription: description,
+		ErrorDomain:      Domain,
+		ErrorMetadata: &impl.ErrorMetadata{HTTPErrorMetadata: &impl.HTTPErrorMetadata{
+			ErrorHeaders: impl.HTTPErrorMetadataHeaders{},
+			ErrorStatus:  422,
+		}},
+		ErrorSection:     OutputSection,
+		ErrorSensitivity: errawr.ErrorSensitivityNone,
+		ErrorTitle:       "Schema validation error",
+		Version:          1,
+	}
+}
+
+// NewOutputSchemaValidationErrorBuilder creates a new error builder for the code "schema_validation_error".
+func NewOutputSchemaValidationErrorBuilder(name string, error string) *OutputSchemaValidationErrorBuilder {
+	return &OutputSchemaValidationErrorBuilder{arguments: impl.ErrorArguments{
+		"error": impl.NewErrorArgument(error, "the validation problem"),
+		"name":  impl.NewErrorArgument(name, "the name of the output"),
+	}}
+}
+
+// NewOutputSchemaValidationError creates a new error with the code "schema_validation_error".
+func NewOutputSchemaValidationError(name string, error string) Error {
+	return NewOutputSchemaValidationErrorBuilder(name, error).Build()
+}
+
+// OutputUndeclaredErrorCode is the code for an instance of "undeclared_error".
+const OutputUndeclaredErrorCode = "rma_output_undeclared_error"
+
+// IsOutputUndeclaredError tests whether a given error is an instance of "undeclared_error".
+func IsOutputUndeclaredError(err errawr.Error) bool {
+	return err != nil && err.Is(OutputUndeclaredErrorCode)
+}
+
+// IsOutputUndeclaredError tests whether a given error is an instance of "undeclared_error".
+func (External) IsOutputUndeclaredError(err errawr.Error) bool {
+	return IsOutputUndeclaredError(err)
+}
+
+// OutputUndeclaredErrorBuilder is a builder for "undeclared_error" errors.
+type OutputUndeclaredErrorBuilder struct {
+	arguments impl.ErrorArguments
+}
+
+// Build creates the error for the code "undeclared_error" from this builder.
+func (b *OutputUndeclaredErrorBuilder) Build() Error {
+	description := &impl.ErrorDescription{
+		Friendly:  "The output {{quote name}} is not declared by this step.",
+		Technical: "The output {{quote name}} is not declared by this step.",
+	}
+
+	return &impl.Error{
+		ErrorArguments:   b.arguments,
+		ErrorCode:        "undeclared_error",
+		ErrorDescription: description,
+		ErrorDomain:      Domain,
+		ErrorMetadata: &impl.ErrorMetadata{HTTPErrorMetadata: &impl.HTTPErrorMetadata{
+			ErrorHeaders: impl.HTTPErrorMetadataHeaders{},
+			ErrorStatus:  422,
+		}},
+		ErrorSection:     OutputSection,
+		ErrorSensitivity: errawr.ErrorSensitivityNone,
+		ErrorTitle:       "Undeclared output",
+		Version:          1,
+	}
+}
+
+// NewOutputUndeclaredErrorBuilder creates a new error builder for the code "undeclared_error".
+func NewOutputUndeclaredErrorBuilder(name string) *OutputUndeclaredErrorBuilder {
+	return &OutputUndeclaredErrorBuilder{arguments: impl.ErrorArguments{"name": impl.NewErrorArgument(name, "the name of the output")}}
+}
+
+// NewOutputUndeclaredError creates a new error with the code "undeclared_error".
+func NewOutputUndeclaredError(name string) Error {
+	return NewOutputUndeclaredErrorBuilder(name).Build()
+}
+
+// WebhookSection defines a section of errors with the following scope:
+// Webhook errors
+var WebhookSection = &impl.ErrorSection{
+	Key:   "webhook",
+	Title: "Webhook errors",
+}
+
+// WebhookVerificationErrorCode is the code for an instance of "verification_error".
+const WebhookVerificationErrorCode = "rma_webhook_verification_error"
+
+// IsWebhookVerificationError tests whether a given error is an instance of "verification_error".
+func IsWebhookVerificationError(err errawr.Error) bool {
+	return err != nil && err.Is(WebhookVerificationErrorCode)
+}
+
+// IsWebhookVerificationError tests whether a given error is an instance of "verification_error".
+func (External) IsWebhookVerificationError(err errawr.Error) bool {
+	return IsWebhookVerificationError(err)
+}
+
+// WebhookVerificationErrorBuilder is a builder for "verification_error" errors.
+type WebhookVerificationErrorBuilder struct {
+	arguments impl.ErrorArguments
+}
+
+// Build creates the error for the code "verification_error" from this builder.
+func (b *WebhookVerificationErrorBuilder) Build() Error {
+	description := &impl.ErrorDescription{
+		Friendly:  "The webhook request could not be verified: {{reason}}",
+		Technical: "The webhook request could not be verified: {{reason}}",
+	}
+
+	return &impl.Error{
+		ErrorArguments:   b.arguments,
+		ErrorCode:        "verification_error",
+		ErrorDescription: description,
+		ErrorDomain:      Domain,
+		ErrorMetadata: &impl.ErrorMetadata{HTTPErrorMetadata: &impl.HTTPErrorMetadata{
+			ErrorHeaders: impl.HTTPErrorMetadataHeaders{},
+			ErrorStatus:  401,
+		}},
+		ErrorSection:     WebhookSection,
+		ErrorSensitivity: errawr.ErrorSensitivityNone,
+		ErrorTitle:       "Verification failed",
+		Version:          1,
+	}
+}
+
+// NewWebhookVerificationErrorBuilder creates a new error builder for the code "verification_error".
+func NewWebhookVerificationErrorBuilder(reason string) *WebhookVerificationErrorBuilder {
+	return &WebhookVerificationErrorBuilder{arguments: impl.ErrorArguments{"reason": impl.NewErrorArgument(reason, "why the request is not valid")}}
+}
+
+// NewWebhookVerificationError creates a new error with the code "verification_error".
+func NewWebhookVerificationError(reason string) Error {
+	return NewWebhookVerificationErrorBuilder(reason).Build()
+}
@@ -4,18 +4,31 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net"
+	"net/http"
 	"net/url"
 	"os"
+	"time"
 
 	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/puppetlabs/leg/storage"
+	_ "github.com/puppetlabs/leg/storage/file"
+	_ "github.com/puppetlabs/leg/storage/gcs"
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
 	"github.com/puppetlabs/relay-core/pkg/authenticate"
+	"github.com/puppetlabs/relay-core/pkg/manager/audit"
+	"github.com/puppetlabs/relay-core/pkg/manager/configmap"
 	"github.com/puppetlabs/relay-pls/pkg/plspb"
 	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/exporters/metric/prometheus"
+	"go.opentelemetry.io/otel/metric"
 	"google.golang.org/grpc"
 	yaml "gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/cert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -23,10 +36,29 @@ const (
 	DefaultVaultURL        = "http://localhost:8200"
 	DefaultStepMetadataURL = "https://relay.sh/step-metadata.json"
 
+	MetricsModuleName = "relay_metadata_api"
+
+	SecretsBackendVault      = "vault"
+	SecretsBackendKubernetes = "kubernetes"
+
 	DefaultKubernetesAutomountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
 	DefaultKubernetesAutomountCAFile    = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
 )
 
+// Scheme contains the types the metadata API manages using its own service
+// account.
+var Scheme = runtime.NewScheme()
+
+func init() {
+	if err := relayv1beta1.AddToScheme(Scheme); err != nil {
+		panic(err)
+	}
+
+	if err := corev1.AddToScheme(Scheme); err != nil {
+		panic(err)
+	}
+}
+
 type Config struct {
 	// Debug determines whether this server starts with debugging enabled.
 	Debug bool
@@ -49,10 +81,23 @@ type Config struct {
 	// LogServiceURL is the HTTP(S) url to the log service
 	LogServiceURL string
 
+	// ArtifactStorageURL is the URL of the blob store to use for step
+	// artifacts, e.g. gs://bucket/path or file:///path. If unset, artifact
+	// uploads and downloads are rejected.
+	ArtifactStorageURL string
+
 	// StepMetadataURL is the HTTP(S) url to the relaysh core step metadata
 	// json file.
 	StepMetadataURL string
 
+	// SecretsBackend determines where the secrets and connections of tenants
+	// are read from. It is either "vault" (the default), which uses the paths
+	// given in the claims of each token, or "kubernetes", which reads labeled
+	// secrets in the tenant namespace using the service account of the
+	// metadata API. The latter requires that the service account be allowed
+	// to list secrets in tenant namespaces.
+	SecretsBackend string
+
 	// VaultTransitURL is the HTTP(S) URL to the Vault server to use for secure
 	// token decryption.
 	VaultTransitURL string
@@ -101,6 +146,29 @@ type Config struct {
 	// SentryDSN is an optional identifier to automatically log API errors to
 	// Sentry.
 	SentryDSN string
+
+	// MetricsServerAddr is the address to serve Prometheus metrics on. If
+	// unset, metrics are not exported.
+	MetricsServerAddr string
+
+	// AuditLogFile is the path to a file to append audit records of secret,
+	// connection and sensitive output reads to, as JSON lines. If it is "-",
+	// records are written to standard output. If unset, reads are not
+	// audited.
+	AuditLogFile string
+
+	// AuditEventSinkURL is the URL of an HTTP service to send audit records
+	// to, one JSON object per request. It may be used together with
+	// AuditLogFile.
+	AuditEventSinkURL string
+
+	// AuditEventSinkToken is the bearer token used to authenticate to the
+	// audit event sink.
+	AuditEventSinkToken string
+
+	// EventDeduplicationWindow is how long the key of a trigger event is
+	// remembered to discard duplicate deliveries.
+	EventDeduplicationWindow time.Duration
 }
 
 func (c *Config) kubernetesInClusterHost() (string, bool) {
@@ -154,7 +222,7 @@ func (c *Config) KubernetesClientFactory(token string) (kubernetes.Interface, er
 	return kubernetes.NewForConfig(cfg)
 }
 
-func (c *Config) KubernetesClient() (*authenticate.KubernetesInterface, error) {
+func (c *Config) serviceAccountClientConfig() (*rest.Config, error) {
 	cfg, err := c.kubernetesClientConfig()
 	if err != nil {
 		return nil, err
@@ -176,9 +244,44 @@ func (c *Config) KubernetesClient() (*authenticate.KubernetesInterface, error) {
 		cfg.BearerTokenFile = DefaultKubernetesAutomountTokenFile
 	}
 
+	return cfg, nil
+}
+
+func (c *Config) KubernetesClient() (*authenticate.KubernetesInterface, error) {
+	cfg, err := c.serviceAccountClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	return authenticate.NewKubernetesInterfaceForConfig(cfg)
 }
 
+// RunClient returns a client that uses the service account of the metadata API
+// to manage Relay objects and, if configured, to read tenant secrets.
+func (c *Config) RunClient() (client.Client, error) {
+	cfg, err := c.serviceAccountClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.New(cfg, client.Options{Scheme: Scheme})
+}
+
+// Metrics returns the meter used to record the metrics of the metadata API
+// and, if metrics are enabled, an HTTP handler that exports them.
+func (c *Config) Metrics() (metric.Meter, http.Handler, error) {
+	if c.MetricsServerAddr == "" {
+		return metric.Meter{}, nil, nil
+	}
+
+	exporter, err := prometheus.InstallNewPipeline(prometheus.Config{})
+	if err != nil {
+		return metric.Meter{}, nil, err
+	}
+
+	return exporter.MeterProvider().Meter(MetricsModuleName), exporter, nil
+}
+
 func (c *Config) LogServiceClient() (plspb.LogClient, error) {
 	if c.LogServiceURL == "" {
 		return nil, nil
@@ -192,6 +295,51 @@ func (c *Config) LogServiceClient() (plspb.LogClient, error) {
 	return plspb.NewLogClient(conn), nil
 }
 
+func (c *Config) ArtifactStorage() (storage.BlobStore, error) {
+	if c.ArtifactStorageURL == "" {
+		return nil, nil
+	}
+
+	u, err := url.Parse(c.ArtifactStorageURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse artifact storage URL: %+v", err)
+	}
+
+	return storage.NewBlobStore(*u)
+}
+
+// AuditSink returns the sink for audit records, or nil if auditing is
+// disabled.
+func (c *Config) AuditSink() (audit.Sink, error) {
+	var sinks []audit.Sink
+
+	switch c.AuditLogFile {
+	case "":
+	case "-":
+		sinks = append(sinks, audit.NewWriterSink(os.Stdout))
+	default:
+		f, err := os.OpenFile(c.AuditLogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open audit log file: %+v", err)
+		}
+
+		sinks = append(sinks, audit.NewWriterSink(f))
+	}
+
+	if c.AuditEventSinkURL != "" {
+		sinks = append(sinks, audit.NewEventSink(c.AuditEventSinkURL, audit.EventSinkWithToken(c.AuditEventSinkToken)))
+	}
+
+	switch len(sinks) {
+	case 0:
+		return nil, nil
+	case 1:
+		return sinks[0], nil
+	default:
+		return audit.NewMultiSink(sinks...), nil
+	}
+}
+
 func (c *Config) VaultTransitClient() (*vaultapi.Client, error) {
 	// Transit is authoritative so can safely fall back to the default config.
 	cfg := vaultapi.DefaultConfig()
@@ -248,6 +396,8 @@ func NewConfig() *Config {
 	viper.SetDefault("environment", "dev")
 	viper.SetDefault("listen_port", DefaultListenPort)
 
+	viper.SetDefault("secrets_backend", SecretsBackendVault)
+
 	viper.SetDefault("vault_transit_url", viper.GetString("vault_addr"))
 	viper.SetDefault("vault_transit_token", viper.GetString("vault_token"))
 	viper.SetDefault("vault_transit_path", "transit")
@@ -258,6 +408,8 @@ func NewConfig() *Config {
 
 	viper.SetDefault("step_metadata_url", DefaultStepMetadataURL)
 
+	viper.SetDefault("event_deduplication_window", configmap.DefaultEventDeduplicationWindow)
+
 	return &Config{
 		Debug:       viper.GetBool("debug"),
 		Environment: viper.GetString("environment"),
@@ -268,8 +420,12 @@ func NewConfig() *Config {
 
 		LogServiceURL: viper.GetString("log_service_url"),
 
+		ArtifactStorageURL: viper.GetString("artifact_storage_url"),
+
 		StepMetadataURL: viper.GetString("step_metadata_url"),
 
+		SecretsBackend: viper.GetString("secrets_backend"),
+
 		VaultTransitURL:   viper.GetString("vault_transit_url"),
 		VaultTransitToken: viper.GetString("vault_transit_token"),
 		VaultTransitPath:  viper.GetString("vault_transit_path"),
@@ -287,5 +443,13 @@ func NewConfig() *Config {
 		SampleHS256SigningKey: viper.GetString("sample_hs256_signing_key"),
 
 		SentryDSN: viper.GetString("sentry_dsn"),
+
+		MetricsServerAddr: viper.GetString("metrics_server_addr"),
+
+		EventDeduplicationWindow: viper.GetDuration("event_deduplication_window"),
+
+		AuditLogFile:        viper.GetString("audit_log_file"),
+		AuditEventSinkURL:   viper.GetString("audit_event_sink_url"),
+		AuditEventSinkToken: viper.GetString("audit_event_sink_token"),
 	}
 }
@@ -54,13 +54,21 @@ func (sp SampleConfigEnvironment) Interface() map[string]interface{} {
 	return copy
 }
 
+type SampleConfigOutputDeclaration struct {
+	Name        string      `yaml:"name"`
+	Description string      `yaml:"description"`
+	Schema      interface{} `yaml:"schema"`
+	Sensitive   bool        `yaml:"sensitive"`
+}
+
 type SampleConfigStep struct {
-	Conditions spec.YAMLTree           `yaml:"conditions"`
-	Env        SampleConfigEnvironment `yaml:"env"`
-	Spec       SampleConfigSpec        `yaml:"spec"`
-	Image      string                  `yaml:"image"`
-	Outputs    map[string]interface{}  `yaml:"outputs"`
-	State      map[string]interface{}  `yaml:"state"`
+	Conditions         spec.YAMLTree                    `yaml:"conditions"`
+	Env                SampleConfigEnvironment          `yaml:"env"`
+	Spec               SampleConfigSpec                 `yaml:"spec"`
+	Image              string                           `yaml:"image"`
+	Outputs            map[string]interface{}           `yaml:"outputs"`
+	OutputDeclarations []*SampleConfigOutputDeclaration `yaml:"outputDeclarations"`
+	State              map[string]interface{}           `yaml:"state"`
 }
 
 type SampleConfigRun struct {
@@ -68,7 +76,25 @@ type SampleConfigRun struct {
 	Steps      map[string]*SampleConfigStep `yaml:"steps"`
 }
 
-type SampleConfigTrigger struct{}
+type SampleConfigWebhookVerification struct {
+	Scheme     string `yaml:"scheme"`
+	SecretName string `yaml:"secretName"`
+	Header     string `yaml:"header"`
+	Algorithm  string `yaml:"algorithm"`
+	Prefix     string `yaml:"prefix"`
+	Encoding   string `yaml:"encoding"`
+}
+
+type SampleConfigWebhookLimits struct {
+	RequestsPerSecond int32 `yaml:"requestsPerSecond"`
+	Burst             int32 `yaml:"burst"`
+	MaxBodySize       int64 `yaml:"maxBodySize"`
+}
+
+type SampleConfigTrigger struct {
+	Verification *SampleConfigWebhookVerification `yaml:"verification"`
+	Limits       *SampleConfigWebhookLimits       `yaml:"limits"`
+}
 
 type SampleConfig struct {
 	Connections SampleConfigConnections         `yaml:"connections"`
@@ -8,17 +8,20 @@ import (
 	"github.com/puppetlabs/relay-core/pkg/manager/builder"
 	mlog "github.com/puppetlabs/relay-core/pkg/manager/log"
 	"github.com/puppetlabs/relay-core/pkg/manager/memory"
+	"github.com/puppetlabs/relay-core/pkg/manager/ratelimit"
 	"github.com/puppetlabs/relay-core/pkg/manager/service"
 	"github.com/puppetlabs/relay-core/pkg/metadataapi/opt"
 	"github.com/puppetlabs/relay-core/pkg/metadataapi/server/middleware"
 	"github.com/puppetlabs/relay-core/pkg/model"
+	"golang.org/x/time/rate"
 	"gopkg.in/square/go-jose.v2/jwt"
 )
 
 type Authenticator struct {
-	sc   *opt.SampleConfig
-	key  interface{}
-	mgrs map[model.Hash]func(mgrs *builder.MetadataBuilder)
+	sc            *opt.SampleConfig
+	key           interface{}
+	mgrs          map[model.Hash]func(mgrs *builder.MetadataBuilder)
+	eventLimiters *ratelimit.Limiters
 }
 
 var _ middleware.Authenticator = &Authenticator{}
@@ -39,7 +42,45 @@ func (a *Authenticator) Authenticate(r *http.Request) (*middleware.Credential, e
 			mgrs.SetConnections(memory.NewConnectionManager(a.sc.Connections))
 			mgrs.SetSecrets(memory.NewSecretManager(a.sc.Secrets))
 
-			// TODO: Add support for triggers!
+			// TODO: Add support for the remaining trigger managers!
+
+			model.IfTrigger(claims.Action(), func(trigger *model.Trigger) {
+				tc, found := a.sc.Triggers[trigger.Name]
+				if !found {
+					return
+				}
+
+				if tc.Verification != nil {
+					mgrs.SetWebhookVerification(memory.NewWebhookVerificationManager(&model.WebhookVerification{
+						Scheme:     model.WebhookVerificationScheme(tc.Verification.Scheme),
+						SecretName: tc.Verification.SecretName,
+						Header:     tc.Verification.Header,
+						Algorithm:  tc.Verification.Algorithm,
+						Prefix:     tc.Verification.Prefix,
+						Encoding:   tc.Verification.Encoding,
+					}))
+				}
+
+				if tc.Limits != nil {
+					limits := &model.EventLimits{
+						RequestsPerSecond: float64(tc.Limits.RequestsPerSecond),
+						Burst:             int(tc.Limits.Burst),
+						MaxBodySize:       tc.Limits.MaxBodySize,
+					}
+					if limits.Burst == 0 {
+						limits.Burst = int(tc.Limits.RequestsPerSecond)
+					}
+
+					mgrs.SetEventLimits(memory.NewEventLimitsManager(limits))
+
+					if limits.RequestsPerSecond > 0 {
+						mgrs.SetEvents(ratelimit.NewEventManager(
+							mlog.EventManager,
+							a.eventLimiters.Get(trigger.Name, rate.Limit(limits.RequestsPerSecond), limits.Burst),
+						))
+					}
+				}
+			})
 
 			model.IfStep(claims.Action(), func(step *model.Step) {
 				cfg, found := a.mgrs[step.Hash()]
@@ -68,15 +109,17 @@ func (a *Authenticator) Authenticate(r *http.Request) (*middleware.Credential, e
 
 func NewAuthenticator(sc *opt.SampleConfig, key interface{}) *Authenticator {
 	a := &Authenticator{
-		sc:   sc,
-		key:  key,
-		mgrs: make(map[model.Hash]func(mgrs *builder.MetadataBuilder)),
+		sc:            sc,
+		key:           key,
+		mgrs:          make(map[model.Hash]func(mgrs *builder.MetadataBuilder)),
+		eventLimiters: ratelimit.NewLimiters(),
 	}
 
 	// Pre-build managers so that changes persist across HTTP requests.
 	for id, sc := range sc.Runs {
 		run := model.Run{ID: id}
 		som := memory.NewStepOutputMap()
+		artm := memory.NewArtifactMap()
 
 		parameterManager := memory.NewParameterManager(memory.ParameterManagerWithInitialParameters(sc.Parameters))
 
@@ -88,6 +131,8 @@ func NewAuthenticator(sc *opt.SampleConfig, key interface{}) *Authenticator {
 
 			actionStatusManager := memory.NewActionStatusManager(step, memory.NewActionStatusMap())
 
+			artifactManager := memory.NewArtifactManager(step, artm)
+
 			var conditionOpts []memory.ConditionManagerOption
 			if sc.Conditions.Tree != nil {
 				conditionOpts = append(conditionOpts, memory.ConditionManagerWithInitialCondition(sc.Conditions.Tree))
@@ -132,10 +177,25 @@ func NewAuthenticator(sc *opt.SampleConfig, key interface{}) *Authenticator {
 
 			stepOutputManager := memory.NewStepOutputManager(step, som)
 
+			var decls []*model.StepOutputDeclaration
+			for _, od := range sc.OutputDeclarations {
+				decls = append(decls, &model.StepOutputDeclaration{
+					Name:        od.Name,
+					Description: od.Description,
+					Schema:      od.Schema,
+					Sensitive:   od.Sensitive,
+				})
+			}
+
+			stepOutputDeclarationManager := memory.NewStepOutputDeclarationManager(
+				memory.StepOutputDeclarationManagerWithInitialDeclarations(decls),
+			)
+
 			stepDecoratorManager := memory.NewStepDecoratorManager(step, memory.NewStepDecoratorMap())
 
 			a.mgrs[step.Hash()] = func(mgrs *builder.MetadataBuilder) {
 				mgrs.SetActionStatus(actionStatusManager)
+				mgrs.SetArtifacts(artifactManager)
 				mgrs.SetConditions(conditionManager)
 				mgrs.SetEnvironment(environmentManager)
 				mgrs.SetLogs(logManager)
@@ -146,6 +206,7 @@ func NewAuthenticator(sc *opt.SampleConfig, key interface{}) *Authenticator {
 				mgrs.SetStepDecorators(stepDecoratorManager)
 				mgrs.SetStepMessages(stepMessageManager)
 				mgrs.SetStepOutputs(stepOutputManager)
+				mgrs.SetStepOutputDeclarations(stepOutputDeclarationManager)
 			}
 		}
 	}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	goerrors "errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	utilapi "github.com/puppetlabs/leg/httputil/api"
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/errors"
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/server/middleware"
+	"github.com/puppetlabs/relay-core/pkg/model"
+)
+
+type ArtifactEnvelope struct {
+	TaskName    string `json:"task_name"`
+	Name        string `json:"name"`
+	ContentType string `json:"content_type,omitempty"`
+	Size        int64  `json:"size"`
+	Digest      string `json:"digest"`
+}
+
+func NewArtifactEnvelope(a *model.Artifact) *ArtifactEnvelope {
+	return &ArtifactEnvelope{
+		TaskName:    a.Step.Name,
+		Name:        a.Name,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Digest:      a.Digest,
+	}
+}
+
+func (s *Server) GetArtifact(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	managers := middleware.Managers(r)
+	am := managers.Artifacts()
+
+	stepName, _ := middleware.Var(r, "stepName")
+	name, _ := middleware.Var(r, "name")
+
+	var started bool
+
+	err := am.Get(ctx, stepName, name, func(a *model.Artifact, rd io.Reader) error {
+		started = true
+
+		contentType := a.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		w.Header().Set("content-type", contentType)
+		w.Header().Set("content-length", strconv.FormatInt(a.Size, 10))
+		w.Header().Set("etag", strconv.Quote(a.Digest))
+		w.WriteHeader(http.StatusOK)
+
+		_, err := io.Copy(w, rd)
+		return err
+	})
+	if err != nil && !started {
+		utilapi.WriteError(ctx, w, ModelReadError(err))
+		return
+	}
+}
+
+func (s *Server) PutArtifact(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	managers := middleware.Managers(r)
+	am := managers.Artifacts()
+
+	name, _ := middleware.Var(r, "name")
+
+	a, err := am.Put(ctx, name, r.Header.Get("content-type"), r.Body)
+	if ane := (*model.ArtifactNameError)(nil); goerrors.As(err, &ane) {
+		utilapi.WriteError(ctx, w, errors.NewArtifactInvalidNameError(ane.Name, ane.Reason))
+		return
+	} else if err != nil {
+		utilapi.WriteError(ctx, w, ModelWriteError(err))
+		return
+	}
+
+	utilapi.WriteObjectCreated(ctx, w, NewArtifactEnvelope(a))
+}
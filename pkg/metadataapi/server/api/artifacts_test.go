@@ -0,0 +1,77 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/opt"
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/sample"
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/server/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPutGetArtifact(t *testing.T) {
+	ctx := context.Background()
+
+	tokenGenerator, err := sample.NewHS256TokenGenerator(nil)
+	require.NoError(t, err)
+
+	sc := &opt.SampleConfig{
+		Runs: map[string]*opt.SampleConfigRun{
+			"test": {
+				Steps: map[string]*opt.SampleConfigStep{
+					"build":  {},
+					"deploy": {},
+				},
+			},
+		},
+	}
+
+	tokenMap := tokenGenerator.GenerateAll(ctx, sc)
+
+	buildToken, found := tokenMap.ForStep("test", "build")
+	require.True(t, found)
+
+	deployToken, found := tokenMap.ForStep("test", "deploy")
+	require.True(t, found)
+
+	h := api.NewHandler(sample.NewAuthenticator(sc, tokenGenerator.Key()))
+
+	req, err := http.NewRequest(http.MethodPut, "/artifacts/bundle.tar", strings.NewReader("tarball contents"))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+buildToken)
+	req.Header.Set("Content-Type", "application/x-tar")
+
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusCreated, resp.Result().StatusCode)
+
+	var env api.ArtifactEnvelope
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&env))
+	require.Equal(t, "build", env.TaskName)
+	require.Equal(t, "bundle.tar", env.Name)
+	require.Equal(t, int64(len("tarball contents")), env.Size)
+	require.True(t, strings.HasPrefix(env.Digest, "sha256:"))
+
+	req, err = http.NewRequest(http.MethodGet, "/artifacts/build/bundle.tar", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+deployToken)
+
+	resp = httptest.NewRecorder()
+	h.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+	require.Equal(t, "application/x-tar", resp.Result().Header.Get("Content-Type"))
+	require.Equal(t, "tarball contents", resp.Body.String())
+
+	req, err = http.NewRequest(http.MethodGet, "/artifacts/deploy/bundle.tar", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+deployToken)
+
+	resp = httptest.NewRecorder()
+	h.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusNotFound, resp.Result().StatusCode)
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/puppetlabs/leg/encoding/transfer"
+	utilapi "github.com/puppetlabs/leg/httputil/api"
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/server/middleware"
+)
+
+type ConnectionSummaryEnvelope struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type GetConnectionsResponseEnvelope struct {
+	Connections []*ConnectionSummaryEnvelope `json:"connections"`
+}
+
+type GetConnectionResponseEnvelope struct {
+	Type       string                 `json:"type"`
+	Name       string                 `json:"name"`
+	Attributes transfer.JSONInterface `json:"attributes"`
+}
+
+// GetConnections lists the connections available to the requesting action.
+// The attributes of the connections are not included.
+func (s *Server) GetConnections(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	managers := middleware.Managers(r)
+	cm := managers.Connections()
+
+	l, err := cm.List(ctx)
+	if err != nil {
+		utilapi.WriteError(ctx, w, ModelReadError(err))
+		return
+	}
+
+	env := &GetConnectionsResponseEnvelope{
+		Connections: make([]*ConnectionSummaryEnvelope, 0, len(l)),
+	}
+	for _, c := range l {
+		env.Connections = append(env.Connections, &ConnectionSummaryEnvelope{
+			Type: c.Type,
+			Name: c.Name,
+		})
+	}
+
+	sort.Slice(env.Connections, func(i, j int) bool {
+		if env.Connections[i].Type != env.Connections[j].Type {
+			return env.Connections[i].Type < env.Connections[j].Type
+		}
+
+		return env.Connections[i].Name < env.Connections[j].Name
+	})
+
+	utilapi.WriteObjectOK(ctx, w, env)
+}
+
+func (s *Server) GetConnection(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	managers := middleware.Managers(r)
+	cm := managers.Connections()
+
+	typ, _ := middleware.Var(r, "type")
+	name, _ := middleware.Var(r, "name")
+
+	c, err := cm.Get(ctx, typ, name)
+	if err != nil {
+		utilapi.WriteError(ctx, w, ModelReadError(err))
+		return
+	}
+
+	env := &GetConnectionResponseEnvelope{
+		Type:       c.Type,
+		Name:       c.Name,
+		Attributes: transfer.JSONInterface{Data: c.Attributes},
+	}
+
+	utilapi.WriteObjectOK(ctx, w, env)
+}
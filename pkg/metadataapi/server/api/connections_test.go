@@ -0,0 +1,77 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/puppetlabs/relay-core/pkg/manager/memory"
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/opt"
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/sample"
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/server/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetConnections(t *testing.T) {
+	ctx := context.Background()
+
+	tokenGenerator, err := sample.NewHS256TokenGenerator(nil)
+	require.NoError(t, err)
+
+	sc := &opt.SampleConfig{
+		Connections: opt.SampleConfigConnections{
+			memory.ConnectionKey{Type: "gcp", Name: "prod"}: {
+				"serviceAccountInfo": "{}",
+			},
+			memory.ConnectionKey{Type: "aws", Name: "test"}: {
+				"accessKeyID":     "AKIA",
+				"secretAccessKey": "wh\nup",
+			},
+		},
+		Runs: map[string]*opt.SampleConfigRun{
+			"test": {
+				Steps: map[string]*opt.SampleConfigStep{
+					"test-task": {},
+				},
+			},
+		},
+	}
+
+	tokenMap := tokenGenerator.GenerateAll(ctx, sc)
+
+	testTaskToken, found := tokenMap.ForStep("test", "test-task")
+	require.True(t, found)
+
+	h := api.NewHandler(sample.NewAuthenticator(sc, tokenGenerator.Key()))
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodGet, path, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+testTaskToken)
+
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, req)
+		return resp
+	}
+
+	resp := get("/connections")
+	require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+	require.JSONEq(t, `{"connections": [{"type": "aws", "name": "test"}, {"type": "gcp", "name": "prod"}]}`, resp.Body.String())
+
+	resp = get("/connections/aws/test")
+	require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+
+	var r api.GetConnectionResponseEnvelope
+	require.NoError(t, json.NewDecoder(resp.Result().Body).Decode(&r))
+	require.Equal(t, "aws", r.Type)
+	require.Equal(t, "test", r.Name)
+	require.Equal(t, map[string]interface{}{
+		"accessKeyID":     "AKIA",
+		"secretAccessKey": "wh\nup",
+	}, r.Attributes.Data)
+
+	resp = get("/connections/aws/prod")
+	require.Equal(t, http.StatusNotFound, resp.Result().StatusCode)
+}
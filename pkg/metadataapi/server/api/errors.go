@@ -24,6 +24,8 @@ func ModelWriteError(err error) errors.Error {
 		return errors.NewModelAuthorizationError()
 	case model.ErrConflict:
 		return errors.NewModelConflictError()
+	case model.ErrRateLimited:
+		return errors.NewModelRateLimitedError()
 	default:
 		return errors.NewModelWriteError().WithCause(err)
 	}
@@ -2,12 +2,17 @@ package api
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 
 	"github.com/puppetlabs/leg/encoding/transfer"
 	utilapi "github.com/puppetlabs/leg/httputil/api"
 	"github.com/puppetlabs/relay-core/pkg/metadataapi/errors"
 	"github.com/puppetlabs/relay-core/pkg/metadataapi/server/middleware"
+	metricsmodel "github.com/puppetlabs/relay-core/pkg/metrics/model"
+	"github.com/puppetlabs/relay-core/pkg/model"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 )
 
 type PostEventRequestEnvelope struct {
@@ -21,8 +26,37 @@ func (s *Server) PostEvent(w http.ResponseWriter, r *http.Request) {
 	managers := middleware.Managers(r)
 	em := managers.Events()
 
+	var maxBodySize int64
+	if el, err := managers.EventLimits().Get(ctx); err == nil {
+		maxBodySize = el.MaxBodySize
+	} else if err != model.ErrNotFound && err != model.ErrRejected {
+		utilapi.WriteError(ctx, w, ModelReadError(err))
+		return
+	}
+
+	var reader io.Reader = r.Body
+	if maxBodySize > 0 {
+		// Read one byte past the limit so we can tell if it was exceeded.
+		reader = io.LimitReader(r.Body, maxBodySize+1)
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		utilapi.WriteError(ctx, w, errors.NewAPIMalformedRequestError().WithCause(err))
+		return
+	}
+
+	if maxBodySize > 0 && int64(len(body)) > maxBodySize {
+		metric.Must(s.meter).NewInt64Counter(metricsmodel.MetricTriggerEventRejections).Add(ctx, 1,
+			attribute.String(metricsmodel.MetricAttributeReason, metricsmodel.TriggerEventRejectionReasonTooLarge),
+		)
+
+		utilapi.WriteError(ctx, w, errors.NewAPIRequestTooLargeError(maxBodySize))
+		return
+	}
+
 	var env PostEventRequestEnvelope
-	if err := json.NewDecoder(r.Body).Decode(&env); err != nil {
+	if err := json.Unmarshal(body, &env); err != nil {
 		utilapi.WriteError(ctx, w, errors.NewAPIMalformedRequestError().WithCause(err))
 		return
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/puppetlabs/relay-core/pkg/metadataapi/opt"
 	"github.com/puppetlabs/relay-core/pkg/metadataapi/sample"
 	"github.com/puppetlabs/relay-core/pkg/metadataapi/server/api"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -40,3 +41,46 @@ func TestPostEvent(t *testing.T) {
 	h.ServeHTTP(resp, req)
 	require.Equal(t, http.StatusAccepted, resp.Result().StatusCode)
 }
+
+func TestPostEventLimits(t *testing.T) {
+	ctx := context.Background()
+
+	tokenGenerator, err := sample.NewHS256TokenGenerator(nil)
+	require.NoError(t, err)
+
+	sc := &opt.SampleConfig{
+		Triggers: map[string]*opt.SampleConfigTrigger{
+			"test": {
+				Limits: &opt.SampleConfigWebhookLimits{
+					RequestsPerSecond: 1,
+					Burst:             2,
+					MaxBodySize:       64,
+				},
+			},
+		},
+	}
+
+	tokenMap := tokenGenerator.GenerateAll(ctx, sc)
+
+	triggerToken, found := tokenMap.ForTrigger("test")
+	require.True(t, found)
+
+	h := api.NewHandler(sample.NewAuthenticator(sc, tokenGenerator.Key()))
+
+	post := func(body string) int {
+		req, err := http.NewRequest(http.MethodPost, "/events", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+triggerToken)
+
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, req)
+		return resp.Result().StatusCode
+	}
+
+	// Bodies over the limit are refused without using up the rate limit.
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post(`{"data":{"foo":"`+strings.Repeat("x", 64)+`"}}`))
+
+	assert.Equal(t, http.StatusAccepted, post(`{"data":{"foo":"bar"}}`))
+	assert.Equal(t, http.StatusAccepted, post(`{"data":{"foo":"baz"}}`))
+	assert.Equal(t, http.StatusTooManyRequests, post(`{"data":{"foo":"quux"}}`))
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	utilapi "github.com/puppetlabs/leg/httputil/api"
+	"github.com/puppetlabs/leg/relspec/pkg/evaluate"
+	"github.com/puppetlabs/leg/relspec/pkg/ref"
+	"github.com/puppetlabs/relay-core/pkg/manager/specadapter"
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/errors"
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/server/middleware"
+	"github.com/puppetlabs/relay-core/pkg/model"
+	"github.com/puppetlabs/relay-core/pkg/spec"
+)
+
+type GetMasksResponseEnvelope struct {
+	Masks []string `json:"masks"`
+}
+
+// GetMasks returns the sensitive values that the spec and environment of the
+// requesting action resolve to, so that they can be redacted from its logs.
+// These are the values of referenced secrets, the attributes of referenced
+// connections and referenced outputs marked as sensitive.
+func (s *Server) GetMasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	managers := middleware.Managers(r)
+
+	var trees []interface{}
+
+	if sp, err := managers.Spec().Get(ctx); err == nil {
+		trees = append(trees, sp.Tree)
+	} else if err != model.ErrNotFound {
+		utilapi.WriteError(ctx, w, ModelReadError(err))
+		return
+	}
+
+	if environment, err := managers.Environment().Get(ctx); err == nil {
+		trees = append(trees, environment.Value)
+	} else if err != model.ErrNotFound {
+		utilapi.WriteError(ctx, w, ModelReadError(err))
+		return
+	}
+
+	ev := spec.NewEvaluator(
+		spec.WithConnectionTypeResolver{ConnectionTypeResolver: specadapter.NewConnectionTypeResolver(managers.Connections())},
+		spec.WithSecretTypeResolver{SecretTypeResolver: specadapter.NewSecretTypeResolver(managers.Secrets())},
+		spec.WithParameterTypeResolver{ParameterTypeResolver: specadapter.NewParameterTypeResolver(managers.Parameters())},
+		spec.WithOutputTypeResolver{OutputTypeResolver: specadapter.NewOutputTypeResolver(managers.StepOutputs())},
+		spec.WithStatusTypeResolver{StatusTypeResolver: specadapter.NewStatusTypeResolver(managers.ActionStatus())},
+	)
+
+	refs := spec.NewReferences()
+	for _, tree := range trees {
+		rv, err := evaluate.EvaluateAll(ctx, ev, tree)
+		if err != nil {
+			utilapi.WriteError(ctx, w, errors.NewExpressionEvaluationError(err.Error()))
+			return
+		}
+
+		refs = refs.Merge(rv.References)
+	}
+
+	masks, err := collectMasks(ctx, managers, refs)
+	if err != nil {
+		utilapi.WriteError(ctx, w, ModelReadError(err))
+		return
+	}
+
+	utilapi.WriteObjectOK(ctx, w, &GetMasksResponseEnvelope{Masks: masks})
+}
+
+func collectMasks(ctx context.Context, managers model.MetadataManagers, refs *spec.References) ([]string, error) {
+	var masks []string
+
+	for _, sr := range resolvedReferences(refs.Secrets) {
+		sec, err := managers.Secrets().Get(ctx, sr.Name)
+		if err == model.ErrNotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		masks = append(masks, sec.Value)
+	}
+
+	for _, cr := range resolvedReferences(refs.Connections) {
+		conn, err := managers.Connections().Get(ctx, cr.Type, cr.Name)
+		if err == model.ErrNotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		for _, attr := range conn.Attributes {
+			if value, ok := attr.(string); ok {
+				masks = append(masks, value)
+			}
+		}
+	}
+
+	for _, or := range resolvedReferences(refs.Outputs) {
+		out, err := managers.StepOutputs().Get(ctx, or.From, or.Name)
+		if err == model.ErrNotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		} else if out.Metadata == nil || !out.Metadata.Sensitive {
+			continue
+		}
+
+		switch value := out.Value.(type) {
+		case string:
+			masks = append(masks, value)
+		default:
+			b, err := json.Marshal(value)
+			if err != nil {
+				return nil, err
+			}
+
+			masks = append(masks, string(b))
+		}
+	}
+
+	return masks, nil
+}
+
+func resolvedReferences[T ref.ID[T]](l *ref.Log[T]) []T {
+	var ids []T
+	l.ForEach(func(r ref.Reference[T]) {
+		if r.Resolved() && r.Error() == nil {
+			ids = append(ids, r.ID())
+		}
+	})
+	return ids
+}
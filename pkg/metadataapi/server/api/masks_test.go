@@ -0,0 +1,99 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/puppetlabs/relay-core/pkg/manager/memory"
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/opt"
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/sample"
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/server/api"
+	"github.com/puppetlabs/relay-core/pkg/spec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMasks(t *testing.T) {
+	ctx := context.Background()
+
+	tokenGenerator, err := sample.NewHS256TokenGenerator(nil)
+	require.NoError(t, err)
+
+	sc := &opt.SampleConfig{
+		Connections: opt.SampleConfigConnections{
+			memory.ConnectionKey{Type: "aws", Name: "test"}: {
+				"accessKeyID":     "AKIA",
+				"secretAccessKey": "wh\nup",
+			},
+		},
+		Secrets: map[string]string{
+			"used":   "test-secret-value",
+			"unused": "test-unused-value",
+		},
+		Runs: map[string]*opt.SampleConfigRun{
+			"test": {
+				Steps: map[string]*opt.SampleConfigStep{
+					"previous-task": {
+						OutputDeclarations: []*opt.SampleConfigOutputDeclaration{
+							{Name: "password", Sensitive: true},
+							{Name: "greeting"},
+						},
+					},
+					"current-task": {
+						Spec: opt.SampleConfigSpec{
+							"aws": spec.YAMLTree{
+								Tree: "${connections.aws.test}",
+							},
+							"password": spec.YAMLTree{
+								Tree: "${outputs.previous-task.password}",
+							},
+							"greeting": spec.YAMLTree{
+								Tree: "${outputs.previous-task.greeting}",
+							},
+						},
+						Env: opt.SampleConfigEnvironment{
+							"SECRET": spec.YAMLTree{
+								Tree: "${secrets.used}",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tokenMap := tokenGenerator.GenerateAll(ctx, sc)
+
+	previousTaskToken, found := tokenMap.ForStep("test", "previous-task")
+	require.True(t, found)
+
+	currentTaskToken, found := tokenMap.ForStep("test", "current-task")
+	require.True(t, found)
+
+	h := api.NewHandler(sample.NewAuthenticator(sc, tokenGenerator.Key()))
+
+	for name, value := range map[string]string{"password": "hunter2", "greeting": "hello"} {
+		req, err := http.NewRequest(http.MethodPut, "/outputs/"+name, strings.NewReader(value))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+previousTaskToken)
+
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusCreated, resp.Result().StatusCode)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/masks", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+currentTaskToken)
+
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+
+	var r api.GetMasksResponseEnvelope
+	require.NoError(t, json.NewDecoder(resp.Result().Body).Decode(&r))
+	require.ElementsMatch(t, []string{"test-secret-value", "AKIA", "wh\nup", "hunter2"}, r.Masks)
+}
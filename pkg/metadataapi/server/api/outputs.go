@@ -2,14 +2,18 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
+	"sort"
 
 	"github.com/puppetlabs/leg/encoding/transfer"
 	utilapi "github.com/puppetlabs/leg/httputil/api"
 	"github.com/puppetlabs/relay-core/pkg/metadataapi/errors"
 	"github.com/puppetlabs/relay-core/pkg/metadataapi/server/middleware"
 	"github.com/puppetlabs/relay-core/pkg/model"
+	"github.com/puppetlabs/relay-core/pkg/util/typeutil"
+	"github.com/xeipuuv/gojsonschema"
 )
 
 type GetOutputResponseEnvelope struct {
@@ -19,6 +23,10 @@ type GetOutputResponseEnvelope struct {
 	Metadata *model.StepOutputMetadata `json:"metadata"`
 }
 
+type GetStepOutputsResponseEnvelope struct {
+	Outputs []*GetOutputResponseEnvelope `json:"outputs"`
+}
+
 type PutOutputMetadataRequestEnvelope struct {
 	Sensitive bool `json:"sensitive"`
 }
@@ -48,6 +56,44 @@ func (s *Server) GetOutput(w http.ResponseWriter, r *http.Request) {
 	utilapi.WriteObjectOK(ctx, w, env)
 }
 
+// GetStepOutputs lists the outputs set by a step of the current run.
+func (s *Server) GetStepOutputs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	managers := middleware.Managers(r)
+	om := managers.StepOutputs()
+
+	stepName, _ := middleware.Var(r, "stepName")
+
+	l, err := om.List(ctx)
+	if err != nil {
+		utilapi.WriteError(ctx, w, ModelReadError(err))
+		return
+	}
+
+	env := &GetStepOutputsResponseEnvelope{
+		Outputs: []*GetOutputResponseEnvelope{},
+	}
+	for _, output := range l {
+		if output.Step == nil || output.Step.Name != stepName {
+			continue
+		}
+
+		env.Outputs = append(env.Outputs, &GetOutputResponseEnvelope{
+			TaskName: output.Step.Name,
+			Key:      output.Name,
+			Value:    transfer.JSONInterface{Data: output.Value},
+			Metadata: output.Metadata,
+		})
+	}
+
+	sort.Slice(env.Outputs, func(i, j int) bool {
+		return env.Outputs[i].Key < env.Outputs[j].Key
+	})
+
+	utilapi.WriteObjectOK(ctx, w, env)
+}
+
 func (s *Server) PutOutput(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -56,25 +102,31 @@ func (s *Server) PutOutput(w http.ResponseWriter, r *http.Request) {
 
 	name, _ := middleware.Var(r, "name")
 
-	var value transfer.JSONInterface
+	value, verr := readRequestValue(r)
+	if verr != nil {
+		utilapi.WriteError(ctx, w, verr)
+		return
+	}
 
-	switch r.Header.Get("content-type") {
-	case "application/json":
-		if err := json.NewDecoder(r.Body).Decode(&value.Data); err != nil {
-			utilapi.WriteError(ctx, w, errors.NewAPIMalformedRequestError().WithCause(err))
+	decl, err := lookupStepOutputDeclaration(ctx, managers, name)
+	if err != nil {
+		utilapi.WriteError(ctx, w, err)
+		return
+	}
+
+	if decl != nil && decl.Schema != nil {
+		// A schema that does not compile is a problem with the workflow, not
+		// with the value the step provided.
+		schema, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(decl.Schema))
+		if err != nil {
+			utilapi.WriteError(ctx, w, errors.NewOutputInvalidSchemaError(name, err.Error()))
 			return
 		}
-	case "text/plain", "application/octet-stream", "":
-		buf := &bytes.Buffer{}
-		if _, err := buf.ReadFrom(r.Body); err != nil {
-			utilapi.WriteError(ctx, w, errors.NewAPIMalformedRequestError().WithCause(err))
+
+		if err := validateStepOutputValue(schema, value.Data); err != nil {
+			utilapi.WriteError(ctx, w, errors.NewOutputSchemaValidationError(name, err.Error()))
 			return
 		}
-
-		value.Data = buf.String()
-	default:
-		utilapi.WriteError(ctx, w, errors.NewAPIUnknownRequestMediaTypeError(r.Header.Get("content-type")))
-		return
 	}
 
 	if err := om.Set(ctx, name, value.Data); err != nil {
@@ -82,6 +134,13 @@ func (s *Server) PutOutput(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if decl != nil {
+		if err := om.SetMetadata(ctx, name, &model.StepOutputMetadata{Sensitive: decl.Sensitive}); err != nil {
+			utilapi.WriteError(ctx, w, ModelWriteError(err))
+			return
+		}
+	}
+
 	w.WriteHeader(http.StatusCreated)
 }
 
@@ -93,6 +152,14 @@ func (s *Server) PutOutputMetadata(w http.ResponseWriter, r *http.Request) {
 
 	name, _ := middleware.Var(r, "name")
 
+	if decl, err := lookupStepOutputDeclaration(ctx, managers, name); err != nil {
+		utilapi.WriteError(ctx, w, err)
+		return
+	} else if decl != nil {
+		utilapi.WriteError(ctx, w, errors.NewOutputMetadataDeclaredError(name))
+		return
+	}
+
 	var env PutOutputMetadataRequestEnvelope
 	if err := json.NewDecoder(r.Body).Decode(&env); err != nil {
 		utilapi.WriteError(ctx, w, errors.NewAPIMalformedRequestError().WithCause(err))
@@ -110,3 +177,58 @@ func (s *Server) PutOutputMetadata(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusCreated)
 }
+
+// readRequestValue decodes the body of a request that sets a value. JSON
+// bodies are decoded and any other body is used as a string.
+func readRequestValue(r *http.Request) (transfer.JSONInterface, errors.Error) {
+	var value transfer.JSONInterface
+
+	switch r.Header.Get("content-type") {
+	case "application/json":
+		if err := json.NewDecoder(r.Body).Decode(&value.Data); err != nil {
+			return value, errors.NewAPIMalformedRequestError().WithCause(err)
+		}
+	case "text/plain", "application/octet-stream", "":
+		buf := &bytes.Buffer{}
+		if _, err := buf.ReadFrom(r.Body); err != nil {
+			return value, errors.NewAPIMalformedRequestError().WithCause(err)
+		}
+
+		value.Data = buf.String()
+	default:
+		return value, errors.NewAPIUnknownRequestMediaTypeError(r.Header.Get("content-type"))
+	}
+
+	return value, nil
+}
+
+// lookupStepOutputDeclaration finds the declaration for the named output of the
+// current step. Steps that do not declare any outputs may set arbitrary
+// outputs, in which case this function returns nil.
+func lookupStepOutputDeclaration(ctx context.Context, managers model.MetadataManagers, name string) (*model.StepOutputDeclaration, errors.Error) {
+	decls, err := managers.StepOutputDeclarations().List(ctx)
+	if err == model.ErrRejected {
+		return nil, nil
+	} else if err != nil {
+		return nil, ModelReadError(err)
+	} else if len(decls) == 0 {
+		return nil, nil
+	}
+
+	for _, decl := range decls {
+		if decl.Name == name {
+			return decl, nil
+		}
+	}
+
+	return nil, errors.NewOutputUndeclaredError(name)
+}
+
+func validateStepOutputValue(schema *gojsonschema.Schema, value interface{}) error {
+	result, err := schema.Validate(gojsonschema.NewGoLoader(value))
+	if err != nil {
+		return err
+	}
+
+	return typeutil.ValidationErrorFromResult(result)
+}
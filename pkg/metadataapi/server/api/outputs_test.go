@@ -82,3 +82,145 @@ func TestPutGetOutput(t *testing.T) {
 	require.Equal(t, "bar\x90", out.Value.Data)
 	require.Equal(t, true, out.Metadata.Sensitive)
 }
+
+func TestPutOutputDeclared(t *testing.T) {
+	ctx := context.Background()
+
+	tokenGenerator, err := sample.NewHS256TokenGenerator(nil)
+	require.NoError(t, err)
+
+	sc := &opt.SampleConfig{
+		Runs: map[string]*opt.SampleConfigRun{
+			"test": {
+				Steps: map[string]*opt.SampleConfigStep{
+					"test-task": {
+						OutputDeclarations: []*opt.SampleConfigOutputDeclaration{
+							{
+								Name: "count",
+								Schema: map[string]interface{}{
+									"type": "integer",
+								},
+							},
+							{
+								Name:      "password",
+								Sensitive: true,
+							},
+							{
+								Name: "broken",
+								Schema: map[string]interface{}{
+									"type": 42,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tokenMap := tokenGenerator.GenerateAll(ctx, sc)
+
+	testTaskToken, found := tokenMap.ForStep("test", "test-task")
+	require.True(t, found)
+
+	h := api.NewHandler(sample.NewAuthenticator(sc, tokenGenerator.Key()))
+
+	put := func(path, contentType, body string) int {
+		req, err := http.NewRequest(http.MethodPut, path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+testTaskToken)
+		req.Header.Set("Content-Type", contentType)
+
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, req)
+		return resp.Result().StatusCode
+	}
+
+	// Undeclared outputs are rejected.
+	require.Equal(t, http.StatusUnprocessableEntity, put("/outputs/other", "text/plain", "value"))
+
+	// Values must conform to the declared schema.
+	require.Equal(t, http.StatusUnprocessableEntity, put("/outputs/count", "application/json", `"three"`))
+	require.Equal(t, http.StatusCreated, put("/outputs/count", "application/json", `3`))
+
+	// A declared schema that is not valid is reported as a server error.
+	require.Equal(t, http.StatusInternalServerError, put("/outputs/broken", "application/json", `3`))
+
+	// Sensitivity comes from the declaration.
+	require.Equal(t, http.StatusConflict, put("/outputs/password/metadata", "application/json", `{"sensitive": false}`))
+	require.Equal(t, http.StatusCreated, put("/outputs/password", "text/plain", "hunter2"))
+
+	req, err := http.NewRequest(http.MethodGet, "/outputs/test-task/password", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+testTaskToken)
+
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+
+	var out api.GetOutputResponseEnvelope
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	require.Equal(t, "hunter2", out.Value.Data)
+	require.True(t, out.Metadata.Sensitive)
+}
+
+func TestGetStepOutputs(t *testing.T) {
+	ctx := context.Background()
+
+	tokenGenerator, err := sample.NewHS256TokenGenerator(nil)
+	require.NoError(t, err)
+
+	sc := &opt.SampleConfig{
+		Runs: map[string]*opt.SampleConfigRun{
+			"test": {
+				Steps: map[string]*opt.SampleConfigStep{
+					"previous-task": {
+						Outputs: map[string]interface{}{
+							"b": "second",
+							"a": map[string]interface{}{"first": true},
+						},
+					},
+					"other-task": {
+						Outputs: map[string]interface{}{
+							"c": "other",
+						},
+					},
+					"current-task": {},
+				},
+			},
+		},
+	}
+
+	tokenMap := tokenGenerator.GenerateAll(ctx, sc)
+
+	currentTaskToken, found := tokenMap.ForStep("test", "current-task")
+	require.True(t, found)
+
+	h := api.NewHandler(sample.NewAuthenticator(sc, tokenGenerator.Key()))
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodGet, path, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+currentTaskToken)
+
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, req)
+		return resp
+	}
+
+	resp := get("/outputs/previous-task")
+	require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+
+	var env api.GetStepOutputsResponseEnvelope
+	require.NoError(t, json.NewDecoder(resp.Result().Body).Decode(&env))
+	require.Len(t, env.Outputs, 2)
+	require.Equal(t, "previous-task", env.Outputs[0].TaskName)
+	require.Equal(t, "a", env.Outputs[0].Key)
+	require.Equal(t, map[string]interface{}{"first": true}, env.Outputs[0].Value.Data)
+	require.Equal(t, "b", env.Outputs[1].Key)
+	require.Equal(t, "second", env.Outputs[1].Value.Data)
+
+	resp = get("/outputs/nonexistent-task")
+	require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+	require.JSONEq(t, `{"outputs": []}`, resp.Body.String())
+}
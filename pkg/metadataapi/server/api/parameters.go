@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/puppetlabs/leg/encoding/transfer"
+	utilapi "github.com/puppetlabs/leg/httputil/api"
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/server/middleware"
+)
+
+type GetParameterResponseEnvelope struct {
+	Key   string                 `json:"key"`
+	Value transfer.JSONInterface `json:"value"`
+}
+
+type GetParametersResponseEnvelope struct {
+	Parameters map[string]transfer.JSONInterface `json:"parameters"`
+}
+
+func (s *Server) GetParameters(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	managers := middleware.Managers(r)
+	pm := managers.Parameters()
+
+	l, err := pm.List(ctx)
+	if err != nil {
+		utilapi.WriteError(ctx, w, ModelReadError(err))
+		return
+	}
+
+	env := &GetParametersResponseEnvelope{
+		Parameters: make(map[string]transfer.JSONInterface, len(l)),
+	}
+	for _, p := range l {
+		env.Parameters[p.Name] = transfer.JSONInterface{Data: p.Value}
+	}
+
+	utilapi.WriteObjectOK(ctx, w, env)
+}
+
+func (s *Server) GetParameter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	managers := middleware.Managers(r)
+	pm := managers.Parameters()
+
+	name, _ := middleware.Var(r, "name")
+
+	p, err := pm.Get(ctx, name)
+	if err != nil {
+		utilapi.WriteError(ctx, w, ModelReadError(err))
+		return
+	}
+
+	env := &GetParameterResponseEnvelope{
+		Key:   p.Name,
+		Value: transfer.JSONInterface{Data: p.Value},
+	}
+
+	utilapi.WriteObjectOK(ctx, w, env)
+}
@@ -0,0 +1,68 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/errors"
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/opt"
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/sample"
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/server/api"
+	"github.com/puppetlabs/relay-core/pkg/util/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetParameters(t *testing.T) {
+	ctx := context.Background()
+
+	tokenGenerator, err := sample.NewHS256TokenGenerator(nil)
+	require.NoError(t, err)
+
+	sc := &opt.SampleConfig{
+		Runs: map[string]*opt.SampleConfigRun{
+			"test": {
+				Parameters: map[string]interface{}{
+					"environment": "staging",
+					"replicas":    3,
+				},
+				Steps: map[string]*opt.SampleConfigStep{
+					"test-task": {},
+				},
+			},
+		},
+	}
+
+	tokenMap := tokenGenerator.GenerateAll(ctx, sc)
+
+	testTaskToken, found := tokenMap.ForStep("test", "test-task")
+	require.True(t, found)
+
+	h := api.NewHandler(sample.NewAuthenticator(sc, tokenGenerator.Key()))
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodGet, path, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+testTaskToken)
+
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, req)
+		return resp
+	}
+
+	resp := get("/parameters")
+	require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+	require.JSONEq(t, `{"parameters": {"environment": "staging", "replicas": 3}}`, resp.Body.String())
+
+	resp = get("/parameters/environment")
+	require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+
+	var env api.GetParameterResponseEnvelope
+	require.NoError(t, json.NewDecoder(resp.Result().Body).Decode(&env))
+	require.Equal(t, "environment", env.Key)
+	require.Equal(t, "staging", env.Value.Data)
+
+	testutil.RequireErrorResponse(t, errors.NewModelNotFoundError(), get("/parameters/nonexistent").Result())
+}
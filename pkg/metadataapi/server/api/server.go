@@ -6,6 +6,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/puppetlabs/relay-core/pkg/metadataapi/server/middleware"
 	"github.com/puppetlabs/relay-core/pkg/workflow/validation"
+	"go.opentelemetry.io/otel/metric"
 )
 
 type ServerOption func(*Server)
@@ -16,18 +17,33 @@ func WithSchemaRegistry(reg validation.SchemaRegistry) ServerOption {
 	}
 }
 
+func WithMeter(meter metric.Meter) ServerOption {
+	return func(s *Server) {
+		s.meter = meter
+	}
+}
+
 type Server struct {
 	auth           middleware.Authenticator
 	schemaRegistry validation.SchemaRegistry
+	meter          metric.Meter
 }
 
 func (s *Server) Route(r *mux.Router) {
 	r.UseEncodedPath()
 	r.Use(middleware.WithAuthentication(s.auth))
 
+	// Artifacts
+	r.HandleFunc("/artifacts/{name}", s.PutArtifact).Methods(http.MethodPut)
+	r.HandleFunc("/artifacts/{stepName}/{name}", s.GetArtifact).Methods(http.MethodGet)
+
 	// Conditions
 	r.HandleFunc("/conditions", s.GetConditions).Methods(http.MethodGet)
 
+	// Connections
+	r.HandleFunc("/connections", s.GetConnections).Methods(http.MethodGet)
+	r.HandleFunc("/connections/{type}/{name}", s.GetConnection).Methods(http.MethodGet)
+
 	// Decorators
 	r.HandleFunc("/decorators/{name}", s.PostDecorator).Methods(http.MethodPost)
 
@@ -42,11 +58,19 @@ func (s *Server) Route(r *mux.Router) {
 	r.HandleFunc("/logs", s.PostLog).Methods(http.MethodPost)
 	r.HandleFunc("/logs/{logId}/messages", s.PostLogMessage).Methods(http.MethodPost)
 
+	// Masks
+	r.HandleFunc("/masks", s.GetMasks).Methods(http.MethodGet)
+
 	// Outputs
+	r.HandleFunc("/outputs/{stepName}", s.GetStepOutputs).Methods(http.MethodGet)
 	r.HandleFunc("/outputs/{name}", s.PutOutput).Methods(http.MethodPut)
 	r.HandleFunc("/outputs/{name}/metadata", s.PutOutputMetadata).Methods(http.MethodPut)
 	r.HandleFunc("/outputs/{stepName}/{name}", s.GetOutput).Methods(http.MethodGet)
 
+	// Parameters
+	r.HandleFunc("/parameters", s.GetParameters).Methods(http.MethodGet)
+	r.HandleFunc("/parameters/{name}", s.GetParameter).Methods(http.MethodGet)
+
 	// Secrets
 	r.HandleFunc("/secrets/{name}", s.GetSecret).Methods(http.MethodGet)
 
@@ -55,6 +79,7 @@ func (s *Server) Route(r *mux.Router) {
 
 	// State
 	r.HandleFunc("/state/{name}", s.GetState).Methods(http.MethodGet)
+	r.HandleFunc("/state/{name}", s.PutState).Methods(http.MethodPut)
 
 	//  Status
 	r.HandleFunc("/status", s.PutActionStatus).Methods(http.MethodPut)
@@ -62,6 +87,9 @@ func (s *Server) Route(r *mux.Router) {
 	// Validation
 	r.HandleFunc("/validate", s.PostValidate).Methods(http.MethodPost)
 
+	// Verification
+	r.HandleFunc("/verify", s.PostVerify).Methods(http.MethodPost)
+
 	// Workflows
 	r.HandleFunc("/workflows/{name}/run", s.PostWorkflowRun).Methods(http.MethodPost)
 }
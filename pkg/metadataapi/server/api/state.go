@@ -34,3 +34,28 @@ func (s *Server) GetState(w http.ResponseWriter, r *http.Request) {
 
 	utilapi.WriteObjectOK(ctx, w, env)
 }
+
+// PutState sets a state value of the requesting action, for example to keep
+// checkpoint data. Values given in the state of the run take precedence and
+// replace values set here when the run is reconciled.
+func (s *Server) PutState(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	managers := middleware.Managers(r)
+	sm := managers.State()
+
+	name, _ := middleware.Var(r, "name")
+
+	value, verr := readRequestValue(r)
+	if verr != nil {
+		utilapi.WriteError(ctx, w, verr)
+		return
+	}
+
+	if _, err := sm.Set(ctx, name, value.Data); err != nil {
+		utilapi.WriteError(ctx, w, ModelWriteError(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/puppetlabs/errawr-go/v2/pkg/errawr"
@@ -89,3 +90,54 @@ func TestGetState(t *testing.T) {
 		})
 	}
 }
+
+func TestPutState(t *testing.T) {
+	ctx := context.Background()
+
+	tokenGenerator, err := sample.NewHS256TokenGenerator(nil)
+	require.NoError(t, err)
+
+	sc := &opt.SampleConfig{
+		Runs: map[string]*opt.SampleConfigRun{
+			"test": {
+				Steps: map[string]*opt.SampleConfigStep{
+					"test-task": {},
+				},
+			},
+		},
+	}
+
+	tokenMap := tokenGenerator.GenerateAll(ctx, sc)
+
+	testTaskToken, found := tokenMap.ForStep("test", "test-task")
+	require.True(t, found)
+
+	h := api.NewHandler(sample.NewAuthenticator(sc, tokenGenerator.Key()))
+
+	do := func(method, path, contentType, body string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+testTaskToken)
+		req.Header.Set("Content-Type", contentType)
+
+		resp := httptest.NewRecorder()
+		h.ServeHTTP(resp, req)
+		return resp
+	}
+
+	require.Equal(t, http.StatusCreated, do(http.MethodPut, "/state/checkpoint", "application/json", `{"page": 3}`).Result().StatusCode)
+	require.Equal(t, http.StatusCreated, do(http.MethodPut, "/state/cursor", "text/plain", "abc").Result().StatusCode)
+	require.Equal(t, http.StatusUnprocessableEntity, do(http.MethodPut, "/state/broken", "application/json", `{`).Result().StatusCode)
+
+	for name, expected := range map[string]interface{}{
+		"checkpoint": map[string]interface{}{"page": float64(3)},
+		"cursor":     "abc",
+	} {
+		resp := do(http.MethodGet, "/state/"+name, "", "")
+		require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+
+		var env api.GetStateResponseEnvelope
+		require.NoError(t, json.NewDecoder(resp.Result().Body).Decode(&env))
+		require.Equal(t, expected, env.Value.Data)
+	}
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/puppetlabs/leg/encoding/transfer"
+	utilapi "github.com/puppetlabs/leg/httputil/api"
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/errors"
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/server/middleware"
+	"github.com/puppetlabs/relay-core/pkg/model"
+)
+
+// PostVerifyRequestEnvelope contains a request received by a trigger. The
+// headers and body must be provided exactly as they were received.
+type PostVerifyRequestEnvelope struct {
+	Headers http.Header        `json:"headers"`
+	Body    transfer.JSONOrStr `json:"body"`
+}
+
+func (s *Server) PostVerify(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	managers := middleware.Managers(r)
+
+	wv, err := managers.WebhookVerification().Get(ctx)
+	if err != nil {
+		utilapi.WriteError(ctx, w, ModelReadError(err))
+		return
+	}
+
+	var env PostVerifyRequestEnvelope
+	if err := json.NewDecoder(r.Body).Decode(&env); err != nil {
+		utilapi.WriteError(ctx, w, errors.NewAPIMalformedRequestError().WithCause(err))
+		return
+	}
+
+	body, err := env.Body.Decode()
+	if err != nil {
+		utilapi.WriteError(ctx, w, errors.NewAPIMalformedRequestError().WithCause(err))
+		return
+	}
+
+	sec, err := managers.Secrets().Get(ctx, wv.SecretName)
+	if err != nil {
+		utilapi.WriteError(ctx, w, ModelReadError(err))
+		return
+	}
+
+	req := &model.WebhookRequest{
+		Header: env.Headers,
+		Body:   body,
+	}
+
+	if err := wv.Verify([]byte(sec.Value), req, time.Now()); err != nil {
+		if ve, ok := err.(*model.WebhookVerificationError); ok {
+			utilapi.WriteError(ctx, w, errors.NewWebhookVerificationError(ve.Reason))
+		} else {
+			utilapi.WriteError(ctx, w, ModelReadError(err))
+		}
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
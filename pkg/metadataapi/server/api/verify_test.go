@@ -0,0 +1,194 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/puppetlabs/errawr-go/v2/pkg/errawr"
+	"github.com/puppetlabs/leg/encoding/transfer"
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/errors"
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/opt"
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/sample"
+	"github.com/puppetlabs/relay-core/pkg/metadataapi/server/api"
+	"github.com/puppetlabs/relay-core/pkg/util/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func sign(fn func() hash.Hash, secret string, parts ...string) []byte {
+	mac := hmac.New(fn, []byte(secret))
+	for _, part := range parts {
+		mac.Write([]byte(part))
+	}
+
+	return mac.Sum(nil)
+}
+
+func TestPostVerify(t *testing.T) {
+	ctx := context.Background()
+
+	tokenGenerator, err := sample.NewHS256TokenGenerator(nil)
+	require.NoError(t, err)
+
+	const secret = "s3cr3t"
+	const body = `{"action":"opened"}`
+
+	sc := &opt.SampleConfig{
+		Secrets: map[string]string{
+			"webhook-secret": secret,
+		},
+		Triggers: map[string]*opt.SampleConfigTrigger{
+			"github": {
+				Verification: &opt.SampleConfigWebhookVerification{Scheme: "github", SecretName: "webhook-secret"},
+			},
+			"gitlab": {
+				Verification: &opt.SampleConfigWebhookVerification{Scheme: "gitlab", SecretName: "webhook-secret"},
+			},
+			"stripe": {
+				Verification: &opt.SampleConfigWebhookVerification{Scheme: "stripe", SecretName: "webhook-secret"},
+			},
+			"hmac": {
+				Verification: &opt.SampleConfigWebhookVerification{
+					Scheme:     "hmac",
+					SecretName: "webhook-secret",
+					Header:     "X-Signature",
+					Algorithm:  "sha512",
+					Prefix:     "v1=",
+					Encoding:   "base64",
+				},
+			},
+			"missing-secret": {
+				Verification: &opt.SampleConfigWebhookVerification{Scheme: "gitlab", SecretName: "nope"},
+			},
+			"unverified": {},
+		},
+	}
+
+	tokenMap := tokenGenerator.GenerateAll(ctx, sc)
+
+	h := api.NewHandler(sample.NewAuthenticator(sc, tokenGenerator.Key()))
+
+	now := fmt.Sprintf("%d", time.Now().Unix())
+	stale := fmt.Sprintf("%d", time.Now().Add(-time.Hour).Unix())
+
+	tests := []struct {
+		Name          string
+		TriggerName   string
+		Headers       http.Header
+		ExpectedError errawr.Error
+	}{
+		{
+			Name:        "GitHub",
+			TriggerName: "github",
+			Headers: http.Header{
+				"X-Hub-Signature-256": {"sha256=" + hex.EncodeToString(sign(sha256.New, secret, body))},
+			},
+		},
+		{
+			Name:        "GitHubWrongSecret",
+			TriggerName: "github",
+			Headers: http.Header{
+				"X-Hub-Signature-256": {"sha256=" + hex.EncodeToString(sign(sha256.New, "wrong", body))},
+			},
+			ExpectedError: errors.NewWebhookVerificationError("the signature does not match"),
+		},
+		{
+			Name:          "GitHubUnsigned",
+			TriggerName:   "github",
+			ExpectedError: errors.NewWebhookVerificationError("the signature header is missing"),
+		},
+		{
+			Name:        "GitLab",
+			TriggerName: "gitlab",
+			Headers: http.Header{
+				"X-Gitlab-Token": {secret},
+			},
+		},
+		{
+			Name:        "GitLabWrongToken",
+			TriggerName: "gitlab",
+			Headers: http.Header{
+				"X-Gitlab-Token": {"wrong"},
+			},
+			ExpectedError: errors.NewWebhookVerificationError("the token does not match"),
+		},
+		{
+			Name:        "Stripe",
+			TriggerName: "stripe",
+			Headers: http.Header{
+				"Stripe-Signature": {fmt.Sprintf("t=%s,v1=%s,v0=ignored", now, hex.EncodeToString(sign(sha256.New, secret, now, ".", body)))},
+			},
+		},
+		{
+			Name:        "StripeReplayed",
+			TriggerName: "stripe",
+			Headers: http.Header{
+				"Stripe-Signature": {fmt.Sprintf("t=%s,v1=%s", stale, hex.EncodeToString(sign(sha256.New, secret, stale, ".", body)))},
+			},
+			ExpectedError: errors.NewWebhookVerificationError("the signature timestamp is outside the tolerance window"),
+		},
+		{
+			Name:        "HMAC",
+			TriggerName: "hmac",
+			Headers: http.Header{
+				"X-Signature": {"v1=" + base64.StdEncoding.EncodeToString(sign(sha512.New, secret, body))},
+			},
+		},
+		{
+			Name:        "HMACWrongAlgorithm",
+			TriggerName: "hmac",
+			Headers: http.Header{
+				"X-Signature": {"v1=" + base64.StdEncoding.EncodeToString(sign(sha256.New, secret, body))},
+			},
+			ExpectedError: errors.NewWebhookVerificationError("the signature does not match"),
+		},
+		{
+			Name:        "MissingSecret",
+			TriggerName: "missing-secret",
+			Headers: http.Header{
+				"X-Gitlab-Token": {secret},
+			},
+			ExpectedError: errors.NewModelNotFoundError(),
+		},
+		{
+			Name:          "Unverified",
+			TriggerName:   "unverified",
+			ExpectedError: errors.NewModelAuthorizationError(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			token, found := tokenMap.ForTrigger(test.TriggerName)
+			require.True(t, found)
+
+			b, err := json.Marshal(api.PostVerifyRequestEnvelope{
+				Headers: test.Headers,
+				Body:    transfer.JSONOrStr{JSON: transfer.JSON{Data: body}},
+			})
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, "/verify", bytes.NewReader(b))
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, req)
+			if test.ExpectedError == nil {
+				require.Equal(t, http.StatusNoContent, resp.Result().StatusCode)
+			} else {
+				testutil.RequireErrorResponse(t, test.ExpectedError, resp.Result())
+			}
+		})
+	}
+}
@@ -5,21 +5,31 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"path"
+	"time"
 
 	"github.com/gorilla/mux"
 	vaultapi "github.com/hashicorp/vault/api"
 	"github.com/puppetlabs/leg/instrumentation/alerts/trackers"
+	"github.com/puppetlabs/leg/storage"
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
 	"github.com/puppetlabs/relay-core/pkg/authenticate"
 	"github.com/puppetlabs/relay-core/pkg/manager/api"
+	"github.com/puppetlabs/relay-core/pkg/manager/audit"
 	"github.com/puppetlabs/relay-core/pkg/manager/builder"
 	"github.com/puppetlabs/relay-core/pkg/manager/configmap"
+	kubernetesmgr "github.com/puppetlabs/relay-core/pkg/manager/kubernetes"
 	"github.com/puppetlabs/relay-core/pkg/manager/memory"
+	"github.com/puppetlabs/relay-core/pkg/manager/ratelimit"
 	"github.com/puppetlabs/relay-core/pkg/manager/reject"
 	"github.com/puppetlabs/relay-core/pkg/manager/service"
 	"github.com/puppetlabs/relay-core/pkg/manager/vault"
 	"github.com/puppetlabs/relay-core/pkg/model"
 	"github.com/puppetlabs/relay-pls/pkg/plspb"
+	"go.opentelemetry.io/otel/metric"
+	"golang.org/x/time/rate"
 	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // Credential represents a valid authentication request.
@@ -45,9 +55,34 @@ type KubernetesAuthenticator struct {
 	// request headers.
 	kubernetesClient *authenticate.KubernetesInterface
 
+	// Client for creating runs in tenant namespaces from trigger events.
+	runClient client.Client
+
+	// How long to remember trigger event keys for deduplication.
+	eventDeduplicationWindow time.Duration
+
+	// Meter for recording trigger event delivery metrics.
+	meter metric.Meter
+
+	// Token buckets for the triggers that have a rate limit, shared across
+	// requests.
+	eventLimiters *ratelimit.Limiters
+
 	// Log Service
 	logServiceClient plspb.LogClient
 
+	// Blob storage for step artifacts.
+	artifactStorage storage.BlobStore
+
+	// Client for reading secrets and connections from Kubernetes secrets in
+	// tenant namespaces instead of Vault.
+	secretClient client.Client
+
+	// Receives audit records of reads of secrets, connections and sensitive
+	// outputs.
+	auditSink audit.Sink
+	auditor   *audit.Auditor
+
 	// Uses Vault for token decryption (Kubernetes intermediary).
 	vaultClient      *vaultapi.Client
 	vaultTransitPath string
@@ -139,7 +174,7 @@ func (ka *KubernetesAuthenticator) resolver(mgrs *builder.MetadataBuilder) authe
 	return authenticate.NewAnyResolver(delegates)
 }
 
-func (ka *KubernetesAuthenticator) injector(mgrs *builder.MetadataBuilder, tags *[]trackers.Tag) authenticate.Injector {
+func (ka *KubernetesAuthenticator) injector(mgrs *builder.MetadataBuilder, tags *[]trackers.Tag, subject *audit.Subject) authenticate.Injector {
 	return authenticate.InjectorFunc(func(ctx context.Context, claims *authenticate.Claims) error {
 		client, err := ka.factory(claims.KubernetesServiceAccountToken)
 		if err != nil {
@@ -151,17 +186,109 @@ func (ka *KubernetesAuthenticator) injector(mgrs *builder.MetadataBuilder, tags
 
 		action := claims.Action()
 
+		if ka.secretClient != nil {
+			mgrs.SetConnections(kubernetesmgr.NewConnectionManager(ka.secretClient, claims.KubernetesNamespaceName))
+			mgrs.SetSecrets(kubernetesmgr.NewSecretManager(ka.secretClient, claims.KubernetesNamespaceName))
+		}
+
 		model.IfStep(action, func(step *model.Step) {
 			// Only a step can work with parameters, decorators and outputs.
 			// Other actions will get the default rejection manager.
 			mgrs.SetParameters(configmap.NewParameterManager(immutableMap))
 			mgrs.SetStepMessages(configmap.NewStepMessageManager(step, mutableMap))
 			mgrs.SetStepOutputs(configmap.NewStepOutputManager(step, mutableMap))
+			mgrs.SetStepOutputDeclarations(configmap.NewStepOutputDeclarationManager(step, immutableMap))
 			mgrs.SetStepDecorators(configmap.NewStepDecoratorManager(step, mutableMap))
+
+			if ka.artifactStorage != nil {
+				prefix := claims.RelayArtifactKeyPrefix
+				if prefix == "" {
+					prefix = path.Join("artifacts", claims.KubernetesNamespaceName, step.Run.ID)
+				}
+
+				mgrs.SetArtifacts(configmap.NewArtifactManager(
+					step,
+					mutableMap,
+					ka.artifactStorage,
+					configmap.ArtifactManagerWithKeyPrefix(prefix),
+				))
+			}
 		})
 
+		if claims.RelayWebhookVerificationScheme != "" {
+			model.IfTrigger(action, func(trigger *model.Trigger) {
+				mgrs.SetWebhookVerification(memory.NewWebhookVerificationManager(&model.WebhookVerification{
+					Scheme:     model.WebhookVerificationScheme(claims.RelayWebhookVerificationScheme),
+					SecretName: claims.RelayWebhookVerificationSecretName,
+					Header:     claims.RelayWebhookVerificationHeader,
+					Algorithm:  claims.RelayWebhookVerificationAlgorithm,
+					Prefix:     claims.RelayWebhookVerificationPrefix,
+					Encoding:   claims.RelayWebhookVerificationEncoding,
+				}))
+			})
+		}
+
+		var events model.EventManager
+
 		if claims.RelayEventAPIURL != nil {
-			mgrs.SetEvents(api.NewEventManager(action, claims.RelayEventAPIURL.URL.String(), claims.RelayEventAPIToken))
+			var opts []api.EventManagerOption
+			if claims.RelayEventAPIFormat == string(relayv1beta1.TriggerEventSinkFormatCloudEvents) {
+				opts = append(opts, api.EventManagerWithCloudEvents(
+					api.TriggerCloudEventsSource(claims.KubernetesNamespaceName, claims.RelayName),
+					relayv1beta1.CloudEventsMode(claims.RelayEventAPICloudEventsMode),
+					claims.RelayEventAPICloudEventsDataSchema,
+				))
+			}
+
+			events = api.NewEventManager(action, claims.RelayEventAPIURL.URL.String(), claims.RelayEventAPIToken, opts...)
+		}
+
+		if claims.RelayEventWorkflowName != "" && ka.runClient != nil {
+			// Runs are created in the namespace of the tenant, which the
+			// scoped service account cannot access, so we use our own
+			// identity. The namespace and workflow come from the signed
+			// claims and cannot be altered by the caller.
+			events = kubernetesmgr.NewEventManager(
+				action,
+				ka.runClient,
+				claims.RelayEventWorkflowNamespace,
+				claims.RelayEventWorkflowName,
+				kubernetesmgr.EventManagerWithParameters(claims.RelayEventWorkflowParameters),
+			)
+		}
+
+		if events != nil {
+			// Events that cannot be delivered right away are kept in the
+			// mutable configuration map for the operator to send again.
+			events = configmap.NewEventManager(
+				action,
+				mutableMap,
+				events,
+				configmap.EventManagerWithDeduplicationWindow(ka.eventDeduplicationWindow),
+				configmap.EventManagerWithMeter(ka.meter),
+			)
+
+			model.IfTrigger(action, func(trigger *model.Trigger) {
+				limits := &model.EventLimits{
+					RequestsPerSecond: float64(claims.RelayWebhookLimitsRequestsPerSecond),
+					Burst:             int(claims.RelayWebhookLimitsBurst),
+					MaxBodySize:       claims.RelayWebhookLimitsMaxBodySize,
+				}
+				mgrs.SetEventLimits(memory.NewEventLimitsManager(limits))
+
+				if limits.RequestsPerSecond > 0 {
+					// Rejected events must not count against the
+					// deduplication window, so the limit is applied first.
+					key := path.Join(claims.KubernetesNamespaceName, trigger.Hash().HexEncoding())
+					events = ratelimit.NewEventManager(
+						events,
+						ka.eventLimiters.Get(key, rate.Limit(limits.RequestsPerSecond), limits.Burst),
+						ratelimit.EventManagerWithMeter(ka.meter),
+					)
+				}
+			})
+
+			mgrs.SetEvents(events)
 		}
 
 		if claims.RelayWorkflowExecutionAPIURL != nil {
@@ -206,6 +333,14 @@ func (ka *KubernetesAuthenticator) injector(mgrs *builder.MetadataBuilder, tags
 			}
 		}
 
+		*subject = audit.Subject{
+			Namespace:  claims.KubernetesNamespaceName,
+			TenantID:   claims.RelayTenantID,
+			RunID:      claims.RelayRunID,
+			ActionType: action.Type().Singular,
+			ActionName: claims.RelayName,
+		}
+
 		return nil
 	})
 }
@@ -213,11 +348,12 @@ func (ka *KubernetesAuthenticator) injector(mgrs *builder.MetadataBuilder, tags
 func (ka *KubernetesAuthenticator) Authenticate(r *http.Request) (*Credential, error) {
 	mgrs := builder.NewMetadataBuilder()
 	var tags []trackers.Tag
+	var subject audit.Subject
 
 	auth := authenticate.NewAuthenticator(
 		ka.intermediary(r, mgrs),
 		ka.resolver(mgrs),
-		authenticate.AuthenticatorWithInjector(ka.injector(mgrs, &tags)),
+		authenticate.AuthenticatorWithInjector(ka.injector(mgrs, &tags, &subject)),
 	)
 
 	if ok, err := auth.Authenticate(r.Context()); err != nil {
@@ -226,8 +362,13 @@ func (ka *KubernetesAuthenticator) Authenticate(r *http.Request) (*Credential, e
 		return nil, nil
 	}
 
+	managers := mgrs.Build()
+	if ka.auditor != nil {
+		managers = audit.NewMetadataManagers(managers, ka.auditor, subject)
+	}
+
 	return &Credential{
-		Managers: mgrs.Build(),
+		Managers: managers,
 		Tags:     tags,
 	}, nil
 }
@@ -240,12 +381,53 @@ func KubernetesAuthenticatorWithKubernetesIntermediary(client *authenticate.Kube
 	}
 }
 
+func KubernetesAuthenticatorWithRunClient(client client.Client) KubernetesAuthenticatorOption {
+	return func(ka *KubernetesAuthenticator) {
+		ka.runClient = client
+	}
+}
+
+func KubernetesAuthenticatorWithEventDeduplicationWindow(window time.Duration) KubernetesAuthenticatorOption {
+	return func(ka *KubernetesAuthenticator) {
+		ka.eventDeduplicationWindow = window
+	}
+}
+
+func KubernetesAuthenticatorWithMeter(meter metric.Meter) KubernetesAuthenticatorOption {
+	return func(ka *KubernetesAuthenticator) {
+		ka.meter = meter
+	}
+}
+
 func KubernetesAuthenticatorWithLogServiceIntermediary(client plspb.LogClient) KubernetesAuthenticatorOption {
 	return func(ka *KubernetesAuthenticator) {
 		ka.logServiceClient = client
 	}
 }
 
+func KubernetesAuthenticatorWithArtifactStorage(bs storage.BlobStore) KubernetesAuthenticatorOption {
+	return func(ka *KubernetesAuthenticator) {
+		ka.artifactStorage = bs
+	}
+}
+
+// KubernetesAuthenticatorWithKubernetesSecrets reads the secrets and
+// connections of a tenant from Kubernetes secrets in its namespace using the
+// given client, taking precedence over any Vault paths in the token claims.
+func KubernetesAuthenticatorWithKubernetesSecrets(client client.Client) KubernetesAuthenticatorOption {
+	return func(ka *KubernetesAuthenticator) {
+		ka.secretClient = client
+	}
+}
+
+// KubernetesAuthenticatorWithAuditSink records every read of a secret, a
+// connection or a sensitive output to the given sink.
+func KubernetesAuthenticatorWithAuditSink(sink audit.Sink) KubernetesAuthenticatorOption {
+	return func(ka *KubernetesAuthenticator) {
+		ka.auditSink = sink
+	}
+}
+
 func KubernetesAuthenticatorWithChainToVaultTransitIntermediary(client *vaultapi.Client, path, key string) KubernetesAuthenticatorOption {
 	return func(ka *KubernetesAuthenticator) {
 		ka.vaultClient = client
@@ -270,13 +452,19 @@ func KubernetesAuthenticatorWithKeyResolver(key interface{}) KubernetesAuthentic
 
 func NewKubernetesAuthenticator(factory KubernetesAuthenticatorClientFactoryFunc, opts ...KubernetesAuthenticatorOption) *KubernetesAuthenticator {
 	ka := &KubernetesAuthenticator{
-		factory: factory,
+		factory:                  factory,
+		eventDeduplicationWindow: configmap.DefaultEventDeduplicationWindow,
+		eventLimiters:            ratelimit.NewLimiters(),
 	}
 
 	for _, opt := range opts {
 		opt(ka)
 	}
 
+	if ka.auditSink != nil {
+		ka.auditor = audit.NewAuditor(ka.auditSink, audit.AuditorWithMeter(ka.meter))
+	}
+
 	return ka
 }
 
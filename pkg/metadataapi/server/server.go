@@ -10,6 +10,7 @@ import (
 	"github.com/puppetlabs/relay-core/pkg/metadataapi/server/api"
 	"github.com/puppetlabs/relay-core/pkg/metadataapi/server/middleware"
 	"github.com/puppetlabs/relay-core/pkg/workflow/validation"
+	"go.opentelemetry.io/otel/metric"
 )
 
 type Server struct {
@@ -18,6 +19,7 @@ type Server struct {
 	capturer         trackers.Capturer
 	trustedProxyHops int
 	schemaRegistry   validation.SchemaRegistry
+	meter            metric.Meter
 }
 
 func (s *Server) Route(r *mux.Router) {
@@ -26,7 +28,7 @@ func (s *Server) Route(r *mux.Router) {
 	r.HandleFunc("/healthz", s.GetHealthz).Methods("GET")
 
 	// This has a different set of middleware so bind it under a subrouter.
-	api.NewServer(s.auth, api.WithSchemaRegistry(s.schemaRegistry)).
+	api.NewServer(s.auth, api.WithSchemaRegistry(s.schemaRegistry), api.WithMeter(s.meter)).
 		Route(r.NewRoute().Subrouter())
 }
 
@@ -56,6 +58,12 @@ func WithSchemaRegistry(r validation.SchemaRegistry) Option {
 	}
 }
 
+func WithMeter(meter metric.Meter) Option {
+	return func(s *Server) {
+		s.meter = meter
+	}
+}
+
 func new(auth middleware.Authenticator, opts ...Option) *Server {
 	s := &Server{
 		auth:             auth,
@@ -21,11 +21,47 @@ const (
 	// in the cluster before completing.
 	MetricWorkflowRunTotalTimeSeconds = "workflow_run_total_time_seconds"
 
+	// MetricTriggerEventDeliveryAttempts counts each attempt to deliver a
+	// trigger event to its sink. The outcome attribute is either delivered or
+	// failed.
+	MetricTriggerEventDeliveryAttempts = "trigger_event_delivery_attempts"
+
+	// MetricTriggerEventDeliveryFailures counts the trigger events that were
+	// given up on, either because the sink rejected them or because they could
+	// not be delivered after retrying.
+	MetricTriggerEventDeliveryFailures = "trigger_event_delivery_failures"
+
+	// MetricTriggerEventDuplicates counts the trigger events discarded because
+	// an event with the same key was recently accepted.
+	MetricTriggerEventDuplicates = "trigger_event_duplicates"
+
+	// MetricTriggerEventRejections counts the trigger events refused because
+	// the trigger exceeded one of its limits. The reason attribute is either
+	// rate_limited or too_large.
+	MetricTriggerEventRejections = "trigger_event_rejections"
+
+	// MetricAuditRecords counts the reads of secrets, connections and
+	// sensitive outputs by the metadata API. The kind attribute is secret,
+	// connection or output, and the outcome attribute is granted, not_found,
+	// rejected or error.
+	MetricAuditRecords = "audit_records"
+
+	MetricAttributeKind    = "kind"
 	MetricAttributeReason  = "reason"
 	MetricAttributeOutcome = "outcome"
 	MetricAttributeStatus  = "status"
 )
 
+const (
+	TriggerEventDeliveryOutcomeDelivered = "delivered"
+	TriggerEventDeliveryOutcomeFailed    = "failed"
+)
+
+const (
+	TriggerEventRejectionReasonRateLimited = "rate_limited"
+	TriggerEventRejectionReasonTooLarge    = "too_large"
+)
+
 type EventFilter struct {
 	Metric  string
 	Filters []string
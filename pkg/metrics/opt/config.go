@@ -3,7 +3,9 @@ package opt
 import (
 	"io"
 	"net/http"
+	"strings"
 
+	"github.com/puppetlabs/relay-core/pkg/metrics/model"
 	"github.com/spf13/viper"
 	"go.opentelemetry.io/otel/exporters/metric/prometheus"
 	"go.opentelemetry.io/otel/exporters/stdout"
@@ -17,6 +19,12 @@ const (
 	OptionMetricsEnabled = "metrics_enabled"
 	OptionMetricsAddress = "metrics_server_addr"
 
+	// OptionEventFiltersNormal and OptionEventFiltersWarning are
+	// comma-separated lists of event reasons to count for each event type. An
+	// empty list counts every reason.
+	OptionEventFiltersNormal  = "event_filters_normal"
+	OptionEventFiltersWarning = "event_filters_warning"
+
 	DefaultOptionDebug          = false
 	DefaultOptionMetricsEnabled = true
 	DefaultOptionMetricsAddress = "0.0.0.0:3050"
@@ -27,6 +35,8 @@ type Config struct {
 
 	MetricsEnabled bool
 	MetricsAddress string
+
+	EventFilters []model.EventFilter
 }
 
 func (c *Config) Metrics() (*metric.Meter, error) {
@@ -76,7 +86,28 @@ func NewConfig() (*Config, error) {
 		Debug:          viper.GetBool(OptionDebug),
 		MetricsEnabled: viper.GetBool(OptionMetricsEnabled),
 		MetricsAddress: viper.GetString(OptionMetricsAddress),
+		EventFilters: []model.EventFilter{
+			{
+				Metric:  model.MetricEventTypeNormal,
+				Filters: splitList(viper.GetString(OptionEventFiltersNormal)),
+			},
+			{
+				Metric:  model.MetricEventTypeWarning,
+				Filters: splitList(viper.GetString(OptionEventFiltersWarning)),
+			},
+		},
 	}
 
 	return config, nil
 }
+
+func splitList(s string) []string {
+	var l []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			l = append(l, item)
+		}
+	}
+
+	return l
+}
@@ -0,0 +1,204 @@
+package event
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"github.com/puppetlabs/relay-core/pkg/metrics/model"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var eventTypeMetrics = map[string]string{
+	corev1.EventTypeNormal:  model.MetricEventTypeNormal,
+	corev1.EventTypeWarning: model.MetricEventTypeWarning,
+}
+
+// triggerEventDeliveryOutcomes maps the reasons of the events the operator
+// records when it redelivers trigger events to the outcome of the attempt.
+var triggerEventDeliveryOutcomes = map[string]string{
+	"TriggerEventDelivered":      model.TriggerEventDeliveryOutcomeDelivered,
+	"TriggerEventDeliveryFailed": model.TriggerEventDeliveryOutcomeFailed,
+	"TriggerEventDropped":        model.TriggerEventDeliveryOutcomeFailed,
+}
+
+type seenEvent struct {
+	uid   types.UID
+	count int32
+}
+
+// EventReconciler counts the Kubernetes events recorded for Relay objects
+// according to a set of event filters.
+type EventReconciler struct {
+	client  client.Client
+	meter   *metric.Meter
+	filters []model.EventFilter
+	started time.Time
+
+	mut  sync.Mutex
+	seen map[types.NamespacedName]seenEvent
+}
+
+var _ reconcile.Reconciler = &EventReconciler{}
+
+func (r *EventReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ev := &corev1.Event{}
+
+	if err := r.client.Get(ctx, req.NamespacedName, ev); errors.IsNotFound(err) {
+		r.mut.Lock()
+		delete(r.seen, req.NamespacedName)
+		r.mut.Unlock()
+
+		return ctrl.Result{}, nil
+	} else if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Events are aggregated by the recorder, so we only count the occurrences
+	// we have not yet observed.
+	count := ev.Count
+	if count < 1 {
+		count = 1
+	}
+
+	r.mut.Lock()
+	prev, found := r.seen[req.NamespacedName]
+	r.seen[req.NamespacedName] = seenEvent{uid: ev.GetUID(), count: count}
+	r.mut.Unlock()
+
+	var delta int32
+	switch {
+	case found && prev.uid == ev.GetUID():
+		delta = count - prev.count
+	case !found && lastObserved(ev).Before(r.started):
+		// This event was recorded before we started (i.e., it is part of the
+		// initial list), so we only use it as a baseline for future updates.
+	default:
+		delta = count
+	}
+
+	if delta <= 0 {
+		return ctrl.Result{}, nil
+	}
+
+	r.recordTriggerEventDelivery(ctx, ev.Reason, int64(delta))
+
+	metricName, ok := eventTypeMetrics[ev.Type]
+	if !ok {
+		return ctrl.Result{}, nil
+	}
+
+	for _, filter := range r.filters {
+		if filter.Metric != metricName || !matchesReason(filter, ev.Reason) {
+			continue
+		}
+
+		counter := metric.Must(*r.meter).NewInt64Counter(filter.Metric)
+		counter.Add(ctx, int64(delta),
+			attribute.String(model.MetricAttributeReason, ev.Reason),
+		)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *EventReconciler) recordTriggerEventDelivery(ctx context.Context, reason string, n int64) {
+	outcome, ok := triggerEventDeliveryOutcomes[reason]
+	if !ok {
+		return
+	}
+
+	metric.Must(*r.meter).NewInt64Counter(model.MetricTriggerEventDeliveryAttempts).Add(ctx, n,
+		attribute.String(model.MetricAttributeOutcome, outcome),
+	)
+
+	// Only dropped events have been given up on.
+	if reason == "TriggerEventDropped" {
+		metric.Must(*r.meter).NewInt64Counter(model.MetricTriggerEventDeliveryFailures).Add(ctx, n)
+	}
+}
+
+func matchesReason(filter model.EventFilter, reason string) bool {
+	if len(filter.Filters) == 0 {
+		return true
+	}
+
+	for _, candidate := range filter.Filters {
+		if candidate == reason {
+			return true
+		}
+	}
+
+	return false
+}
+
+func lastObserved(ev *corev1.Event) time.Time {
+	switch {
+	case !ev.LastTimestamp.IsZero():
+		return ev.LastTimestamp.Time
+	case !ev.EventTime.IsZero():
+		return ev.EventTime.Time
+	default:
+		return ev.GetCreationTimestamp().Time
+	}
+}
+
+func isRelayEvent(obj client.Object) bool {
+	ev, ok := obj.(*corev1.Event)
+	if !ok {
+		return false
+	}
+
+	gv, err := schema.ParseGroupVersion(ev.InvolvedObject.APIVersion)
+	if err != nil {
+		return false
+	}
+
+	return gv.Group == relayv1beta1.SchemeGroupVersion.Group
+}
+
+// SelectorsByObject restricts the events cached by the manager to those
+// involving Relay objects. It should be provided to the cache of any manager
+// the event reconciler is added to.
+func SelectorsByObject() cache.SelectorsByObject {
+	return cache.SelectorsByObject{
+		&corev1.Event{}: {
+			Field: fields.OneTermEqualSelector("involvedObject.apiVersion", relayv1beta1.SchemeGroupVersion.String()),
+		},
+	}
+}
+
+func NewEventReconciler(c client.Client, meter *metric.Meter, filters []model.EventFilter) *EventReconciler {
+	return &EventReconciler{
+		client:  c,
+		meter:   meter,
+		filters: filters,
+		// Event timestamps only have second precision.
+		started: time.Now().Truncate(time.Second),
+		seen:    make(map[types.NamespacedName]seenEvent),
+	}
+}
+
+func Add(mgr manager.Manager, meter *metric.Meter, filters []model.EventFilter) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Event{}).
+		WithEventFilter(predicate.NewPredicateFuncs(isRelayEvent)).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: 16,
+		}).
+		Complete(NewEventReconciler(mgr.GetClient(), meter, filters))
+}
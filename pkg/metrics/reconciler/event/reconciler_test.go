@@ -0,0 +1,199 @@
+package event_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"github.com/puppetlabs/relay-core/pkg/metrics/model"
+	"github.com/puppetlabs/relay-core/pkg/metrics/reconciler/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/number"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// recordingMeterImpl is a minimal metric SDK that sums the values recorded by
+// each synchronous instrument, keyed by instrument name and reason or outcome.
+type recordingMeterImpl struct {
+	mut    sync.Mutex
+	counts map[string]map[string]int64
+}
+
+var _ metric.MeterImpl = &recordingMeterImpl{}
+
+func (mi *recordingMeterImpl) record(name string, n number.Number, labels []attribute.KeyValue) {
+	mi.mut.Lock()
+	defer mi.mut.Unlock()
+
+	var reason string
+	for _, label := range labels {
+		if label.Key == model.MetricAttributeReason || label.Key == model.MetricAttributeOutcome {
+			reason = label.Value.AsString()
+		}
+	}
+
+	if mi.counts[name] == nil {
+		mi.counts[name] = make(map[string]int64)
+	}
+	mi.counts[name][reason] += n.AsInt64()
+}
+
+func (mi *recordingMeterImpl) count(name, reason string) int64 {
+	mi.mut.Lock()
+	defer mi.mut.Unlock()
+
+	return mi.counts[name][reason]
+}
+
+func (mi *recordingMeterImpl) RecordBatch(ctx context.Context, labels []attribute.KeyValue, ms ...metric.Measurement) {
+	for _, m := range ms {
+		mi.record(m.SyncImpl().Descriptor().Name(), m.Number(), labels)
+	}
+}
+
+func (mi *recordingMeterImpl) NewSyncInstrument(desc metric.Descriptor) (metric.SyncImpl, error) {
+	return &recordingSyncImpl{meter: mi, desc: desc}, nil
+}
+
+func (mi *recordingMeterImpl) NewAsyncInstrument(desc metric.Descriptor, runner metric.AsyncRunner) (metric.AsyncImpl, error) {
+	return metric.NoopAsync{}, nil
+}
+
+type recordingSyncImpl struct {
+	metric.NoopSync
+	meter *recordingMeterImpl
+	desc  metric.Descriptor
+}
+
+func (si *recordingSyncImpl) Descriptor() metric.Descriptor {
+	return si.desc
+}
+
+func (si *recordingSyncImpl) RecordOne(ctx context.Context, n number.Number, labels []attribute.KeyValue) {
+	si.meter.record(si.desc.Name(), n, labels)
+}
+
+func TestEventReconciler(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	before := metav1.NewTime(time.Now().Add(-time.Hour))
+
+	initial := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-run.initial",
+			UID:       types.UID("initial"),
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: relayv1beta1.SchemeGroupVersion.String(),
+			Kind:       "Run",
+			Name:       "my-run",
+		},
+		Type:          corev1.EventTypeWarning,
+		Reason:        "RunFailed",
+		Count:         3,
+		LastTimestamp: before,
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(initial).Build()
+
+	mi := &recordingMeterImpl{counts: make(map[string]map[string]int64)}
+	meter := metric.WrapMeterImpl(mi, "test")
+
+	r := event.NewEventReconciler(c, &meter, []model.EventFilter{
+		{Metric: model.MetricEventTypeWarning, Filters: []string{"RunFailed"}},
+		{Metric: model.MetricEventTypeNormal},
+	})
+
+	reconcile := func(ev *corev1.Event) {
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(ev)})
+		require.NoError(t, err)
+	}
+
+	// Events that existed before the reconciler started are not counted.
+	reconcile(initial)
+	assert.Equal(t, int64(0), mi.count(model.MetricEventTypeWarning, "RunFailed"))
+
+	// Subsequent occurrences of the same event are.
+	initial.Count = 5
+	initial.LastTimestamp = metav1.Now()
+	require.NoError(t, c.Update(ctx, initial))
+
+	reconcile(initial)
+	assert.Equal(t, int64(2), mi.count(model.MetricEventTypeWarning, "RunFailed"))
+
+	// Reprocessing an unchanged event does not count it again.
+	reconcile(initial)
+	assert.Equal(t, int64(2), mi.count(model.MetricEventTypeWarning, "RunFailed"))
+
+	// New events are counted in full.
+	created := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "my-run.created",
+			UID:       types.UID("created"),
+		},
+		InvolvedObject: initial.InvolvedObject,
+		Type:           corev1.EventTypeNormal,
+		Reason:         "RunStarted",
+		Count:          1,
+		LastTimestamp:  metav1.Now(),
+	}
+	require.NoError(t, c.Create(ctx, created))
+
+	reconcile(created)
+	assert.Equal(t, int64(1), mi.count(model.MetricEventTypeNormal, "RunStarted"))
+
+	// Warnings that do not match a filter are ignored.
+	ignored := created.DeepCopy()
+	ignored.ResourceVersion = ""
+	ignored.Name = "my-run.ignored"
+	ignored.UID = types.UID("ignored")
+	ignored.Type = corev1.EventTypeWarning
+	ignored.Reason = "StepSkipped"
+	require.NoError(t, c.Create(ctx, ignored))
+
+	reconcile(ignored)
+	assert.Equal(t, int64(0), mi.count(model.MetricEventTypeWarning, "StepSkipped"))
+
+	// Trigger event redeliveries are counted by outcome.
+	for _, reason := range []string{"TriggerEventDelivered", "TriggerEventDeliveryFailed", "TriggerEventDropped"} {
+		redelivery := &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "my-trigger." + reason,
+				UID:       types.UID(reason),
+			},
+			InvolvedObject: corev1.ObjectReference{
+				APIVersion: relayv1beta1.SchemeGroupVersion.String(),
+				Kind:       "WebhookTrigger",
+				Name:       "my-trigger",
+			},
+			Type:          corev1.EventTypeWarning,
+			Reason:        reason,
+			Count:         1,
+			LastTimestamp: metav1.Now(),
+		}
+		require.NoError(t, c.Create(ctx, redelivery))
+
+		reconcile(redelivery)
+	}
+
+	assert.Equal(t, int64(1), mi.count(model.MetricTriggerEventDeliveryAttempts, model.TriggerEventDeliveryOutcomeDelivered))
+	assert.Equal(t, int64(2), mi.count(model.MetricTriggerEventDeliveryAttempts, model.TriggerEventDeliveryOutcomeFailed))
+	assert.Equal(t, int64(1), mi.count(model.MetricTriggerEventDeliveryFailures, ""))
+}
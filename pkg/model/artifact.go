@@ -0,0 +1,41 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// ArtifactNameError indicates that the name of an artifact cannot be used by
+// the underlying storage.
+type ArtifactNameError struct {
+	Name   string
+	Reason string
+}
+
+func (e *ArtifactNameError) Error() string {
+	return fmt.Sprintf("model: invalid artifact name %q: %s", e.Name, e.Reason)
+}
+
+type Artifact struct {
+	Step        *Step
+	Name        string
+	ContentType string
+	Size        int64
+	Digest      string
+}
+
+type ArtifactGetterManager interface {
+	List(ctx context.Context) ([]*Artifact, error)
+	ListSelf(ctx context.Context) ([]*Artifact, error)
+	Get(ctx context.Context, stepName, name string, fn func(a *Artifact, r io.Reader) error) error
+}
+
+type ArtifactSetterManager interface {
+	Put(ctx context.Context, name, contentType string, r io.Reader) (*Artifact, error)
+}
+
+type ArtifactManager interface {
+	ArtifactGetterManager
+	ArtifactSetterManager
+}
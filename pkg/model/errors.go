@@ -6,4 +6,8 @@ var (
 	ErrNotFound = errors.New("model: not found")
 	ErrRejected = errors.New("model: rejected")
 	ErrConflict = errors.New("model: conflict")
+
+	// ErrRateLimited is returned when an action has performed an operation
+	// too often and must wait before trying again.
+	ErrRateLimited = errors.New("model: rate limited")
 )
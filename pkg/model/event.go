@@ -1,6 +1,10 @@
 package model
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"time"
+)
 
 type Event struct {
 	Data map[string]interface{}
@@ -10,3 +14,94 @@ type Event struct {
 type EventManager interface {
 	Emit(ctx context.Context, data map[string]interface{}, key string) (*Event, error)
 }
+
+// EventLimits restricts the events a trigger may emit.
+type EventLimits struct {
+	// RequestsPerSecond is the sustained rate of events to allow. If zero,
+	// the rate is not limited.
+	RequestsPerSecond float64
+
+	// Burst is the number of events that may be emitted at once before the
+	// rate limit applies.
+	Burst int
+
+	// MaxBodySize is the largest request body in bytes to accept for an
+	// event. If zero, the size is not limited.
+	MaxBodySize int64
+}
+
+type EventLimitsGetterManager interface {
+	Get(ctx context.Context) (*EventLimits, error)
+}
+
+// EventDeliveryError is returned by an event manager when the sink could not
+// accept an event at this time, but may be able to if the event is sent again
+// later.
+type EventDeliveryError struct {
+	Cause error
+}
+
+func (e *EventDeliveryError) Error() string {
+	return fmt.Sprintf("event could not be delivered: %+v", e.Cause)
+}
+
+func (e *EventDeliveryError) Unwrap() error {
+	return e.Cause
+}
+
+// PendingEvent is an event that was accepted from a trigger but has not yet
+// been delivered to its sink.
+type PendingEvent struct {
+	ID    string
+	Event *Event
+
+	// AcceptTime is when the event was first emitted by the trigger.
+	AcceptTime time.Time
+
+	Attempts        int
+	LastAttemptTime time.Time
+	LastError       string
+}
+
+type EventOutcome string
+
+const (
+	// EventOutcomeDelivered indicates that the sink accepted the event.
+	EventOutcomeDelivered EventOutcome = "delivered"
+
+	// EventOutcomePending indicates that the event is waiting to be sent to
+	// the sink again.
+	EventOutcomePending EventOutcome = "pending"
+
+	// EventOutcomeRejected indicates that the sink refused the event or that
+	// it could not be delivered after retrying.
+	EventOutcomeRejected EventOutcome = "rejected"
+)
+
+// RecordedEvent is an event kept in the history of a trigger so that it can
+// be inspected and replayed.
+type RecordedEvent struct {
+	ID      string
+	Event   *Event
+	Time    time.Time
+	Outcome EventOutcome
+	Error   string
+
+	// Truncated is true if the data of the event was too large to keep, in
+	// which case the event cannot be replayed.
+	Truncated bool
+}
+
+// EventStats summarizes the events emitted by a trigger.
+type EventStats struct {
+	// Accepted is the number of events handed to the sink or kept for
+	// redelivery.
+	Accepted int64
+
+	// Rejected is the number of events the sink refused or that were
+	// discarded after they could not be delivered.
+	Rejected int64
+
+	LastEventTime time.Time
+	LastError     string
+}
@@ -25,6 +25,15 @@ const (
 	RelayVaultEngineMountAnnotation    = "relay.sh/vault-engine-mount"
 	RelayVaultSecretPathAnnotation     = "relay.sh/vault-secret-path"
 	RelayVaultConnectionPathAnnotation = "relay.sh/vault-connection-path"
+	RelayTriggerNameAnnotation         = "relay.sh/trigger-name"
+	RelayEventKeyAnnotation            = "relay.sh/event-key"
+	RelayReplayEventAnnotation         = "relay.sh/replay-event"
+	RelaySecretNameAnnotation          = "relay.sh/secret-name"
+	RelayConnectionTypeAnnotation      = "relay.sh/connection-type"
+	RelayConnectionNameAnnotation      = "relay.sh/connection-name"
+
+	RelaySecretLabel     = "relay.sh/secret"
+	RelayConnectionLabel = "relay.sh/connection"
 
 	RelayControllerTokenHashAnnotation = "controller.relay.sh/token-hash"
 
@@ -92,19 +101,23 @@ var (
 // service.
 type MetadataManagers interface {
 	ActionStatus() ActionStatusManager
+	Artifacts() ArtifactManager
 	Conditions() ConditionGetterManager
 	Connections() ConnectionManager
 	Events() EventManager
+	EventLimits() EventLimitsGetterManager
 	Environment() EnvironmentGetterManager
 	Parameters() ParameterGetterManager
 	Logs() LogManager
 	Secrets() SecretManager
 	Spec() SpecGetterManager
-	State() StateGetterManager
+	State() StateManager
 	ActionMetadata() ActionMetadataManager
 	StepDecorators() StepDecoratorManager
 	StepMessages() StepMessageManager
 	StepOutputs() StepOutputManager
+	StepOutputDeclarations() StepOutputDeclarationGetterManager
+	WebhookVerification() WebhookVerificationGetterManager
 	WorkflowRuns() WorkflowRunManager
 }
 
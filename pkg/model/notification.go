@@ -0,0 +1,34 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+type RunOutcome string
+
+const (
+	RunOutcomeSucceeded RunOutcome = "succeeded"
+	RunOutcomeFailed    RunOutcome = "failed"
+	RunOutcomeCancelled RunOutcome = "cancelled"
+)
+
+// RunNotification describes a run that has reached a terminal condition.
+type RunNotification struct {
+	// DeliveryID uniquely identifies this notification so that receivers can
+	// discard duplicate deliveries.
+	DeliveryID string
+
+	Run            Run
+	Namespace      string
+	WorkflowName   string
+	Outcome        RunOutcome
+	Message        string
+	StartTime      *time.Time
+	CompletionTime *time.Time
+	Outputs        map[string]interface{}
+}
+
+type NotificationManager interface {
+	Notify(ctx context.Context, n *RunNotification) error
+}
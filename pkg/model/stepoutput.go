@@ -30,3 +30,23 @@ type StepOutputManager interface {
 	StepOutputGetterManager
 	StepOutputSetterManager
 }
+
+type StepOutputDeclaration struct {
+	Name        string
+	Description string
+	Schema      interface{}
+	Sensitive   bool
+}
+
+type StepOutputDeclarationGetterManager interface {
+	List(ctx context.Context) ([]*StepOutputDeclaration, error)
+}
+
+type StepOutputDeclarationSetterManager interface {
+	Set(ctx context.Context, decls []*StepOutputDeclaration) error
+}
+
+type StepOutputDeclarationManager interface {
+	StepOutputDeclarationGetterManager
+	StepOutputDeclarationSetterManager
+}
@@ -0,0 +1,185 @@
+package model
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type WebhookVerificationScheme string
+
+const (
+	WebhookVerificationSchemeGitHub WebhookVerificationScheme = "github"
+	WebhookVerificationSchemeGitLab WebhookVerificationScheme = "gitlab"
+	WebhookVerificationSchemeStripe WebhookVerificationScheme = "stripe"
+	WebhookVerificationSchemeHMAC   WebhookVerificationScheme = "hmac"
+)
+
+// WebhookStripeTolerance is the maximum age of a Stripe-style signature
+// timestamp.
+const WebhookStripeTolerance = 5 * time.Minute
+
+// WebhookVerification describes how the requests received by a trigger are
+// signed.
+type WebhookVerification struct {
+	Scheme     WebhookVerificationScheme
+	SecretName string
+
+	// Header, Algorithm, Prefix and Encoding are only used by the generic HMAC
+	// scheme.
+	Header    string
+	Algorithm string
+	Prefix    string
+	Encoding  string
+}
+
+// WebhookRequest is the part of an HTTP request received by a trigger that
+// is covered by its signature.
+type WebhookRequest struct {
+	Header http.Header
+	Body   []byte
+}
+
+// WebhookVerificationError is returned when a webhook request does not have a
+// valid signature.
+type WebhookVerificationError struct {
+	Reason string
+}
+
+func (e *WebhookVerificationError) Error() string {
+	return fmt.Sprintf("model: webhook verification failed: %s", e.Reason)
+}
+
+// Verify checks the signature of the given request using the given shared
+// secret. It returns a *WebhookVerificationError if the signature is missing
+// or incorrect.
+func (wv *WebhookVerification) Verify(secret []byte, req *WebhookRequest, now time.Time) error {
+	switch wv.Scheme {
+	case WebhookVerificationSchemeGitHub:
+		return verifyHMAC(sha256.New, secret, req.Body, req.Header.Get("X-Hub-Signature-256"), "sha256=", hex.DecodeString)
+	case WebhookVerificationSchemeGitLab:
+		token := req.Header.Get("X-Gitlab-Token")
+		if token == "" {
+			return &WebhookVerificationError{Reason: "the X-Gitlab-Token header is missing"}
+		} else if subtle.ConstantTimeCompare([]byte(token), secret) != 1 {
+			return &WebhookVerificationError{Reason: "the token does not match"}
+		}
+
+		return nil
+	case WebhookVerificationSchemeStripe:
+		return verifyStripe(secret, req, now)
+	case WebhookVerificationSchemeHMAC:
+		var fn func() hash.Hash
+		switch wv.Algorithm {
+		case "sha1":
+			fn = sha1.New
+		case "", "sha256":
+			fn = sha256.New
+		case "sha512":
+			fn = sha512.New
+		default:
+			return fmt.Errorf("model: unknown HMAC algorithm %q", wv.Algorithm)
+		}
+
+		var decode func(string) ([]byte, error)
+		switch wv.Encoding {
+		case "", "hex":
+			decode = hex.DecodeString
+		case "base64":
+			decode = base64.StdEncoding.DecodeString
+		default:
+			return fmt.Errorf("model: unknown digest encoding %q", wv.Encoding)
+		}
+
+		if wv.Header == "" {
+			return fmt.Errorf("model: no header is configured for HMAC verification")
+		}
+
+		return verifyHMAC(fn, secret, req.Body, req.Header.Get(wv.Header), wv.Prefix, decode)
+	default:
+		return fmt.Errorf("model: unknown webhook verification scheme %q", wv.Scheme)
+	}
+}
+
+func verifyHMAC(fn func() hash.Hash, secret, body []byte, value, prefix string, decode func(string) ([]byte, error)) error {
+	if value == "" {
+		return &WebhookVerificationError{Reason: "the signature header is missing"}
+	} else if !strings.HasPrefix(value, prefix) {
+		return &WebhookVerificationError{Reason: "the signature has an unexpected format"}
+	}
+
+	digest, err := decode(strings.TrimPrefix(value, prefix))
+	if err != nil {
+		return &WebhookVerificationError{Reason: "the signature could not be decoded"}
+	}
+
+	mac := hmac.New(fn, secret)
+	mac.Write(body)
+
+	if !hmac.Equal(digest, mac.Sum(nil)) {
+		return &WebhookVerificationError{Reason: "the signature does not match"}
+	}
+
+	return nil
+}
+
+func verifyStripe(secret []byte, req *WebhookRequest, now time.Time) error {
+	value := req.Header.Get("Stripe-Signature")
+	if value == "" {
+		return &WebhookVerificationError{Reason: "the Stripe-Signature header is missing"}
+	}
+
+	var timestamp string
+	var signatures [][]byte
+	for _, part := range strings.Split(value, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		switch kv[0] {
+		case "t":
+			timestamp = kv[1]
+		case "v1":
+			if sig, err := hex.DecodeString(kv[1]); err == nil {
+				signatures = append(signatures, sig)
+			}
+		}
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return &WebhookVerificationError{Reason: "the signature timestamp is missing or invalid"}
+	} else if age := now.Sub(time.Unix(ts, 0)); age > WebhookStripeTolerance || age < -WebhookStripeTolerance {
+		return &WebhookVerificationError{Reason: "the signature timestamp is outside the tolerance window"}
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(req.Body)
+	expected := mac.Sum(nil)
+
+	for _, sig := range signatures {
+		if hmac.Equal(sig, expected) {
+			return nil
+		}
+	}
+
+	return &WebhookVerificationError{Reason: "the signature does not match"}
+}
+
+type WebhookVerificationGetterManager interface {
+	Get(ctx context.Context) (*WebhookVerification, error)
+}
@@ -0,0 +1,37 @@
+package obj
+
+import (
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/helper"
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/lifecycle"
+	servingv1beta1 "knative.dev/serving/pkg/apis/serving/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	KnativeDomainMappingKind = servingv1beta1.SchemeGroupVersion.WithKind("DomainMapping")
+)
+
+type KnativeDomainMapping struct {
+	*helper.NamespaceScopedAPIObject
+
+	Key    client.ObjectKey
+	Object *servingv1beta1.DomainMapping
+}
+
+func makeKnativeDomainMapping(key client.ObjectKey, obj *servingv1beta1.DomainMapping) *KnativeDomainMapping {
+	kdm := &KnativeDomainMapping{Key: key, Object: obj}
+	kdm.NamespaceScopedAPIObject = helper.ForNamespaceScopedAPIObject(&kdm.Key, lifecycle.TypedObject{GVK: KnativeDomainMappingKind, Object: kdm.Object})
+	return kdm
+}
+
+func (kdm *KnativeDomainMapping) Copy() *KnativeDomainMapping {
+	return makeKnativeDomainMapping(kdm.Key, kdm.Object.DeepCopy())
+}
+
+func NewKnativeDomainMapping(key client.ObjectKey) *KnativeDomainMapping {
+	return makeKnativeDomainMapping(key, &servingv1beta1.DomainMapping{})
+}
+
+func NewKnativeDomainMappingFromObject(obj *servingv1beta1.DomainMapping) *KnativeDomainMapping {
+	return makeKnativeDomainMapping(client.ObjectKeyFromObject(obj), obj)
+}
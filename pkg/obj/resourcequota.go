@@ -0,0 +1,37 @@
+package obj
+
+import (
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/helper"
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/lifecycle"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	ResourceQuotaKind = corev1.SchemeGroupVersion.WithKind("ResourceQuota")
+)
+
+type ResourceQuota struct {
+	*helper.NamespaceScopedAPIObject
+
+	Key    client.ObjectKey
+	Object *corev1.ResourceQuota
+}
+
+func makeResourceQuota(key client.ObjectKey, obj *corev1.ResourceQuota) *ResourceQuota {
+	rq := &ResourceQuota{Key: key, Object: obj}
+	rq.NamespaceScopedAPIObject = helper.ForNamespaceScopedAPIObject(&rq.Key, lifecycle.TypedObject{GVK: ResourceQuotaKind, Object: rq.Object})
+	return rq
+}
+
+func (rq *ResourceQuota) Copy() *ResourceQuota {
+	return makeResourceQuota(rq.Key, rq.Object.DeepCopy())
+}
+
+func NewResourceQuota(key client.ObjectKey) *ResourceQuota {
+	return makeResourceQuota(key, &corev1.ResourceQuota{})
+}
+
+func NewResourceQuotaFromObject(obj *corev1.ResourceQuota) *ResourceQuota {
+	return makeResourceQuota(client.ObjectKeyFromObject(obj), obj)
+}
@@ -14,6 +14,8 @@ import (
 
 const (
 	RunStateCancel = "cancel"
+
+	RunStatusReasonValidationFailed = "ValidationFailed"
 )
 
 type Run struct {
@@ -65,6 +67,16 @@ func (r *Run) IsRunning() bool {
 	return r.IsCondition(relayv1beta1.RunCompleted, corev1.ConditionFalse)
 }
 
+func (r *Run) IsValidationFailed() bool {
+	for _, cond := range r.Object.Status.Conditions {
+		if cond.Reason == RunStatusReasonValidationFailed {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (r *Run) IsCondition(rct relayv1beta1.RunConditionType, status corev1.ConditionStatus) bool {
 	for _, cond := range r.Object.Status.Conditions {
 		if cond.Type == rct && cond.Status == status {
@@ -14,6 +14,8 @@ const (
 	TenantStatusReasonNamespaceReady = "NamespaceReady"
 	TenantStatusReasonNamespaceError = "NamespaceError"
 
+	TenantStatusReasonLimitRangeInvalid = "LimitRangeInvalid"
+
 	TenantStatusReasonEventSinkMissing       = "EventSinkMissing"
 	TenantStatusReasonEventSinkNotConfigured = "EventSinkNotConfigured"
 	TenantStatusReasonEventSinkReady         = "EventSinkReady"
@@ -5,38 +5,14 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/puppetlabs/relay-core/pkg/operator/config"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
-var (
-	PodNodeSelector = map[string]string{
-		"nebula.puppet.com/scheduling.customer-ready": "true",
-	}
-	PodTolerations = []corev1.Toleration{
-		{
-			Key:    "nebula.puppet.com/scheduling.customer-workload",
-			Value:  "true",
-			Effect: corev1.TaintEffectNoSchedule,
-		},
-		{
-			Key:    "sandbox.gke.io/runtime",
-			Value:  "gvisor",
-			Effect: corev1.TaintEffectNoSchedule,
-		},
-	}
-	PodDNSPolicy = corev1.DNSNone
-	PodDNSConfig = &corev1.PodDNSConfig{
-		Nameservers: []string{
-			"1.1.1.1",
-			"1.0.0.1",
-			"8.8.8.8",
-		},
-	}
-)
-
 type PodEnforcementHandler struct {
+	platform         *config.PlatformConfig
 	runtimeClassName string
 	standalone       bool
 	decoder          *admission.Decoder
@@ -56,16 +32,20 @@ func (peh *PodEnforcementHandler) Handle(ctx context.Context, req admission.Requ
 	}
 
 	if !peh.standalone {
-		if pod.Spec.NodeSelector == nil {
-			pod.Spec.NodeSelector = map[string]string{}
+		sc := peh.platform.Scheduling
+		if len(sc.NodeSelector) > 0 && pod.Spec.NodeSelector == nil {
+			pod.Spec.NodeSelector = make(map[string]string, len(sc.NodeSelector))
 		}
-		for key, value := range PodNodeSelector {
+		for key, value := range sc.NodeSelector {
 			pod.Spec.NodeSelector[key] = value
 		}
-		pod.Spec.Tolerations = PodTolerations
+		if len(sc.Tolerations) > 0 {
+			pod.Spec.Tolerations = append([]corev1.Toleration{}, sc.Tolerations...)
+		}
 
-		pod.Spec.DNSPolicy = PodDNSPolicy
-		pod.Spec.DNSConfig = PodDNSConfig
+		dc := peh.platform.DNS
+		pod.Spec.DNSPolicy = dc.Policy
+		pod.Spec.DNSConfig = dc.Config.DeepCopy()
 	}
 
 	if peh.runtimeClassName != "" {
@@ -87,6 +67,16 @@ func (peh *PodEnforcementHandler) InjectDecoder(d *admission.Decoder) error {
 
 type PodEnforcementHandlerOption func(peh *PodEnforcementHandler)
 
+// PodEnforcementHandlerWithPlatformConfig sets the node placement and DNS
+// configuration of tenant pods, which otherwise match the defaults of the
+// config package. The runtime class of the configuration is used unless one is
+// set explicitly.
+func PodEnforcementHandlerWithPlatformConfig(cfg *config.PlatformConfig) PodEnforcementHandlerOption {
+	return func(peh *PodEnforcementHandler) {
+		peh.platform = cfg
+	}
+}
+
 func PodEnforcementHandlerWithRuntimeClassName(runtimeClassName string) PodEnforcementHandlerOption {
 	return func(peh *PodEnforcementHandler) {
 		peh.runtimeClassName = runtimeClassName
@@ -100,11 +90,17 @@ func PodEnforcementHandlerWithStandaloneMode(standalone bool) PodEnforcementHand
 }
 
 func NewPodEnforcementHandler(opts ...PodEnforcementHandlerOption) *PodEnforcementHandler {
-	peh := &PodEnforcementHandler{}
+	peh := &PodEnforcementHandler{
+		platform: config.DefaultPlatformConfig(),
+	}
 
 	for _, opt := range opts {
 		opt(peh)
 	}
 
+	if peh.runtimeClassName == "" {
+		peh.runtimeClassName = peh.platform.Sandbox.RuntimeClassName
+	}
+
 	return peh
 }
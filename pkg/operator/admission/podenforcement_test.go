@@ -0,0 +1,106 @@
+package admission_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/puppetlabs/relay-core/pkg/operator/admission"
+	"github.com/puppetlabs/relay-core/pkg/operator/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrladmission "sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func handlePod(t *testing.T, peh *admission.PodEnforcementHandler, pod *corev1.Pod) map[string]interface{} {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	decoder, err := ctrladmission.NewDecoder(scheme)
+	require.NoError(t, err)
+	require.NoError(t, peh.InjectDecoder(decoder))
+
+	raw, err := json.Marshal(pod)
+	require.NoError(t, err)
+
+	resp := peh.Handle(context.Background(), ctrladmission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	})
+	require.True(t, resp.Allowed)
+
+	patches := make(map[string]interface{}, len(resp.Patches))
+	for _, patch := range resp.Patches {
+		patches[patch.Path] = patch.Value
+	}
+	return patches
+}
+
+func toJSONValue(t *testing.T, v interface{}) interface{} {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	var out interface{}
+	require.NoError(t, json.Unmarshal(b, &out))
+	return out
+}
+
+func TestPodEnforcementHandlerDefaults(t *testing.T) {
+	def := config.DefaultPlatformConfig()
+
+	patches := handlePod(t, admission.NewPodEnforcementHandler(), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "step"},
+	})
+	assert.Equal(t, toJSONValue(t, def.Scheduling.NodeSelector), patches["/spec/nodeSelector"])
+	assert.Equal(t, toJSONValue(t, def.Scheduling.Tolerations), patches["/spec/tolerations"])
+	assert.Equal(t, "None", patches["/spec/dnsPolicy"])
+	assert.Equal(t, toJSONValue(t, def.DNS.Config), patches["/spec/dnsConfig"])
+	assert.NotContains(t, patches, "/spec/runtimeClassName")
+}
+
+func TestPodEnforcementHandlerPlatformConfig(t *testing.T) {
+	cfg := &config.PlatformConfig{
+		Scheduling: config.SchedulingConfig{
+			NodeSelector: map[string]string{"example.com/pool": "tenants"},
+		},
+		DNS: config.DNSConfig{
+			Policy: corev1.DNSClusterFirst,
+		},
+		Sandbox: config.SandboxConfig{
+			RuntimeClassName: "kata",
+		},
+	}
+
+	patches := handlePod(t, admission.NewPodEnforcementHandler(admission.PodEnforcementHandlerWithPlatformConfig(cfg)), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "step"},
+		Spec: corev1.PodSpec{
+			NodeSelector: map[string]string{"kubernetes.io/os": "linux"},
+			Tolerations: []corev1.Toleration{
+				{Key: "example.com/existing", Operator: corev1.TolerationOpExists},
+			},
+		},
+	})
+	assert.Equal(t, "tenants", patches["/spec/nodeSelector/example.com~1pool"])
+	assert.NotContains(t, patches, "/spec/tolerations")
+	assert.Equal(t, "ClusterFirst", patches["/spec/dnsPolicy"])
+	assert.NotContains(t, patches, "/spec/dnsConfig")
+	assert.Equal(t, "kata", patches["/spec/runtimeClassName"])
+}
+
+func TestPodEnforcementHandlerStandalone(t *testing.T) {
+	patches := handlePod(t, admission.NewPodEnforcementHandler(
+		admission.PodEnforcementHandlerWithStandaloneMode(true),
+		admission.PodEnforcementHandlerWithRuntimeClassName("runsc"),
+	), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "step"},
+	})
+	assert.Equal(t, map[string]interface{}{
+		"/spec/runtimeClassName": "runsc",
+	}, patches)
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"context"
+	"path"
+
+	"github.com/puppetlabs/leg/storage"
+	"github.com/puppetlabs/relay-core/pkg/manager/configmap"
+	"github.com/puppetlabs/relay-core/pkg/obj"
+)
+
+// RunArtifactKeyPrefix is the blob store key prefix for the content of the
+// artifacts uploaded by the steps of a run. It only depends on the run itself
+// so that the content can be found even if the workflow no longer exists.
+func RunArtifactKeyPrefix(r *obj.Run) string {
+	return path.Join("artifacts", r.Key.Namespace, r.Key.Name)
+}
+
+// DeleteRunArtifacts removes the content of all artifacts uploaded by the
+// steps of a run from the given blob store.
+func DeleteRunArtifacts(ctx context.Context, rd *RunDeps, bs storage.BlobStore) error {
+	if bs == nil {
+		return nil
+	}
+
+	if rd.MutableConfigMap == nil {
+		// The workflow (or its tenant) is gone, so we have no index to work
+		// from. Fall back to the artifacts recorded in the run status.
+		return deleteRunArtifactsFromStatus(ctx, rd.Run, bs)
+	}
+
+	configMap := configmap.NewLocalConfigMap(rd.MutableConfigMap.Object)
+
+	for _, step := range rd.Workflow.Object.Spec.Steps {
+		if err := configmap.NewArtifactManager(ModelStep(rd.Run, step), configMap, bs).DeleteAll(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func deleteRunArtifactsFromStatus(ctx context.Context, r *obj.Run, bs storage.BlobStore) error {
+	prefix := RunArtifactKeyPrefix(r)
+
+	for _, ss := range r.Object.Status.Steps {
+		step := ModelStepFromName(r, ss.Name)
+
+		for _, a := range ss.Artifacts {
+			key := configmap.ArtifactBlobKey(prefix, step, a.Name)
+			if err := bs.Delete(ctx, key, storage.DeleteOptions{}); err != nil && !storage.IsNotFoundError(err) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
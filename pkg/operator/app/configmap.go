@@ -14,7 +14,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
-func ConfigureImmutableConfigMapForWebhookTrigger(ctx context.Context, cm *corev1obj.ConfigMap, wt *obj.WebhookTrigger) error {
+func ConfigureImmutableConfigMapForWebhookTrigger(ctx context.Context, cm *corev1obj.ConfigMap, wtd *WebhookTriggerDeps) error {
+	wt := wtd.WebhookTrigger
 	tm := ModelWebhookTrigger(wt)
 
 	// This implementation manages the underlying object, so no need to retrieve
@@ -27,17 +28,9 @@ func ConfigureImmutableConfigMapForWebhookTrigger(ctx context.Context, cm *corev
 		}
 	}
 
-	if env := wt.Object.Spec.Env.Value(); env != nil {
-		em := configmap.NewEnvironmentManager(ModelWebhookTrigger(wt), lcm)
-
-		vars := make(map[string]interface{})
-		for name, value := range env {
-			vars[name] = value
-		}
-
-		if _, err := em.Set(ctx, vars); err != nil {
-			return err
-		}
+	em := configmap.NewEnvironmentManager(ModelWebhookTrigger(wt), lcm)
+	if _, err := em.Set(ctx, TenantEnvironment(wtd.Tenant, wt.Object.Spec.Env)); err != nil {
+		return err
 	}
 
 	if len(wt.Object.Spec.Input) > 0 {
@@ -90,15 +83,31 @@ func ConfigureImmutableConfigMapForRun(ctx context.Context, cm *corev1obj.Config
 			}
 		}
 
-		if env := step.Env.Value(); env != nil {
-			em := configmap.NewEnvironmentManager(sm, lcm)
+		em := configmap.NewEnvironmentManager(sm, lcm)
+		if _, err := em.Set(ctx, TenantEnvironment(rd.WorkflowDeps.Tenant, step.Env)); err != nil {
+			return err
+		}
+
+		if len(step.Outputs) > 0 {
+			decls := make([]*model.StepOutputDeclaration, 0, len(step.Outputs))
+			for _, output := range step.Outputs {
+				if output == nil {
+					continue
+				}
+
+				decl := &model.StepOutputDeclaration{
+					Name:        output.Name,
+					Description: output.Description,
+					Sensitive:   output.Sensitive,
+				}
+				if output.Schema != nil {
+					decl.Schema = output.Schema.Value()
+				}
 
-			vars := make(map[string]interface{})
-			for name, value := range env {
-				vars[name] = value
+				decls = append(decls, decl)
 			}
 
-			if _, err := em.Set(ctx, vars); err != nil {
+			if err := configmap.NewStepOutputDeclarationManager(sm, lcm).Set(ctx, decls); err != nil {
 				return err
 			}
 		}
@@ -0,0 +1,192 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"github.com/puppetlabs/relay-core/pkg/obj"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	EventReasonRunStarted   = "RunStarted"
+	EventReasonRunSucceeded = "RunSucceeded"
+	EventReasonRunFailed    = "RunFailed"
+	EventReasonRunCancelled = "RunCancelled"
+
+	EventReasonStepSkipped = "StepSkipped"
+
+	EventReasonImageResolutionFailed = "ImageResolutionFailed"
+
+	EventReasonTenantReady    = "TenantReady"
+	EventReasonTenantNotReady = "TenantNotReady"
+
+	EventReasonTriggerServiceReady    = "TriggerServiceReady"
+	EventReasonTriggerServiceNotReady = "TriggerServiceNotReady"
+
+	EventReasonTriggerEventDelivered      = "TriggerEventDelivered"
+	EventReasonTriggerEventDeliveryFailed = "TriggerEventDeliveryFailed"
+	EventReasonTriggerEventDropped        = "TriggerEventDropped"
+	EventReasonTriggerEventReplayed       = "TriggerEventReplayed"
+	EventReasonTriggerEventReplayFailed   = "TriggerEventReplayFailed"
+)
+
+// ImageResolutionError is returned when the entrypoint of a container image
+// cannot be determined, usually because the image does not exist or the
+// registry is not reachable.
+type ImageResolutionError struct {
+	Image string
+	Cause error
+}
+
+func (e *ImageResolutionError) Error() string {
+	return fmt.Sprintf("could not resolve image %q: %+v", e.Image, e.Cause)
+}
+
+func (e *ImageResolutionError) Unwrap() error {
+	return e.Cause
+}
+
+// RecordImageResolutionError emits a warning event for the given object if the
+// error was caused by a failure to resolve a container image.
+func RecordImageResolutionError(rec record.EventRecorder, o runtime.Object, err error) {
+	var ire *ImageResolutionError
+	if !errors.As(err, &ire) {
+		return
+	}
+
+	rec.Eventf(o, corev1.EventTypeWarning, EventReasonImageResolutionFailed, "Could not resolve image %q: %v", ire.Image, ire.Cause)
+}
+
+// RecordRunEvents emits events for the transitions between the given previous
+// status of a run and its current status.
+func RecordRunEvents(rec record.EventRecorder, r *obj.Run, prev *relayv1beta1.RunStatus) {
+	cur := &r.Object.Status
+
+	if prev.StartTime == nil && cur.StartTime != nil && !r.IsValidationFailed() {
+		rec.Event(r.Object, corev1.EventTypeNormal, EventReasonRunStarted, "Run started")
+	}
+
+	prevSkipped := make(map[string]bool, len(prev.Steps))
+	for _, ss := range prev.Steps {
+		if cond, ok := findStepCondition(ss.Conditions, relayv1beta1.StepSkipped); ok && cond.Status == corev1.ConditionTrue {
+			prevSkipped[ss.Name] = true
+		}
+	}
+
+	for _, ss := range cur.Steps {
+		cond, ok := findStepCondition(ss.Conditions, relayv1beta1.StepSkipped)
+		if !ok || cond.Status != corev1.ConditionTrue || prevSkipped[ss.Name] {
+			continue
+		}
+
+		msg := fmt.Sprintf("Step %q skipped (%s)", ss.Name, cond.Reason)
+		for _, m := range ss.Messages {
+			if m.Source.WhenEvaluation != nil && m.Details != "" {
+				msg = fmt.Sprintf("%s: %s", msg, m.Details)
+				break
+			}
+		}
+
+		rec.Event(r.Object, corev1.EventTypeNormal, EventReasonStepSkipped, msg)
+	}
+
+	if cond, ok := findRunCondition(cur.Conditions, relayv1beta1.RunCancelled); ok && cond.Status == corev1.ConditionTrue {
+		if pc, ok := findRunCondition(prev.Conditions, relayv1beta1.RunCancelled); !ok || pc.Status != corev1.ConditionTrue {
+			rec.Event(r.Object, corev1.EventTypeNormal, EventReasonRunCancelled, "Run cancelled")
+		}
+
+		return
+	}
+
+	cond, ok := findRunCondition(cur.Conditions, relayv1beta1.RunSucceeded)
+	if !ok || cond.Status == corev1.ConditionUnknown {
+		return
+	} else if pc, ok := findRunCondition(prev.Conditions, relayv1beta1.RunSucceeded); ok && pc.Status == cond.Status {
+		return
+	}
+
+	if cond.Status == corev1.ConditionTrue {
+		rec.Event(r.Object, corev1.EventTypeNormal, EventReasonRunSucceeded, "Run succeeded")
+	} else if cond.Message != "" {
+		rec.Eventf(r.Object, corev1.EventTypeWarning, EventReasonRunFailed, "Run failed: %s", cond.Message)
+	} else {
+		rec.Event(r.Object, corev1.EventTypeWarning, EventReasonRunFailed, "Run failed")
+	}
+}
+
+// RecordTenantEvents emits an event when the readiness of a tenant changes.
+func RecordTenantEvents(rec record.EventRecorder, t *obj.Tenant, prev *relayv1beta1.TenantStatus) {
+	find := func(conds []relayv1beta1.TenantCondition) (relayv1beta1.Condition, bool) {
+		for _, cond := range conds {
+			if cond.Type == relayv1beta1.TenantReady {
+				return cond.Condition, true
+			}
+		}
+
+		return relayv1beta1.Condition{}, false
+	}
+
+	cond, ok := find(t.Object.Status.Conditions)
+	if !ok || cond.Status == corev1.ConditionUnknown {
+		return
+	} else if pc, ok := find(prev.Conditions); ok && pc.Status == cond.Status {
+		return
+	}
+
+	if cond.Status == corev1.ConditionTrue {
+		rec.Event(t.Object, corev1.EventTypeNormal, EventReasonTenantReady, cond.Message)
+	} else {
+		rec.Event(t.Object, corev1.EventTypeWarning, EventReasonTenantNotReady, cond.Message)
+	}
+}
+
+// RecordWebhookTriggerEvents emits an event when the readiness of the service
+// backing a webhook trigger changes.
+func RecordWebhookTriggerEvents(rec record.EventRecorder, wt *obj.WebhookTrigger, prev *relayv1beta1.WebhookTriggerStatus) {
+	find := func(conds []relayv1beta1.WebhookTriggerCondition) (relayv1beta1.Condition, bool) {
+		for _, cond := range conds {
+			if cond.Type == relayv1beta1.WebhookTriggerServiceReady {
+				return cond.Condition, true
+			}
+		}
+
+		return relayv1beta1.Condition{}, false
+	}
+
+	cond, ok := find(wt.Object.Status.Conditions)
+	if !ok || cond.Status == corev1.ConditionUnknown {
+		return
+	} else if pc, ok := find(prev.Conditions); ok && pc.Status == cond.Status && pc.Message == cond.Message {
+		return
+	}
+
+	if cond.Status == corev1.ConditionTrue {
+		rec.Event(wt.Object, corev1.EventTypeNormal, EventReasonTriggerServiceReady, cond.Message)
+	} else {
+		rec.Event(wt.Object, corev1.EventTypeWarning, EventReasonTriggerServiceNotReady, cond.Message)
+	}
+}
+
+func findRunCondition(conds []relayv1beta1.RunCondition, rct relayv1beta1.RunConditionType) (relayv1beta1.Condition, bool) {
+	for _, cond := range conds {
+		if cond.Type == rct {
+			return cond.Condition, true
+		}
+	}
+
+	return relayv1beta1.Condition{}, false
+}
+
+func findStepCondition(conds []relayv1beta1.StepCondition, sct relayv1beta1.StepConditionType) (relayv1beta1.Condition, bool) {
+	for _, cond := range conds {
+		if cond.Type == sct {
+			return cond.Condition, true
+		}
+	}
+
+	return relayv1beta1.Condition{}, false
+}
@@ -0,0 +1,291 @@
+package app_test
+
+import (
+	"testing"
+
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"github.com/puppetlabs/relay-core/pkg/obj"
+	"github.com/puppetlabs/relay-core/pkg/operator/app"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func recordedEvents(rec *record.FakeRecorder) (events []string) {
+	for {
+		select {
+		case ev := <-rec.Events:
+			events = append(events, ev)
+		default:
+			return
+		}
+	}
+}
+
+func runCondition(typ relayv1beta1.RunConditionType, status corev1.ConditionStatus, reason, message string) relayv1beta1.RunCondition {
+	return relayv1beta1.RunCondition{
+		Condition: relayv1beta1.Condition{
+			Status:  status,
+			Reason:  reason,
+			Message: message,
+		},
+		Type: typ,
+	}
+}
+
+func skippedStep(name string, messages ...*relayv1beta1.StepMessage) *relayv1beta1.StepStatus {
+	return &relayv1beta1.StepStatus{
+		Name: name,
+		Conditions: []relayv1beta1.StepCondition{
+			{
+				Condition: relayv1beta1.Condition{
+					Status: corev1.ConditionTrue,
+					Reason: "WhenConditionNotSatisfied",
+				},
+				Type: relayv1beta1.StepSkipped,
+			},
+		},
+		Messages: messages,
+	}
+}
+
+func TestRecordRunEvents(t *testing.T) {
+	now := metav1.Now()
+
+	tcs := []struct {
+		Name     string
+		Previous relayv1beta1.RunStatus
+		Current  relayv1beta1.RunStatus
+		Expected []string
+	}{
+		{
+			Name: "Started",
+			Current: relayv1beta1.RunStatus{
+				StartTime: &now,
+			},
+			Expected: []string{"Normal RunStarted Run started"},
+		},
+		{
+			Name: "AlreadyStarted",
+			Previous: relayv1beta1.RunStatus{
+				StartTime: &now,
+			},
+			Current: relayv1beta1.RunStatus{
+				StartTime: &now,
+			},
+		},
+		{
+			Name: "ValidationFailed",
+			Current: relayv1beta1.RunStatus{
+				StartTime: &now,
+				Conditions: []relayv1beta1.RunCondition{
+					runCondition(relayv1beta1.RunSucceeded, corev1.ConditionFalse, obj.RunStatusReasonValidationFailed, "invalid workflow"),
+				},
+			},
+			Expected: []string{"Warning RunFailed Run failed: invalid workflow"},
+		},
+		{
+			Name: "StepSkipped",
+			Previous: relayv1beta1.RunStatus{
+				StartTime: &now,
+				Steps:     []*relayv1beta1.StepStatus{skippedStep("already-skipped")},
+			},
+			Current: relayv1beta1.RunStatus{
+				StartTime: &now,
+				Steps: []*relayv1beta1.StepStatus{
+					skippedStep("already-skipped"),
+					skippedStep("newly-skipped", &relayv1beta1.StepMessage{
+						Source: relayv1beta1.StepMessageSource{
+							WhenEvaluation: &relayv1beta1.WhenEvaluationStepMessageSource{},
+						},
+						Details: "condition is false",
+					}),
+				},
+			},
+			Expected: []string{`Normal StepSkipped Step "newly-skipped" skipped (WhenConditionNotSatisfied): condition is false`},
+		},
+		{
+			Name: "Succeeded",
+			Previous: relayv1beta1.RunStatus{
+				StartTime: &now,
+				Conditions: []relayv1beta1.RunCondition{
+					runCondition(relayv1beta1.RunSucceeded, corev1.ConditionUnknown, "", ""),
+				},
+			},
+			Current: relayv1beta1.RunStatus{
+				StartTime: &now,
+				Conditions: []relayv1beta1.RunCondition{
+					runCondition(relayv1beta1.RunSucceeded, corev1.ConditionTrue, "", ""),
+				},
+			},
+			Expected: []string{"Normal RunSucceeded Run succeeded"},
+		},
+		{
+			Name: "AlreadySucceeded",
+			Previous: relayv1beta1.RunStatus{
+				StartTime: &now,
+				Conditions: []relayv1beta1.RunCondition{
+					runCondition(relayv1beta1.RunSucceeded, corev1.ConditionTrue, "", ""),
+				},
+			},
+			Current: relayv1beta1.RunStatus{
+				StartTime: &now,
+				Conditions: []relayv1beta1.RunCondition{
+					runCondition(relayv1beta1.RunSucceeded, corev1.ConditionTrue, "", ""),
+				},
+			},
+		},
+		{
+			Name: "Failed",
+			Previous: relayv1beta1.RunStatus{
+				StartTime: &now,
+			},
+			Current: relayv1beta1.RunStatus{
+				StartTime: &now,
+				Conditions: []relayv1beta1.RunCondition{
+					runCondition(relayv1beta1.RunSucceeded, corev1.ConditionFalse, "", ""),
+				},
+			},
+			Expected: []string{"Warning RunFailed Run failed"},
+		},
+		{
+			Name: "Cancelled",
+			Previous: relayv1beta1.RunStatus{
+				StartTime: &now,
+			},
+			Current: relayv1beta1.RunStatus{
+				StartTime: &now,
+				Conditions: []relayv1beta1.RunCondition{
+					runCondition(relayv1beta1.RunCancelled, corev1.ConditionTrue, "", ""),
+					runCondition(relayv1beta1.RunSucceeded, corev1.ConditionFalse, "", ""),
+				},
+			},
+			Expected: []string{"Normal RunCancelled Run cancelled"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Name, func(t *testing.T) {
+			rec := record.NewFakeRecorder(10)
+
+			r := obj.NewRun(client.ObjectKey{Namespace: "default", Name: "my-run"})
+			r.Object.Status = tc.Current
+
+			app.RecordRunEvents(rec, r, &tc.Previous)
+			assert.Equal(t, tc.Expected, recordedEvents(rec))
+		})
+	}
+}
+
+func TestRecordTenantEvents(t *testing.T) {
+	condition := func(status corev1.ConditionStatus, message string) []relayv1beta1.TenantCondition {
+		return []relayv1beta1.TenantCondition{
+			{
+				Condition: relayv1beta1.Condition{
+					Status:  status,
+					Message: message,
+				},
+				Type: relayv1beta1.TenantReady,
+			},
+		}
+	}
+
+	tcs := []struct {
+		Name     string
+		Previous []relayv1beta1.TenantCondition
+		Current  []relayv1beta1.TenantCondition
+		Expected []string
+	}{
+		{
+			Name:     "Ready",
+			Current:  condition(corev1.ConditionTrue, "The tenant is ready."),
+			Expected: []string{"Normal TenantReady The tenant is ready."},
+		},
+		{
+			Name:     "NotReady",
+			Previous: condition(corev1.ConditionTrue, "The tenant is ready."),
+			Current:  condition(corev1.ConditionFalse, "The tenant is not ready."),
+			Expected: []string{"Warning TenantNotReady The tenant is not ready."},
+		},
+		{
+			Name:     "Unchanged",
+			Previous: condition(corev1.ConditionTrue, "The tenant is ready."),
+			Current:  condition(corev1.ConditionTrue, "The tenant is ready."),
+		},
+		{
+			Name:    "Unknown",
+			Current: condition(corev1.ConditionUnknown, ""),
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Name, func(t *testing.T) {
+			rec := record.NewFakeRecorder(10)
+
+			tn := obj.NewTenant(client.ObjectKey{Namespace: "default", Name: "my-tenant"})
+			tn.Object.Status.Conditions = tc.Current
+
+			app.RecordTenantEvents(rec, tn, &relayv1beta1.TenantStatus{Conditions: tc.Previous})
+			assert.Equal(t, tc.Expected, recordedEvents(rec))
+		})
+	}
+}
+
+func TestRecordWebhookTriggerEvents(t *testing.T) {
+	condition := func(status corev1.ConditionStatus, message string) []relayv1beta1.WebhookTriggerCondition {
+		return []relayv1beta1.WebhookTriggerCondition{
+			{
+				Condition: relayv1beta1.Condition{
+					Status:  status,
+					Message: message,
+				},
+				Type: relayv1beta1.WebhookTriggerServiceReady,
+			},
+		}
+	}
+
+	tcs := []struct {
+		Name     string
+		Previous []relayv1beta1.WebhookTriggerCondition
+		Current  []relayv1beta1.WebhookTriggerCondition
+		Expected []string
+	}{
+		{
+			Name:     "Ready",
+			Current:  condition(corev1.ConditionTrue, "The service is ready."),
+			Expected: []string{"Normal TriggerServiceReady The service is ready."},
+		},
+		{
+			Name:     "NotReady",
+			Previous: condition(corev1.ConditionTrue, "The service is ready."),
+			Current:  condition(corev1.ConditionFalse, "The revision failed."),
+			Expected: []string{"Warning TriggerServiceNotReady The revision failed."},
+		},
+		{
+			Name:     "MessageChanged",
+			Previous: condition(corev1.ConditionFalse, "The revision failed."),
+			Current:  condition(corev1.ConditionFalse, "The image could not be pulled."),
+			Expected: []string{"Warning TriggerServiceNotReady The image could not be pulled."},
+		},
+		{
+			Name:     "Unchanged",
+			Previous: condition(corev1.ConditionTrue, "The service is ready."),
+			Current:  condition(corev1.ConditionTrue, "The service is ready."),
+		},
+		{
+			Name:    "Unknown",
+			Current: condition(corev1.ConditionUnknown, ""),
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Name, func(t *testing.T) {
+			rec := record.NewFakeRecorder(10)
+
+			wt := obj.NewWebhookTrigger(client.ObjectKey{Namespace: "default", Name: "my-trigger"})
+			wt.Object.Status.Conditions = tc.Current
+
+			app.RecordWebhookTriggerEvents(rec, wt, &relayv1beta1.WebhookTriggerStatus{Conditions: tc.Previous})
+			assert.Equal(t, tc.Expected, recordedEvents(rec))
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/lifecycle"
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"github.com/puppetlabs/relay-core/pkg/model"
+	"github.com/puppetlabs/relay-core/pkg/obj"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	servingv1beta1 "knative.dev/serving/pkg/apis/serving/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type KnativeDomainMappingSet struct {
+	ListOptions *client.ListOptions
+
+	DomainMappings []*obj.KnativeDomainMapping
+}
+
+var _ lifecycle.Loader = &KnativeDomainMappingSet{}
+
+func (kdms *KnativeDomainMappingSet) Load(ctx context.Context, cl client.Client) (bool, error) {
+	dms := &servingv1beta1.DomainMappingList{}
+	if err := cl.List(ctx, dms, kdms.ListOptions); err != nil {
+		return false, err
+	}
+
+	kdms.DomainMappings = make([]*obj.KnativeDomainMapping, len(dms.Items))
+	for i := range dms.Items {
+		kdms.DomainMappings[i] = obj.NewKnativeDomainMappingFromObject(&dms.Items[i])
+	}
+
+	return true, nil
+}
+
+func NewKnativeDomainMappingSet(opts ...client.ListOption) *KnativeDomainMappingSet {
+	o := &client.ListOptions{}
+	o.ApplyOptions(opts)
+
+	return &KnativeDomainMappingSet{
+		ListOptions: o,
+	}
+}
+
+func NewKnativeDomainMappingSetForWebhookTrigger(wtd *WebhookTriggerDeps) *KnativeDomainMappingSet {
+	return NewKnativeDomainMappingSet(
+		client.InNamespace(wtd.TenantDeps.Namespace.Name),
+		client.MatchingLabels{
+			model.RelayControllerWebhookTriggerIDLabel: wtd.WebhookTrigger.Key.Name,
+		},
+	)
+}
+
+func ConfigureKnativeDomainMapping(ctx context.Context, dm *obj.KnativeDomainMapping, ks *obj.KnativeService, wtd *WebhookTriggerDeps) error {
+	dm.LabelAnnotateFrom(ctx, wtd.WebhookTrigger.Object)
+	lifecycle.Label(ctx, dm, model.RelayControllerWebhookTriggerIDLabel, wtd.WebhookTrigger.Key.Name)
+
+	if err := wtd.OwnerConfigMap.Own(ctx, dm); err != nil {
+		return err
+	}
+
+	// Changes to the status of the mapping need to be reflected in the URL of
+	// the webhook trigger.
+	if err := DependencyManager.SetDependencyOf(
+		&dm.Object.ObjectMeta,
+		lifecycle.TypedObject{
+			Object: wtd.WebhookTrigger.Object,
+			GVK:    relayv1beta1.WebhookTriggerKind,
+		}); err != nil {
+		return err
+	}
+
+	dm.Object.Spec = servingv1beta1.DomainMappingSpec{
+		Ref: duckv1.KReference{
+			APIVersion: obj.KnativeServiceKind.GroupVersion().String(),
+			Kind:       obj.KnativeServiceKind.Kind,
+			Namespace:  ks.Key.Namespace,
+			Name:       ks.Key.Name,
+		},
+	}
+
+	if tls := wtd.WebhookTrigger.Object.Spec.Domain.TLS; tls != nil {
+		dm.Object.Spec.TLS = &servingv1beta1.SecretTLS{
+			SecretName: tls.SecretName,
+		}
+	}
+
+	return nil
+}
+
+// ApplyKnativeDomainMapping maps the custom domain of the webhook trigger, if
+// any, to the given Knative service. Mappings for domains the trigger no longer
+// uses are removed.
+func ApplyKnativeDomainMapping(ctx context.Context, cl client.Client, wtd *WebhookTriggerDeps, ks *obj.KnativeService) (*obj.KnativeDomainMapping, error) {
+	var dm *obj.KnativeDomainMapping
+	if domain := wtd.WebhookTrigger.Object.Spec.Domain; domain != nil {
+		// Knative requires the mapping to be named for the domain.
+		dm = obj.NewKnativeDomainMapping(client.ObjectKey{
+			Namespace: ks.Key.Namespace,
+			Name:      domain.Name,
+		})
+
+		if ok, err := dm.Load(ctx, cl); err != nil {
+			return nil, err
+		} else if ok && dm.Object.GetLabels()[model.RelayControllerWebhookTriggerIDLabel] != wtd.WebhookTrigger.Key.Name {
+			return nil, fmt.Errorf("the domain %q is already in use", domain.Name)
+		}
+
+		if err := ConfigureKnativeDomainMapping(ctx, dm, ks, wtd); err != nil {
+			return nil, err
+		}
+
+		if err := dm.Persist(ctx, cl); err != nil {
+			return nil, err
+		}
+	}
+
+	kdms := NewKnativeDomainMappingSetForWebhookTrigger(wtd)
+	if _, err := kdms.Load(ctx, cl); err != nil {
+		return nil, err
+	}
+
+	for _, stale := range kdms.DomainMappings {
+		if dm != nil && stale.Key == dm.Key {
+			continue
+		}
+
+		if _, err := stale.Delete(ctx, cl); err != nil {
+			return nil, err
+		}
+	}
+
+	return dm, nil
+}
+
+type KnativeDomainMappingResult struct {
+	KnativeDomainMapping *obj.KnativeDomainMapping
+	Error                error
+}
+
+func AsKnativeDomainMappingResult(dm *obj.KnativeDomainMapping, err error) *KnativeDomainMappingResult {
+	return &KnativeDomainMappingResult{
+		KnativeDomainMapping: dm,
+		Error:                err,
+	}
+}
@@ -29,10 +29,20 @@ const (
 )
 
 func ConfigureKnativeService(ctx context.Context, s *obj.KnativeService, wtd *WebhookTriggerDeps) error {
-	// FIXME This should be configurable
-	lifecycle.Label(ctx, s, KnativeServiceVisibilityLabel, KnativeServiceVisibilityClusterLocal)
+	if err := ValidateWebhookTriggerSpec(&wtd.WebhookTrigger.Object.Spec); err != nil {
+		return err
+	}
+
 	s.LabelAnnotateFrom(ctx, wtd.WebhookTrigger.Object)
 
+	// Knative exposes services externally unless they are explicitly marked
+	// otherwise.
+	if wtd.WebhookTrigger.Object.Spec.Visibility == relayv1beta1.WebhookTriggerVisibilityExternal {
+		delete(s.Object.Labels, KnativeServiceVisibilityLabel)
+	} else {
+		lifecycle.Label(ctx, s, KnativeServiceVisibilityLabel, KnativeServiceVisibilityClusterLocal)
+	}
+
 	// Owned by the owner ConfigMap so we only have to worry about deleting one
 	// thing.
 	if err := wtd.OwnerConfigMap.Own(ctx, s); err != nil {
@@ -80,6 +90,8 @@ func ConfigureKnativeService(ctx context.Context, s *obj.KnativeService, wtd *We
 		},
 	}
 
+	ConfigureTenantDefaultMetadata(wtd.Tenant, &template.ObjectMeta)
+
 	// The revisions will be marked with a dependency reference as well as we
 	// need to track them to clean up stale resources.
 	if err := DependencyManager.SetDependencyOf(
@@ -139,7 +151,8 @@ func ConfigureKnativeService(ctx context.Context, s *obj.KnativeService, wtd *We
 				ReadOnly:  false,
 			},
 		},
-		Env: envVars,
+		Env:             envVars,
+		SecurityContext: KnativeSecurityContext(),
 	}
 
 	container := corev1.Container{
@@ -147,6 +160,7 @@ func ConfigureKnativeService(ctx context.Context, s *obj.KnativeService, wtd *We
 		Image:           image,
 		ImagePullPolicy: corev1.PullAlways,
 		Env:             envVars,
+		SecurityContext: KnativeSecurityContext(),
 		VolumeMounts: []corev1.VolumeMount{
 			{
 				Name:      model.ToolsMountName,
@@ -212,7 +226,7 @@ func ConfigureKnativeService(ctx context.Context, s *obj.KnativeService, wtd *We
 
 	ep, err := entrypoint.ImageEntrypoint(image, []string{command}, args)
 	if err != nil {
-		return err
+		return &ImageResolutionError{Image: image, Cause: err}
 	}
 
 	container.Command = []string{path.Join(model.ToolsMountPath, ep.Entrypoint)}
@@ -221,6 +235,8 @@ func ConfigureKnativeService(ctx context.Context, s *obj.KnativeService, wtd *We
 	template.Spec.PodSpec.InitContainers = []corev1.Container{toolsContainer}
 	template.Spec.PodSpec.Containers = []corev1.Container{container}
 
+	ConfigureKnativeRevisionTemplateScaling(&template, &wtd.WebhookTrigger.Object.Spec)
+
 	if err := wtd.AnnotateTriggerToken(ctx, &template.ObjectMeta); err != nil {
 		return err
 	}
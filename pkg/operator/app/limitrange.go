@@ -1,7 +1,13 @@
 package app
 
 import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
 	corev1obj "github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/api/corev1"
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
@@ -32,6 +38,74 @@ func LimitRangeWithContainerMaxLimit(rl corev1.ResourceList) LimitRangeOption {
 	}
 }
 
+// LimitRangeWithTenantLimitRange replaces individual resources of the
+// defaults and maximum with those given by a tenant. Resources the tenant does
+// not specify are left alone.
+func LimitRangeWithTenantLimitRange(tlr *relayv1beta1.TenantLimitRange) LimitRangeOption {
+	merge := func(target corev1.ResourceList, from corev1.ResourceList) corev1.ResourceList {
+		if len(from) == 0 {
+			return target
+		}
+
+		merged := target.DeepCopy()
+		if merged == nil {
+			merged = make(corev1.ResourceList, len(from))
+		}
+
+		for name, q := range from {
+			merged[name] = q.DeepCopy()
+		}
+
+		return merged
+	}
+
+	return func(opts *limitRangeOptions) {
+		opts.containerDefaultLimit = merge(opts.containerDefaultLimit, tlr.Default)
+		opts.containerDefaultRequestLimit = merge(opts.containerDefaultRequestLimit, tlr.DefaultRequest)
+		opts.containerMaxLimit = merge(opts.containerMaxLimit, tlr.Max)
+	}
+}
+
+// LimitRangeContainerMax returns the maximum resource limits of containers in
+// the given limit range.
+func LimitRangeContainerMax(lr *corev1obj.LimitRange) corev1.ResourceList {
+	for _, item := range lr.Object.Spec.Limits {
+		if item.Type == corev1.LimitTypeContainer {
+			return item.Max
+		}
+	}
+
+	return nil
+}
+
+// ValidateLimitRange checks that the default request of each resource is no
+// more than its default limit, and that both are no more than its maximum, as
+// the API server requires.
+func ValidateLimitRange(lr *corev1obj.LimitRange) error {
+	var violations []string
+
+	check := func(name string, values corev1.ResourceList, boundName string, bounds corev1.ResourceList) {
+		for rn, q := range values {
+			if bound, found := bounds[rn]; found && q.Cmp(bound) > 0 {
+				violations = append(violations, fmt.Sprintf("%s %s %s exceeds %s %s", name, rn, q.String(), boundName, bound.String()))
+			}
+		}
+	}
+
+	for _, item := range lr.Object.Spec.Limits {
+		check("default request", item.DefaultRequest, "default limit", item.Default)
+		check("default limit", item.Default, "maximum", item.Max)
+		check("default request", item.DefaultRequest, "maximum", item.Max)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	sort.Strings(violations)
+	return errors.New(strings.Join(violations, "; "))
+}
+
 func ConfigureLimitRange(lr *corev1obj.LimitRange, opts ...LimitRangeOption) {
 	lro := &limitRangeOptions{
 		containerDefaultLimit: corev1.ResourceList{
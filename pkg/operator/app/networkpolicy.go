@@ -3,12 +3,17 @@ package app
 import (
 	networkingv1obj "github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/api/networkingv1"
 	"github.com/puppetlabs/relay-core/pkg/obj"
+	"github.com/puppetlabs/relay-core/pkg/operator/config"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+const (
+	NetworkPolicyDNSPort = 53
+)
+
 var (
 	DefaultNetworkPolicyDeniedIPBlocks = []string{
 		"0.0.0.0/8",       // "This host on this network"
@@ -31,10 +36,28 @@ var (
 )
 
 type networkPolicyOptions struct {
-	deniedIPBlocks          []string
-	systemNamespaceSelector metav1.LabelSelector
-	metadataAPIPodSelector  metav1.LabelSelector
-	metadataAPIPort         int
+	deniedIPBlocks                  []string
+	systemNamespaceSelector         metav1.LabelSelector
+	metadataAPIPodSelector          metav1.LabelSelector
+	metadataAPIPort                 int
+	webhookGatewayNamespaceSelector metav1.LabelSelector
+	internetEgress                  bool
+	additionalEgress                []networkingv1.NetworkPolicyEgressRule
+}
+
+func newNetworkPolicyOptions(opts []NetworkPolicyOption) *networkPolicyOptions {
+	npo := &networkPolicyOptions{
+		deniedIPBlocks: DefaultNetworkPolicyDeniedIPBlocks,
+		internetEgress: true,
+	}
+
+	NetworkPolicyWithPlatformConfig(config.DefaultPlatformConfig())(npo)
+
+	for _, opt := range opts {
+		opt(npo)
+	}
+
+	return npo
 }
 
 type NetworkPolicyOption func(opts *networkPolicyOptions)
@@ -63,58 +86,156 @@ func NetworkPolicyWithMetadataAPIPort(port int) NetworkPolicyOption {
 	}
 }
 
-func ConfigureNetworkPolicyForTenant(np *networkingv1obj.NetworkPolicy) {
-	// The default tenant policy blocks all traffic. Additional policies are
-	// additive.
+// NetworkPolicyWithWebhookGatewayNamespaceSelector sets the namespaces that
+// may send requests to webhook triggers.
+func NetworkPolicyWithWebhookGatewayNamespaceSelector(selector metav1.LabelSelector) NetworkPolicyOption {
+	return func(opts *networkPolicyOptions) {
+		opts.webhookGatewayNamespaceSelector = selector
+	}
+}
+
+// NetworkPolicyWithPlatformConfig applies the system selectors and metadata
+// API port of the given platform configuration.
+func NetworkPolicyWithPlatformConfig(cfg *config.PlatformConfig) NetworkPolicyOption {
+	return func(opts *networkPolicyOptions) {
+		if cfg == nil {
+			return
+		}
+
+		npc := cfg.NetworkPolicy
+
+		if npc.SystemNamespaceSelector != nil {
+			NetworkPolicyWithSystemNamespaceSelector(*npc.SystemNamespaceSelector.DeepCopy())(opts)
+		}
+
+		if npc.MetadataAPIPodSelector != nil {
+			NetworkPolicyWithMetadataAPIPodSelector(*npc.MetadataAPIPodSelector.DeepCopy())(opts)
+		}
+
+		if npc.MetadataAPIPort != 0 {
+			NetworkPolicyWithMetadataAPIPort(npc.MetadataAPIPort)(opts)
+		}
+
+		if npc.WebhookGatewayNamespaceSelector != nil {
+			NetworkPolicyWithWebhookGatewayNamespaceSelector(*npc.WebhookGatewayNamespaceSelector.DeepCopy())(opts)
+		}
+	}
+}
+
+// NetworkPolicyWithInternetEgress sets whether workloads may connect to
+// public addresses outside the cluster. Workloads may always send DNS queries.
+func NetworkPolicyWithInternetEgress(allow bool) NetworkPolicyOption {
+	return func(opts *networkPolicyOptions) {
+		opts.internetEgress = allow
+	}
+}
+
+// NetworkPolicyWithAdditionalEgressRules allows workloads to connect to the
+// destinations given by the rules in addition to the defaults.
+func NetworkPolicyWithAdditionalEgressRules(rules []networkingv1.NetworkPolicyEgressRule) NetworkPolicyOption {
+	return func(opts *networkPolicyOptions) {
+		opts.additionalEgress = append(opts.additionalEgress, rules...)
+	}
+}
+
+// NetworkPolicyOptionsForTenant returns the options that apply the network
+// policy settings of the given tenant.
+func NetworkPolicyOptionsForTenant(t *obj.Tenant) []NetworkPolicyOption {
+	tnp := t.Object.Spec.NetworkPolicy
+	if tnp == nil {
+		return nil
+	}
+
+	return []NetworkPolicyOption{
+		NetworkPolicyWithInternetEgress(!tnp.DenyInternetEgress),
+		NetworkPolicyWithAdditionalEgressRules(tnp.Egress),
+	}
+}
+
+func ConfigureNetworkPolicyForTenant(np *networkingv1obj.NetworkPolicy, opts ...NetworkPolicyOption) {
+	npo := newNetworkPolicyOptions(opts)
+
+	// The default tenant policy blocks all traffic except to the destinations
+	// the tenant allows. Additional policies are additive.
 	np.Object.Spec = networkingv1.NetworkPolicySpec{
 		PolicyTypes: []networkingv1.PolicyType{
 			networkingv1.PolicyTypeIngress,
 			networkingv1.PolicyTypeEgress,
 		},
+		Egress: copyNetworkPolicyEgressRules(npo.additionalEgress),
 	}
 }
 
 func ConfigureNetworkPolicyForRun(np *networkingv1obj.NetworkPolicy, r *obj.Run, opts ...NetworkPolicyOption) {
-	np.Object.Spec = baseTenantWorkloadNetworkPolicySpec(r.PodSelector(), opts)
+	np.Object.Spec = baseTenantWorkloadNetworkPolicySpec(r.PodSelector(), newNetworkPolicyOptions(opts))
 }
 
 func ConfigureNetworkPolicyForWebhookTrigger(np *networkingv1obj.NetworkPolicy, wt *obj.WebhookTrigger, opts ...NetworkPolicyOption) {
-	np.Object.Spec = baseTenantWorkloadNetworkPolicySpec(wt.PodSelector(), opts)
+	npo := newNetworkPolicyOptions(opts)
+
+	np.Object.Spec = baseTenantWorkloadNetworkPolicySpec(wt.PodSelector(), npo)
 
 	// Allow ingress from the defined upstream.
 	np.Object.Spec.Ingress = append(np.Object.Spec.Ingress, networkingv1.NetworkPolicyIngressRule{
 		From: []networkingv1.NetworkPolicyPeer{
 			{
-				NamespaceSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{
-						"nebula.puppet.com/network-policy.webhook-gateway": "true",
-					},
-				},
+				NamespaceSelector: &npo.webhookGatewayNamespaceSelector,
 			},
 		},
 	})
 }
 
-func baseTenantWorkloadNetworkPolicySpec(podSelector metav1.LabelSelector, opts []NetworkPolicyOption) networkingv1.NetworkPolicySpec {
-	npo := &networkPolicyOptions{
-		deniedIPBlocks: DefaultNetworkPolicyDeniedIPBlocks,
-		systemNamespaceSelector: metav1.LabelSelector{
-			MatchLabels: map[string]string{
-				"nebula.puppet.com/network-policy.tasks": "true",
+func baseTenantWorkloadNetworkPolicySpec(podSelector metav1.LabelSelector, npo *networkPolicyOptions) networkingv1.NetworkPolicySpec {
+
+	var egress []networkingv1.NetworkPolicyEgressRule
+	if npo.internetEgress {
+		egress = append(egress, networkingv1.NetworkPolicyEgressRule{
+			// Allow all external traffic except RFC 1918 space and IANA
+			// special-purpose address registry.
+			To: []networkingv1.NetworkPolicyPeer{
+				{
+					IPBlock: &networkingv1.IPBlock{
+						CIDR:   "0.0.0.0/0",
+						Except: npo.deniedIPBlocks,
+					},
+				},
+			},
+		})
+	} else {
+		// Names must still resolve, whether the cluster DNS service or an
+		// external resolver answers.
+		egress = append(egress, networkingv1.NetworkPolicyEgressRule{
+			Ports: []networkingv1.NetworkPolicyPort{
+				{
+					Protocol: func(p corev1.Protocol) *corev1.Protocol { return &p }(corev1.ProtocolUDP),
+					Port:     func(i intstr.IntOrString) *intstr.IntOrString { return &i }(intstr.FromInt(NetworkPolicyDNSPort)),
+				},
+				{
+					Protocol: func(p corev1.Protocol) *corev1.Protocol { return &p }(corev1.ProtocolTCP),
+					Port:     func(i intstr.IntOrString) *intstr.IntOrString { return &i }(intstr.FromInt(NetworkPolicyDNSPort)),
+				},
+			},
+		})
+	}
+
+	egress = append(egress, networkingv1.NetworkPolicyEgressRule{
+		// Allow access to the metadata API.
+		To: []networkingv1.NetworkPolicyPeer{
+			{
+				NamespaceSelector: &npo.systemNamespaceSelector,
+				PodSelector:       &npo.metadataAPIPodSelector,
 			},
 		},
-		metadataAPIPodSelector: metav1.LabelSelector{
-			MatchLabels: map[string]string{
-				"app.kubernetes.io/name":      "nebula-system",
-				"app.kubernetes.io/component": "metadata-api",
+		Ports: []networkingv1.NetworkPolicyPort{
+			{
+				Protocol: func(p corev1.Protocol) *corev1.Protocol { return &p }(corev1.ProtocolTCP),
+				Port:     func(i intstr.IntOrString) *intstr.IntOrString { return &i }(intstr.FromInt(npo.metadataAPIPort)),
 			},
 		},
-		metadataAPIPort: 7000,
-	}
+	})
 
-	for _, opt := range opts {
-		opt(npo)
-	}
+	// Allow any destinations requested by the tenant.
+	egress = append(egress, copyNetworkPolicyEgressRules(npo.additionalEgress)...)
 
 	return networkingv1.NetworkPolicySpec{
 		PodSelector: podSelector,
@@ -123,34 +244,19 @@ func baseTenantWorkloadNetworkPolicySpec(podSelector metav1.LabelSelector, opts
 			networkingv1.PolicyTypeEgress,
 		},
 		Ingress: []networkingv1.NetworkPolicyIngressRule{},
-		Egress: []networkingv1.NetworkPolicyEgressRule{
-			{
-				// Allow all external traffic except RFC 1918 space and IANA
-				// special-purpose address registry.
-				To: []networkingv1.NetworkPolicyPeer{
-					{
-						IPBlock: &networkingv1.IPBlock{
-							CIDR:   "0.0.0.0/0",
-							Except: npo.deniedIPBlocks,
-						},
-					},
-				},
-			},
-			{
-				// Allow access to the metadata API.
-				To: []networkingv1.NetworkPolicyPeer{
-					{
-						NamespaceSelector: &npo.systemNamespaceSelector,
-						PodSelector:       &npo.metadataAPIPodSelector,
-					},
-				},
-				Ports: []networkingv1.NetworkPolicyPort{
-					{
-						Protocol: func(p corev1.Protocol) *corev1.Protocol { return &p }(corev1.ProtocolTCP),
-						Port:     func(i intstr.IntOrString) *intstr.IntOrString { return &i }(intstr.FromInt(npo.metadataAPIPort)),
-					},
-				},
-			},
-		},
+		Egress:  egress,
 	}
 }
+
+func copyNetworkPolicyEgressRules(rules []networkingv1.NetworkPolicyEgressRule) []networkingv1.NetworkPolicyEgressRule {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	cp := make([]networkingv1.NetworkPolicyEgressRule, len(rules))
+	for i := range rules {
+		rules[i].DeepCopyInto(&cp[i])
+	}
+
+	return cp
+}
@@ -0,0 +1,157 @@
+package app_test
+
+import (
+	"testing"
+
+	networkingv1obj "github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/api/networkingv1"
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"github.com/puppetlabs/relay-core/pkg/obj"
+	"github.com/puppetlabs/relay-core/pkg/operator/app"
+	"github.com/puppetlabs/relay-core/pkg/operator/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestConfigureNetworkPolicyForTenant(t *testing.T) {
+	https := intstr.FromInt(443)
+	vcs := networkingv1.NetworkPolicyEgressRule{
+		To: []networkingv1.NetworkPolicyPeer{
+			{IPBlock: &networkingv1.IPBlock{CIDR: "10.20.0.0/16"}},
+		},
+		Ports: []networkingv1.NetworkPolicyPort{{Port: &https}},
+	}
+	registry := networkingv1.NetworkPolicyEgressRule{
+		To: []networkingv1.NetworkPolicyPeer{
+			{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"name": "registry"}},
+				PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "registry"}},
+			},
+		},
+	}
+
+	tcs := []struct {
+		Name                   string
+		NetworkPolicy          *relayv1beta1.TenantNetworkPolicy
+		ExpectedTenantEgress   []networkingv1.NetworkPolicyEgressRule
+		ExpectedInternetEgress bool
+	}{
+		{
+			Name:                   "Default",
+			ExpectedInternetEgress: true,
+		},
+		{
+			Name: "AllowList",
+			NetworkPolicy: &relayv1beta1.TenantNetworkPolicy{
+				Egress: []networkingv1.NetworkPolicyEgressRule{vcs, registry},
+			},
+			ExpectedTenantEgress:   []networkingv1.NetworkPolicyEgressRule{vcs, registry},
+			ExpectedInternetEgress: true,
+		},
+		{
+			Name: "DenyInternetEgress",
+			NetworkPolicy: &relayv1beta1.TenantNetworkPolicy{
+				DenyInternetEgress: true,
+				Egress:             []networkingv1.NetworkPolicyEgressRule{vcs},
+			},
+			ExpectedTenantEgress: []networkingv1.NetworkPolicyEgressRule{vcs},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Name, func(t *testing.T) {
+			tn := obj.NewTenant(client.ObjectKey{Namespace: "default", Name: "my-tenant"})
+			tn.Object.Spec.NetworkPolicy = tc.NetworkPolicy
+
+			opts := app.NetworkPolicyOptionsForTenant(tn)
+
+			tnp := networkingv1obj.NewNetworkPolicy(client.ObjectKey{Namespace: "tenant", Name: "my-tenant"})
+			app.ConfigureNetworkPolicyForTenant(tnp, opts...)
+			assert.Equal(t, tc.ExpectedTenantEgress, tnp.Object.Spec.Egress)
+			assert.Empty(t, tnp.Object.Spec.Ingress)
+
+			rnp := networkingv1obj.NewNetworkPolicy(client.ObjectKey{Namespace: "tenant", Name: "my-run"})
+			app.ConfigureNetworkPolicyForRun(rnp, obj.NewRun(client.ObjectKey{Namespace: "tenant", Name: "my-run"}), opts...)
+
+			wnp := networkingv1obj.NewNetworkPolicy(client.ObjectKey{Namespace: "tenant", Name: "my-trigger"})
+			app.ConfigureNetworkPolicyForWebhookTrigger(wnp, obj.NewWebhookTrigger(client.ObjectKey{Namespace: "tenant", Name: "my-trigger"}), opts...)
+
+			for _, np := range []*networkingv1obj.NetworkPolicy{rnp, wnp} {
+				var internet, metadataAPI, dns bool
+				for _, rule := range np.Object.Spec.Egress {
+					if len(rule.To) == 0 && len(rule.Ports) == 2 &&
+						rule.Ports[0].Port.IntValue() == 53 && *rule.Ports[0].Protocol == corev1.ProtocolUDP &&
+						rule.Ports[1].Port.IntValue() == 53 && *rule.Ports[1].Protocol == corev1.ProtocolTCP {
+						dns = true
+					}
+
+					for _, peer := range rule.To {
+						if peer.IPBlock != nil && peer.IPBlock.CIDR == "0.0.0.0/0" {
+							internet = true
+							assert.Equal(t, app.DefaultNetworkPolicyDeniedIPBlocks, peer.IPBlock.Except)
+						}
+
+						if peer.PodSelector != nil && peer.PodSelector.MatchLabels["app.kubernetes.io/component"] == "metadata-api" {
+							metadataAPI = true
+							require.Len(t, rule.Ports, 1)
+							assert.Equal(t, corev1.ProtocolTCP, *rule.Ports[0].Protocol)
+						}
+					}
+				}
+
+				assert.Equal(t, tc.ExpectedInternetEgress, internet)
+				assert.Equal(t, !tc.ExpectedInternetEgress, dns, "DNS must be allowed separately when internet egress is denied")
+				assert.True(t, metadataAPI, "metadata API must always be reachable")
+
+				for _, rule := range tc.ExpectedTenantEgress {
+					assert.Contains(t, np.Object.Spec.Egress, rule)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigureNetworkPolicyWithPlatformConfig(t *testing.T) {
+	cfg := config.DefaultPlatformConfig()
+	cfg.NetworkPolicy = config.NetworkPolicyConfig{
+		SystemNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"relay.sh/system": "true"}},
+		MetadataAPIPodSelector:  &metav1.LabelSelector{MatchLabels: map[string]string{"app": "relay-metadata-api"}},
+		MetadataAPIPort:         8080,
+		WebhookGatewayNamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"relay.sh/gateway": "true"},
+		},
+	}
+
+	np := networkingv1obj.NewNetworkPolicy(client.ObjectKey{Namespace: "tenant", Name: "my-trigger"})
+	app.ConfigureNetworkPolicyForWebhookTrigger(
+		np,
+		obj.NewWebhookTrigger(client.ObjectKey{Namespace: "tenant", Name: "my-trigger"}),
+		app.NetworkPolicyWithPlatformConfig(cfg),
+	)
+
+	port := intstr.FromInt(8080)
+	assert.Contains(t, np.Object.Spec.Egress, networkingv1.NetworkPolicyEgressRule{
+		To: []networkingv1.NetworkPolicyPeer{
+			{
+				NamespaceSelector: cfg.NetworkPolicy.SystemNamespaceSelector,
+				PodSelector:       cfg.NetworkPolicy.MetadataAPIPodSelector,
+			},
+		},
+		Ports: []networkingv1.NetworkPolicyPort{
+			{
+				Protocol: func(p corev1.Protocol) *corev1.Protocol { return &p }(corev1.ProtocolTCP),
+				Port:     &port,
+			},
+		},
+	})
+	assert.Equal(t, []networkingv1.NetworkPolicyIngressRule{
+		{
+			From: []networkingv1.NetworkPolicyPeer{
+				{NamespaceSelector: cfg.NetworkPolicy.WebhookGatewayNamespaceSelector},
+			},
+		},
+	}, np.Object.Spec.Ingress)
+}
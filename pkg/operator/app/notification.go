@@ -0,0 +1,159 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"github.com/puppetlabs/relay-core/pkg/manager/api"
+	"github.com/puppetlabs/relay-core/pkg/model"
+	"github.com/puppetlabs/relay-core/pkg/obj"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	RunNotificationMaxAttempts = 8
+
+	// RunNotificationAttemptTimeout bounds each delivery attempt. Delivery
+	// happens in the run reconciler, so a slow sink must not tie up a worker;
+	// we rely on retries instead.
+	RunNotificationAttemptTimeout = 5 * time.Second
+
+	runNotificationInitialBackoff = 5 * time.Second
+	runNotificationMaxBackoff     = 5 * time.Minute
+)
+
+// RunNotificationBackoff returns the amount of time to wait after the given
+// number of failed delivery attempts before trying again.
+func RunNotificationBackoff(attempts int32) time.Duration {
+	backoff := runNotificationInitialBackoff
+	for i := int32(1); i < attempts; i++ {
+		backoff *= 2
+		if backoff >= runNotificationMaxBackoff {
+			return runNotificationMaxBackoff
+		}
+	}
+
+	return backoff
+}
+
+// DeliverRunNotification sends a notification to the tenant notification sink
+// once the run reaches a terminal condition. It reports whether the run status
+// was updated and, if delivery should be retried, how long to wait before the
+// next attempt.
+func DeliverRunNotification(ctx context.Context, rd *RunDeps) (bool, time.Duration) {
+	if rd.WorkflowDeps == nil || rd.WorkflowDeps.TenantDeps == nil {
+		return false, 0
+	}
+
+	sink := rd.WorkflowDeps.TenantDeps.WebhookNotificationSink
+	if sink == nil {
+		return false, 0
+	}
+
+	n, ok := runNotification(rd.Run)
+	if !ok {
+		return false, 0
+	}
+
+	prev := rd.Run.Object.Status.Notification
+	if prev != nil {
+		if prev.State != relayv1beta1.RunNotificationPending {
+			return false, 0
+		}
+
+		if prev.LastAttemptTime != nil {
+			if wait := time.Until(prev.LastAttemptTime.Add(RunNotificationBackoff(prev.Attempts))); wait > 0 {
+				return false, wait
+			}
+		}
+	}
+
+	status := &relayv1beta1.RunNotificationStatus{
+		Attempts:        1,
+		LastAttemptTime: &metav1.Time{Time: time.Now()},
+	}
+	if prev != nil {
+		status.Attempts = prev.Attempts + 1
+	}
+
+	var err error
+	if token, found := sink.Token(); !found && sink.Sink.TokenFrom != nil {
+		err = fmt.Errorf("the notification sink token could not be resolved")
+	} else {
+		attemptCtx, cancel := context.WithTimeout(ctx, RunNotificationAttemptTimeout)
+		defer cancel()
+
+		err = api.NewNotificationManager(sink.URL(), token).Notify(attemptCtx, n)
+	}
+
+	var retry time.Duration
+
+	switch {
+	case err == nil:
+		status.State = relayv1beta1.RunNotificationDelivered
+		status.Message = "The notification was delivered."
+	case status.Attempts >= RunNotificationMaxAttempts:
+		status.State = relayv1beta1.RunNotificationFailed
+		status.Message = fmt.Sprintf("The notification could not be delivered: %v", err)
+	default:
+		status.State = relayv1beta1.RunNotificationPending
+		status.Message = fmt.Sprintf("The notification could not be delivered and will be retried: %v", err)
+		retry = RunNotificationBackoff(status.Attempts)
+	}
+
+	rd.Run.Object.Status.Notification = status
+
+	return true, retry
+}
+
+func runNotification(r *obj.Run) (*model.RunNotification, bool) {
+	if !r.IsCondition(relayv1beta1.RunCompleted, corev1.ConditionTrue) &&
+		!r.IsCondition(relayv1beta1.RunSucceeded, corev1.ConditionTrue) {
+		return nil, false
+	}
+
+	n := &model.RunNotification{
+		DeliveryID:   string(r.Object.GetUID()),
+		Run:          model.Run{ID: r.Key.Name},
+		Namespace:    r.Key.Namespace,
+		WorkflowName: r.Object.Spec.WorkflowRef.Name,
+	}
+
+	switch {
+	case r.IsCondition(relayv1beta1.RunCancelled, corev1.ConditionTrue):
+		n.Outcome = model.RunOutcomeCancelled
+	case r.IsCondition(relayv1beta1.RunSucceeded, corev1.ConditionTrue):
+		n.Outcome = model.RunOutcomeSucceeded
+	default:
+		n.Outcome = model.RunOutcomeFailed
+
+		if cond, ok := findRunCondition(r.Object.Status.Conditions, relayv1beta1.RunSucceeded); ok {
+			n.Message = cond.Message
+		}
+	}
+
+	if t := r.Object.Status.StartTime; t != nil {
+		n.StartTime = &t.Time
+	}
+
+	if t := r.Object.Status.CompletionTime; t != nil {
+		n.CompletionTime = &t.Time
+	}
+
+	for _, ro := range r.Object.Status.Outputs {
+		if ro.Sensitive || ro.Value == nil {
+			continue
+		}
+
+		if n.Outputs == nil {
+			n.Outputs = make(map[string]interface{})
+		}
+
+		n.Outputs[ro.Name] = ro.Value.Value()
+	}
+
+	return n, true
+}
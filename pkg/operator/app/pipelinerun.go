@@ -17,6 +17,10 @@ func ConfigurePipelineRun(ctx context.Context, pr *obj.PipelineRun, pp *Pipeline
 	lifecycle.Label(ctx, pr, model.RelayControllerWorkflowRunIDLabel, pp.Deps.Run.Key.Name)
 	pr.LabelAnnotateFrom(ctx, pp.Deps.Run.Object)
 
+	// Tekton propagates the labels and annotations of the pipeline run to the
+	// pods of each step.
+	ConfigureTenantDefaultMetadata(pp.Deps.WorkflowDeps.Tenant, &pr.Object.ObjectMeta)
+
 	if err := pp.Deps.OwnerConfigMap.Own(ctx, pr); err != nil {
 		return err
 	}
@@ -30,20 +34,38 @@ func ConfigurePipelineRun(ctx context.Context, pr *obj.PipelineRun, pp *Pipeline
 		return err
 	}
 
-	sans := make([]tektonv1beta1.PipelineRunSpecServiceAccountName, len(pp.Pipeline.Object.Spec.Tasks))
-	for i, pt := range pp.Pipeline.Object.Spec.Tasks {
-		sans[i] = tektonv1beta1.PipelineRunSpecServiceAccountName{
-			TaskName: pt.Name,
+	// Pipeline tasks are named by the hash of their step. The service accounts
+	// of the steps were checked against the tenant when the run was validated.
+	serviceAccountNames := make(map[string]string)
+	for _, step := range pp.Deps.Workflow.Object.Spec.Steps {
+		if step.ServiceAccountName != "" {
+			serviceAccountNames[ModelStep(pp.Deps.Run, step).Hash().HexEncoding()] = step.ServiceAccountName
+		}
+	}
+
+	var trs []tektonv1beta1.PipelineTaskRunSpec
+	for _, pt := range pp.Pipeline.Object.Spec.Tasks {
+		if san, found := serviceAccountNames[pt.Name]; found {
+			trs = append(trs, tektonv1beta1.PipelineTaskRunSpec{
+				PipelineTaskName:       pt.Name,
+				TaskServiceAccountName: san,
+			})
 		}
 	}
 
 	pr.Object.Spec = tektonv1beta1.PipelineRunSpec{
-		ServiceAccountNames: sans,
+		TaskRunSpecs: trs,
 		PipelineRef: &tektonv1beta1.PipelineRef{
 			Name: pp.Pipeline.Key.Name,
 		},
 		PodTemplate: &tektonv1beta1.PodTemplate{
 			EnableServiceLinks: pointer.BoolPtr(false),
+			// Also applies to the containers Tekton adds to each pod.
+			SecurityContext: &corev1.PodSecurityContext{
+				SeccompProfile: &corev1.SeccompProfile{
+					Type: corev1.SeccompProfileTypeRuntimeDefault,
+				},
+			},
 		},
 	}
 
@@ -0,0 +1,78 @@
+package app_test
+
+import (
+	"context"
+	"testing"
+
+	corev1obj "github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/api/corev1"
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"github.com/puppetlabs/relay-core/pkg/obj"
+	"github.com/puppetlabs/relay-core/pkg/operator/app"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestConfigurePipelineRunServiceAccounts(t *testing.T) {
+	ctx := context.Background()
+
+	key := client.ObjectKey{Namespace: "tenant", Name: "my-run"}
+
+	run := obj.NewRun(key)
+	run.Object.Name = key.Name
+
+	wf := obj.NewWorkflow(key)
+	wf.Object.Spec.Steps = []*relayv1beta1.Step{
+		{Name: "build"},
+		{Name: "deploy", ServiceAccountName: "deployer"},
+		{Name: "notify"},
+		{Name: "cleanup", ServiceAccountName: "janitor"},
+	}
+
+	owner := corev1obj.NewConfigMap(key)
+	owner.Object.UID = types.UID("a8c1c5d2-8f4e-4f6f-9b53-0e7d1c4e2b11")
+
+	tenant := obj.NewTenant(client.ObjectKey{Namespace: "default", Name: "my-tenant"})
+
+	rd := &app.RunDeps{
+		Run:      run,
+		Workflow: wf,
+		WorkflowDeps: &app.WorkflowDeps{
+			Workflow: wf,
+			Tenant:   tenant,
+			TenantDeps: &app.TenantDeps{
+				Tenant:    tenant,
+				Namespace: corev1obj.NewNamespace(key.Namespace),
+			},
+		},
+		OwnerConfigMap: owner,
+	}
+
+	pp := app.NewPipelineParts(rd)
+
+	// The lookup must not depend on the order of the pipeline tasks.
+	for i := len(wf.Object.Spec.Steps) - 1; i >= 0; i-- {
+		pp.Pipeline.Object.Spec.Tasks = append(pp.Pipeline.Object.Spec.Tasks, tektonv1beta1.PipelineTask{
+			Name: app.ModelStep(run, wf.Object.Spec.Steps[i]).Hash().HexEncoding(),
+		})
+	}
+
+	pr := obj.NewPipelineRun(key)
+	require.NoError(t, app.ConfigurePipelineRun(ctx, pr, pp))
+
+	taskName := func(step string) string {
+		return app.ModelStepFromName(run, step).Hash().HexEncoding()
+	}
+
+	assert.ElementsMatch(t, []tektonv1beta1.PipelineTaskRunSpec{
+		{PipelineTaskName: taskName("deploy"), TaskServiceAccountName: "deployer"},
+		{PipelineTaskName: taskName("cleanup"), TaskServiceAccountName: "janitor"},
+	}, pr.Object.Spec.TaskRunSpecs)
+
+	for _, step := range []string{"build", "notify"} {
+		assert.Empty(t, pr.Object.GetTaskRunSpec(taskName(step)).TaskServiceAccountName)
+	}
+	assert.Equal(t, "deployer", pr.Object.GetTaskRunSpec(taskName("deploy")).TaskServiceAccountName)
+}
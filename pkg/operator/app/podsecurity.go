@@ -0,0 +1,102 @@
+package app
+
+import (
+	"context"
+
+	corev1obj "github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/api/corev1"
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/lifecycle"
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"github.com/puppetlabs/relay-core/pkg/obj"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	PodSecurityEnforceLabel        = "pod-security.kubernetes.io/enforce"
+	PodSecurityEnforceVersionLabel = "pod-security.kubernetes.io/enforce-version"
+	PodSecurityWarnLabel           = "pod-security.kubernetes.io/warn"
+	PodSecurityWarnVersionLabel    = "pod-security.kubernetes.io/warn-version"
+	PodSecurityAuditLabel          = "pod-security.kubernetes.io/audit"
+	PodSecurityAuditVersionLabel   = "pod-security.kubernetes.io/audit-version"
+)
+
+const (
+	PodSecurityVersionLatest = "latest"
+)
+
+var podSecurityLabels = []string{
+	PodSecurityEnforceLabel,
+	PodSecurityEnforceVersionLabel,
+	PodSecurityWarnLabel,
+	PodSecurityWarnVersionLabel,
+	PodSecurityAuditLabel,
+	PodSecurityAuditVersionLabel,
+}
+
+// ConfigureNamespacePodSecurity labels a tenant namespace with the Pod
+// Security Standards level of the tenant. If the tenant does not specify a
+// level, labels previously set by the tenant are removed, except for those
+// given explicitly in its namespace template.
+//
+// The restricted level is only warned about and audited. It is enforced as
+// baseline because the init containers Tekton adds to step pods do not set a
+// security context and would be rejected.
+func ConfigureNamespacePodSecurity(ctx context.Context, ns *corev1obj.Namespace, t *obj.Tenant) {
+	ps := t.Object.Spec.PodSecurity
+	if ps == nil {
+		template := t.Object.Spec.NamespaceTemplate.Metadata.GetLabels()
+		for _, label := range podSecurityLabels {
+			if _, found := template[label]; !found {
+				delete(ns.Object.Labels, label)
+			}
+		}
+
+		return
+	}
+
+	version := ps.Version
+	if version == "" {
+		version = PodSecurityVersionLatest
+	}
+
+	enforce := ps.Level
+	if enforce == relayv1beta1.TenantPodSecurityRestricted {
+		enforce = relayv1beta1.TenantPodSecurityBaseline
+	}
+
+	lifecycle.Label(ctx, ns, PodSecurityEnforceLabel, string(enforce))
+	lifecycle.Label(ctx, ns, PodSecurityEnforceVersionLabel, version)
+	lifecycle.Label(ctx, ns, PodSecurityWarnLabel, string(ps.Level))
+	lifecycle.Label(ctx, ns, PodSecurityWarnVersionLabel, version)
+	lifecycle.Label(ctx, ns, PodSecurityAuditLabel, string(ps.Level))
+	lifecycle.Label(ctx, ns, PodSecurityAuditVersionLabel, version)
+}
+
+// RestrictedSecurityContext returns a container security context that meets
+// the restricted Pod Security Standards profile, except that the container may
+// run as root. Whether it does is left to its image, which is not inspected
+// here so that rendering a step does not depend on the registry.
+func RestrictedSecurityContext() *corev1.SecurityContext {
+	return &corev1.SecurityContext{
+		AllowPrivilegeEscalation: pointer.BoolPtr(false),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+}
+
+// KnativeSecurityContext returns the parts of RestrictedSecurityContext that
+// Knative accepts for a service container.
+//
+// Knative does not allow a service to set a seccomp profile or to disallow
+// privilege escalation, so triggers are exempt from the restricted profile and
+// only meet baseline.
+func KnativeSecurityContext() *corev1.SecurityContext {
+	sc := RestrictedSecurityContext()
+	sc.AllowPrivilegeEscalation = nil
+	sc.SeccompProfile = nil
+	return sc
+}
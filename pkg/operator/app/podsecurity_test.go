@@ -0,0 +1,85 @@
+package app_test
+
+import (
+	"context"
+	"testing"
+
+	corev1obj "github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/api/corev1"
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"github.com/puppetlabs/relay-core/pkg/obj"
+	"github.com/puppetlabs/relay-core/pkg/operator/app"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/serving/pkg/apis/serving"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestConfigureNamespacePodSecurity(t *testing.T) {
+	ctx := context.Background()
+
+	tn := obj.NewTenant(client.ObjectKey{Namespace: "default", Name: "my-tenant"})
+	tn.Object.Spec.PodSecurity = &relayv1beta1.TenantPodSecurity{
+		Level: relayv1beta1.TenantPodSecurityRestricted,
+	}
+
+	ns := corev1obj.NewNamespace("tenant")
+	app.ConfigureNamespacePodSecurity(ctx, ns, tn)
+	assert.Equal(t, map[string]string{
+		app.PodSecurityEnforceLabel:        "baseline",
+		app.PodSecurityEnforceVersionLabel: "latest",
+		app.PodSecurityWarnLabel:           "restricted",
+		app.PodSecurityWarnVersionLabel:    "latest",
+		app.PodSecurityAuditLabel:          "restricted",
+		app.PodSecurityAuditVersionLabel:   "latest",
+	}, ns.Object.GetLabels())
+
+	// Lower levels are enforced as given.
+	tn.Object.Spec.PodSecurity = &relayv1beta1.TenantPodSecurity{
+		Level:   relayv1beta1.TenantPodSecurityBaseline,
+		Version: "v1.23",
+	}
+
+	app.ConfigureNamespacePodSecurity(ctx, ns, tn)
+	assert.Equal(t, map[string]string{
+		app.PodSecurityEnforceLabel:        "baseline",
+		app.PodSecurityEnforceVersionLabel: "v1.23",
+		app.PodSecurityWarnLabel:           "baseline",
+		app.PodSecurityWarnVersionLabel:    "v1.23",
+		app.PodSecurityAuditLabel:          "baseline",
+		app.PodSecurityAuditVersionLabel:   "v1.23",
+	}, ns.Object.GetLabels())
+
+	// Removing the level removes the labels unless the namespace template
+	// sets them.
+	tn.Object.Spec.PodSecurity = nil
+	tn.Object.Spec.NamespaceTemplate.Metadata.Labels = map[string]string{
+		app.PodSecurityWarnLabel: "baseline",
+	}
+
+	app.ConfigureNamespacePodSecurity(ctx, ns, tn)
+	assert.Equal(t, map[string]string{
+		app.PodSecurityWarnLabel: "baseline",
+	}, ns.Object.GetLabels())
+}
+
+func TestKnativeSecurityContext(t *testing.T) {
+	ctx := context.Background()
+
+	sc := app.KnativeSecurityContext()
+
+	// Knative must accept the entire security context.
+	assert.Equal(t, sc, serving.SecurityContextMask(ctx, sc.DeepCopy()))
+
+	// It meets the baseline profile...
+	assert.Nil(t, sc.Privileged)
+	assert.Nil(t, sc.SELinuxOptions)
+	assert.Nil(t, sc.ProcMount)
+	if assert.NotNil(t, sc.Capabilities) {
+		assert.Empty(t, sc.Capabilities.Add)
+		assert.Equal(t, []corev1.Capability{"ALL"}, sc.Capabilities.Drop)
+	}
+
+	// ...but not the restricted profile, which triggers are exempt from.
+	assert.Nil(t, sc.AllowPrivilegeEscalation)
+	assert.Nil(t, sc.SeccompProfile)
+}
@@ -88,3 +88,43 @@ func NewAPIWorkflowExecutionSink(namespace string, sink *relayv1beta1.APIWorkflo
 
 	return a
 }
+
+type WebhookNotificationSink struct {
+	Sink        *relayv1beta1.WebhookNotificationSink
+	TokenSecret *corev1obj.OpaqueSecret
+}
+
+var _ lifecycle.Loader = &WebhookNotificationSink{}
+
+func (ns *WebhookNotificationSink) Load(ctx context.Context, cl client.Client) (bool, error) {
+	return lifecycle.IgnoreNilLoader{Loader: ns.TokenSecret}.Load(ctx, cl)
+}
+
+func (ns *WebhookNotificationSink) URL() string {
+	return ns.Sink.URL
+}
+
+func (ns *WebhookNotificationSink) Token() (string, bool) {
+	if ns.Sink.Token != "" {
+		return ns.Sink.Token, true
+	} else if ns.TokenSecret != nil {
+		return ns.TokenSecret.Data(ns.Sink.TokenFrom.SecretKeyRef.Key)
+	}
+
+	return "", false
+}
+
+func NewWebhookNotificationSink(namespace string, sink *relayv1beta1.WebhookNotificationSink) *WebhookNotificationSink {
+	ns := &WebhookNotificationSink{
+		Sink: sink,
+	}
+
+	if sink.TokenFrom != nil && sink.TokenFrom.SecretKeyRef != nil {
+		ns.TokenSecret = corev1obj.NewOpaqueSecret(client.ObjectKey{
+			Namespace: namespace,
+			Name:      sink.TokenFrom.SecretKeyRef.Name,
+		})
+	}
+
+	return ns
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"github.com/puppetlabs/relay-core/pkg/obj"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ConfigureResourceQuota sets the hard limits of a resource quota from a
+// tenant quota. CPU, memory, and ephemeral storage are enforced against the
+// container limits, which the tenant limit range always provides.
+func ConfigureResourceQuota(rq *obj.ResourceQuota, quota *relayv1beta1.TenantQuota) {
+	hard := make(corev1.ResourceList)
+
+	if quota.CPU != nil {
+		hard[corev1.ResourceLimitsCPU] = quota.CPU.DeepCopy()
+	}
+
+	if quota.Memory != nil {
+		hard[corev1.ResourceLimitsMemory] = quota.Memory.DeepCopy()
+	}
+
+	if quota.EphemeralStorage != nil {
+		hard[corev1.ResourceLimitsEphemeralStorage] = quota.EphemeralStorage.DeepCopy()
+	}
+
+	if quota.Pods != nil {
+		hard[corev1.ResourcePods] = *resource.NewQuantity(int64(*quota.Pods), resource.DecimalSI)
+	}
+
+	rq.Object.Spec = corev1.ResourceQuotaSpec{
+		Hard: hard,
+	}
+}
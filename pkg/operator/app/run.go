@@ -2,13 +2,19 @@ package app
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/puppetlabs/leg/relspec/pkg/evaluate"
 	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
 	"github.com/puppetlabs/relay-core/pkg/manager/configmap"
+	"github.com/puppetlabs/relay-core/pkg/manager/specadapter"
 	"github.com/puppetlabs/relay-core/pkg/model"
 	"github.com/puppetlabs/relay-core/pkg/obj"
 	"github.com/puppetlabs/relay-core/pkg/operator/handler/condition"
+	"github.com/puppetlabs/relay-core/pkg/spec"
 	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -17,6 +23,69 @@ import (
 func ConfigureRun(ctx context.Context, rd *RunDeps, pr *obj.PipelineRun) {
 	ConfigureRunStepStatus(ctx, rd, pr)
 	ConfigureRunStatus(ctx, rd)
+	ConfigureRunOutputs(ctx, rd)
+}
+
+// ConfigureRunOutputs evaluates the outputs declared by the workflow once the
+// run has completed. Outputs are only computed once; subsequent calls leave
+// the existing status untouched.
+func ConfigureRunOutputs(ctx context.Context, rd *RunDeps) {
+	outputs := rd.Workflow.Object.Spec.Outputs
+	if len(outputs) == 0 ||
+		rd.Run.Object.Status.Outputs != nil ||
+		!rd.Run.IsCondition(relayv1beta1.RunCompleted, corev1.ConditionTrue) {
+		return
+	}
+
+	om := configmap.NewStepOutputManager(ModelStepFromName(rd.Run, ""), configmap.NewLocalConfigMap(rd.MutableConfigMap.Object))
+	ev := spec.NewEvaluator(
+		spec.WithOutputTypeResolver{OutputTypeResolver: specadapter.NewOutputTypeResolver(om)},
+	)
+
+	names := make([]string, 0, len(outputs))
+	for name := range outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ros := make([]*relayv1beta1.RunOutput, 0, len(names))
+	for _, name := range names {
+		ro := &relayv1beta1.RunOutput{Name: name}
+
+		r, err := evaluate.EvaluateAll(ctx, ev, outputs[name].Value())
+		if err != nil {
+			ro.Message = fmt.Sprintf("The output could not be evaluated: %v", err)
+			ros = append(ros, ro)
+			continue
+		}
+
+		if r.References != nil {
+			for _, ref := range r.References.Outputs.OKReferences() {
+				so, err := om.Get(ctx, ref.ID().From, ref.ID().Name)
+				if err != nil || (so.Metadata != nil && so.Metadata.Sensitive) {
+					ro.Sensitive = true
+					break
+				}
+			}
+		}
+
+		switch {
+		case r.References != nil && !r.References.OK():
+			var causes []string
+			for _, cause := range r.References.ToError().Causes {
+				causes = append(causes, cause.Error())
+			}
+
+			ro.Message = fmt.Sprintf("The output could not be fully resolved: %s", strings.Join(causes, "; "))
+		case !ro.Sensitive:
+			value := relayv1beta1.AsUnstructured(r.Value)
+			ro.Value = &value
+		}
+
+		ros = append(ros, ro)
+	}
+
+	rd.Run.Object.Status.Outputs = ros
 }
 
 func ConfigureRunStatus(ctx context.Context, rd *RunDeps) {
@@ -172,6 +241,22 @@ func ConfigureStepStatus(ctx context.Context, rd *RunDeps, stepName string, acti
 		}
 	}
 
+	step.Artifacts = make([]*relayv1beta1.StepArtifact, 0)
+	if artifacts, err := configmap.NewArtifactManager(action, configMap, nil).ListSelf(ctx); err == nil {
+		for _, artifact := range artifacts {
+			step.Artifacts = append(step.Artifacts, &relayv1beta1.StepArtifact{
+				Name:        artifact.Name,
+				ContentType: artifact.ContentType,
+				Size:        artifact.Size,
+				Digest:      artifact.Digest,
+			})
+		}
+
+		sort.Slice(step.Artifacts, func(i, j int) bool {
+			return step.Artifacts[i].Name < step.Artifacts[j].Name
+		})
+	}
+
 	decs := []*relayv1beta1.Decorator{}
 	if sdecs, err := configmap.NewStepDecoratorManager(action, configMap).List(ctx); err == nil {
 		for _, sdec := range sdecs {
@@ -254,6 +339,42 @@ func ConfigureRunWithSpecificStatus(r *obj.Run, rc relayv1beta1.RunConditionType
 	}
 }
 
+// ConfigureRunWithValidationError marks a run as completed and failed because
+// its workflow could not be validated.
+func ConfigureRunWithValidationError(r *obj.Run, err error) {
+	now := metav1.Now()
+
+	if r.Object.Status.StartTime == nil {
+		r.Object.Status.StartTime = &now
+	}
+
+	if r.Object.Status.CompletionTime == nil {
+		r.Object.Status.CompletionTime = &now
+	}
+
+	r.Object.Status.ObservedGeneration = r.Object.GetGeneration()
+	r.Object.Status.Conditions = []relayv1beta1.RunCondition{
+		{
+			Condition: relayv1beta1.Condition{
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: now,
+				Reason:             obj.RunStatusReasonValidationFailed,
+				Message:            err.Error(),
+			},
+			Type: relayv1beta1.RunCompleted,
+		},
+		{
+			Condition: relayv1beta1.Condition{
+				Status:             corev1.ConditionFalse,
+				LastTransitionTime: now,
+				Reason:             obj.RunStatusReasonValidationFailed,
+				Message:            err.Error(),
+			},
+			Type: relayv1beta1.RunSucceeded,
+		},
+	}
+}
+
 func isConditionEmpty(cond *relayv1beta1.Condition) bool {
 	return cond == nil ||
 		(cond.Status == corev1.ConditionUnknown &&
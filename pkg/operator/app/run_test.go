@@ -0,0 +1,139 @@
+package app_test
+
+import (
+	"context"
+	"testing"
+
+	corev1obj "github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/api/corev1"
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"github.com/puppetlabs/relay-core/pkg/manager/configmap"
+	"github.com/puppetlabs/relay-core/pkg/model"
+	"github.com/puppetlabs/relay-core/pkg/obj"
+	"github.com/puppetlabs/relay-core/pkg/operator/app"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestConfigureRunOutputs(t *testing.T) {
+	ctx := context.Background()
+
+	outputRef := func(from, name string) interface{} {
+		return map[string]interface{}{
+			"$type": "Output",
+			"from":  from,
+			"name":  name,
+		}
+	}
+
+	newRunDeps := func(t *testing.T, outputs map[string]interface{}, completed bool) *app.RunDeps {
+		key := client.ObjectKey{Namespace: "default", Name: "my-run"}
+
+		run := obj.NewRun(key)
+		run.Object.Name = key.Name
+		if completed {
+			run.Object.Status.Conditions = []relayv1beta1.RunCondition{
+				{
+					Condition: relayv1beta1.Condition{Status: corev1.ConditionTrue},
+					Type:      relayv1beta1.RunCompleted,
+				},
+			}
+		}
+
+		wf := obj.NewWorkflow(key)
+		wf.Object.Spec.Outputs = relayv1beta1.NewUnstructuredObject(outputs)
+
+		mutable := corev1obj.NewConfigMap(key)
+
+		rd := &app.RunDeps{
+			Run:              run,
+			Workflow:         wf,
+			MutableConfigMap: mutable,
+		}
+
+		cm := configmap.NewLocalConfigMap(mutable.Object)
+
+		build := configmap.NewStepOutputManager(app.ModelStepFromName(run, "build"), cm)
+		require.NoError(t, build.Set(ctx, "url", "https://example.com"))
+
+		creds := configmap.NewStepOutputManager(app.ModelStepFromName(run, "creds"), cm)
+		require.NoError(t, creds.Set(ctx, "password", "hunter2"))
+		require.NoError(t, creds.SetMetadata(ctx, "password", &model.StepOutputMetadata{Sensitive: true}))
+
+		return rd
+	}
+
+	t.Run("Resolved", func(t *testing.T) {
+		rd := newRunDeps(t, map[string]interface{}{
+			"url":     outputRef("build", "url"),
+			"message": "Deployed to ${outputs.build.url}",
+		}, true)
+
+		app.ConfigureRunOutputs(ctx, rd)
+
+		outputs := rd.Run.Object.Status.Outputs
+		require.Len(t, outputs, 2)
+
+		assert.Equal(t, "message", outputs[0].Name)
+		require.NotNil(t, outputs[0].Value)
+		assert.Equal(t, "Deployed to https://example.com", outputs[0].Value.Value())
+		assert.False(t, outputs[0].Sensitive)
+		assert.Empty(t, outputs[0].Message)
+
+		assert.Equal(t, "url", outputs[1].Name)
+		require.NotNil(t, outputs[1].Value)
+		assert.Equal(t, "https://example.com", outputs[1].Value.Value())
+	})
+
+	t.Run("Sensitive", func(t *testing.T) {
+		rd := newRunDeps(t, map[string]interface{}{
+			"login": []interface{}{outputRef("build", "url"), outputRef("creds", "password")},
+		}, true)
+
+		app.ConfigureRunOutputs(ctx, rd)
+
+		outputs := rd.Run.Object.Status.Outputs
+		require.Len(t, outputs, 1)
+		assert.True(t, outputs[0].Sensitive)
+		assert.Nil(t, outputs[0].Value)
+	})
+
+	t.Run("Unresolved", func(t *testing.T) {
+		rd := newRunDeps(t, map[string]interface{}{
+			"missing": outputRef("build", "nope"),
+		}, true)
+
+		app.ConfigureRunOutputs(ctx, rd)
+
+		outputs := rd.Run.Object.Status.Outputs
+		require.Len(t, outputs, 1)
+		assert.False(t, outputs[0].Sensitive)
+		assert.Nil(t, outputs[0].Value)
+		assert.Contains(t, outputs[0].Message, "could not be fully resolved")
+		assert.Contains(t, outputs[0].Message, "nope")
+	})
+
+	t.Run("EvaluationError", func(t *testing.T) {
+		rd := newRunDeps(t, map[string]interface{}{
+			"broken": map[string]interface{}{"$type": "Output"},
+		}, true)
+
+		app.ConfigureRunOutputs(ctx, rd)
+
+		outputs := rd.Run.Object.Status.Outputs
+		require.Len(t, outputs, 1)
+		assert.Nil(t, outputs[0].Value)
+		assert.Contains(t, outputs[0].Message, "could not be evaluated")
+	})
+
+	t.Run("NotCompleted", func(t *testing.T) {
+		rd := newRunDeps(t, map[string]interface{}{
+			"url": outputRef("build", "url"),
+		}, false)
+
+		app.ConfigureRunOutputs(ctx, rd)
+
+		assert.Nil(t, rd.Run.Object.Status.Outputs)
+	})
+}
@@ -34,9 +34,10 @@ type RunDeps struct {
 	Workflow     *obj.Workflow
 	WorkflowDeps *WorkflowDeps
 
-	Environment       string
-	RuntimeToolsImage string
-	Standalone        bool
+	Environment          string
+	RuntimeToolsImage    string
+	Standalone           bool
+	NetworkPolicyOptions []NetworkPolicyOption
 
 	Issuer authenticate.Issuer
 
@@ -236,6 +237,8 @@ func (rd *RunDeps) AnnotateStepToken(ctx context.Context, target *metav1.ObjectM
 		RelayKubernetesImmutableConfigMapName: rd.ImmutableConfigMap.Key.Name,
 		RelayKubernetesMutableConfigMapName:   rd.MutableConfigMap.Key.Name,
 
+		RelayArtifactKeyPrefix: RunArtifactKeyPrefix(rd.Run),
+
 		RelayVaultEnginePath:     annotations[model.RelayVaultEngineMountAnnotation],
 		RelayVaultSecretPath:     annotations[model.RelayVaultSecretPathAnnotation],
 		RelayVaultConnectionPath: annotations[model.RelayVaultConnectionPathAnnotation],
@@ -274,6 +277,14 @@ func RunDepsWithRuntimeToolsImage(image string) RunDepsOption {
 	}
 }
 
+// RunDepsWithNetworkPolicyOptions sets options for the network policy of the
+// run before the options of its tenant are applied.
+func RunDepsWithNetworkPolicyOptions(opts ...NetworkPolicyOption) RunDepsOption {
+	return func(rd *RunDeps) {
+		rd.NetworkPolicyOptions = append(rd.NetworkPolicyOptions, opts...)
+	}
+}
+
 func RunDepsWithStandaloneMode(standalone bool) RunDepsOption {
 	return func(rd *RunDeps) {
 		if standalone {
@@ -328,7 +339,8 @@ func ConfigureRunDeps(ctx context.Context, rd *RunDeps) error {
 	if rd.Standalone {
 		rd.NetworkPolicy.AllowAll()
 	} else {
-		ConfigureNetworkPolicyForRun(rd.NetworkPolicy, rd.Run)
+		opts := append(append([]NetworkPolicyOption{}, rd.NetworkPolicyOptions...), NetworkPolicyOptionsForTenant(rd.WorkflowDeps.Tenant)...)
+		ConfigureNetworkPolicyForRun(rd.NetworkPolicy, rd.Run, opts...)
 	}
 
 	if err := ConfigureImmutableConfigMapForRun(ctx, rd.ImmutableConfigMap, rd); err != nil {
@@ -17,6 +17,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// DefaultStepEphemeralStorage is the ephemeral storage given to steps when the
+// tenant limit range does not say how much a container may use.
+var DefaultStepEphemeralStorage = resource.MustParse("20Gi")
+
 func ConfigureTask(ctx context.Context, t *obj.Task, rd *RunDeps, ws *relayv1beta1.Step) error {
 	image := ws.Image
 	command := ws.Command
@@ -73,6 +77,8 @@ func ConfigureTask(ctx context.Context, t *obj.Task, rd *RunDeps, ws *relayv1bet
 		Command:    []string{model.ToolsSource},
 		Args:       []string{model.ToolsCommandInitialize},
 		Env:        envVars,
+
+		SecurityContext: RestrictedSecurityContext(),
 	}
 
 	container := corev1.Container{
@@ -80,18 +86,22 @@ func ConfigureTask(ctx context.Context, t *obj.Task, rd *RunDeps, ws *relayv1bet
 		Image:           image,
 		ImagePullPolicy: corev1.PullAlways,
 		Env:             envVars,
-		SecurityContext: &corev1.SecurityContext{
-			AllowPrivilegeEscalation: func(b bool) *bool { return &b }(false),
-		},
+		SecurityContext: RestrictedSecurityContext(),
 	}
 
-	if rd.WorkflowDeps.TenantDeps.LimitRange != nil {
+	if lr := rd.WorkflowDeps.TenantDeps.LimitRange; lr != nil {
+		// Give steps as much ephemeral storage as the tenant allows.
+		storage, found := LimitRangeContainerMax(lr)[corev1.ResourceEphemeralStorage]
+		if !found {
+			storage = DefaultStepEphemeralStorage
+		}
+
 		container.Resources = corev1.ResourceRequirements{
 			Requests: corev1.ResourceList{
-				corev1.ResourceEphemeralStorage: resource.MustParse("20Gi"),
+				corev1.ResourceEphemeralStorage: storage.DeepCopy(),
 			},
 			Limits: corev1.ResourceList{
-				corev1.ResourceEphemeralStorage: resource.MustParse("20Gi"),
+				corev1.ResourceEphemeralStorage: storage.DeepCopy(),
 			},
 		}
 	}
@@ -130,7 +140,7 @@ func ConfigureTask(ctx context.Context, t *obj.Task, rd *RunDeps, ws *relayv1bet
 
 	ep, err := entrypoint.ImageEntrypoint(image, []string{command}, args)
 	if err != nil {
-		return err
+		return &ImageResolutionError{Image: image, Cause: err}
 	}
 
 	t.SetWorkspace(tektonv1beta1.WorkspaceDeclaration{
@@ -1,12 +1,15 @@
 package app
 
 import (
+	"fmt"
+
 	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
 	"github.com/puppetlabs/relay-core/pkg/obj"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func ConfigureTenant(t *obj.Tenant, td *TenantDepsResult) {
+func ConfigureTenant(t *obj.Tenant, td *TenantDepsResult, tw *TenantWorkloads) {
 	// Set up our initial map from the existing data.
 	conds := map[relayv1beta1.TenantConditionType]*relayv1beta1.Condition{
 		relayv1beta1.TenantNamespaceReady: {},
@@ -28,6 +31,12 @@ func ConfigureTenant(t *obj.Tenant, td *TenantDepsResult) {
 				Reason:  obj.TenantStatusReasonNamespaceError,
 				Message: td.Error.Error(),
 			}
+		} else if td.TenantDeps != nil && td.TenantDeps.LimitRangeError != nil {
+			return relayv1beta1.Condition{
+				Status:  corev1.ConditionFalse,
+				Reason:  obj.TenantStatusReasonLimitRangeInvalid,
+				Message: fmt.Sprintf("The limit range is invalid, so the built-in limits apply: %v.", td.TenantDeps.LimitRangeError),
+			}
 		} else if td.TenantDeps != nil {
 			return relayv1beta1.Condition{
 				Status:  corev1.ConditionTrue,
@@ -43,6 +52,14 @@ func ConfigureTenant(t *obj.Tenant, td *TenantDepsResult) {
 
 	UpdateStatusConditionIfTransitioned(conds[relayv1beta1.TenantEventSinkReady], func() relayv1beta1.Condition {
 		if td.TenantDeps != nil {
+			if tes := t.Object.Spec.TriggerEventSink; tes.API != nil && tes.WorkflowRef != nil {
+				return relayv1beta1.Condition{
+					Status:  corev1.ConditionFalse,
+					Reason:  obj.TenantStatusReasonEventSinkNotConfigured,
+					Message: "The trigger event sink may specify either an API or a workflow reference, but not both.",
+				}
+			}
+
 			if sink := td.TenantDeps.APITriggerEventSink; sink != nil {
 				if sink.URL() == "" {
 					return relayv1beta1.Condition{
@@ -65,6 +82,14 @@ func ConfigureTenant(t *obj.Tenant, td *TenantDepsResult) {
 				}
 			}
 
+			if t.Object.Spec.TriggerEventSink.WorkflowRef != nil {
+				return relayv1beta1.Condition{
+					Status:  corev1.ConditionTrue,
+					Reason:  obj.TenantStatusReasonEventSinkReady,
+					Message: "The event sink is ready.",
+				}
+			}
+
 			// This shouldn't block people who want to use these APIs without
 			// WebhookTriggers.
 			return relayv1beta1.Condition{
@@ -120,5 +145,69 @@ func ConfigureTenant(t *obj.Tenant, td *TenantDepsResult) {
 	if td.TenantDeps != nil {
 		t.Object.Status.ObservedGeneration = t.Object.GetGeneration()
 		t.Object.Status.Namespace = td.TenantDeps.Namespace.Name
+
+		if rq := td.TenantDeps.ResourceQuota; rq != nil && t.Object.Spec.Quota != nil && rq.Object.GetUID() != "" {
+			t.Object.Status.Quota = &relayv1beta1.TenantQuotaStatus{
+				Hard: rq.Object.Status.Hard,
+				Used: rq.Object.Status.Used,
+			}
+		}
+
+		t.Object.Status.Sinks = TenantSinkStatuses(t, td.TenantDeps, tw)
+	}
+
+	if tw != nil {
+		t.Object.Status.Runs = TenantRunCounts(tw)
+		t.Object.Status.Triggers = TenantTriggerCounts(tw)
+	}
+}
+
+// TenantEnvironment returns the environment of a step or trigger container
+// layered over the default environment of the tenant.
+func TenantEnvironment(t *obj.Tenant, env relayv1beta1.UnstructuredObject) map[string]interface{} {
+	vars := make(map[string]interface{})
+
+	if d := t.Object.Spec.Defaults; d != nil {
+		for name, value := range d.Env.Value() {
+			vars[name] = value
+		}
+	}
+
+	for name, value := range env.Value() {
+		vars[name] = value
+	}
+
+	return vars
+}
+
+// ConfigureTenantDefaultMetadata adds the default labels and annotations of
+// the tenant to the given metadata. Existing labels and annotations are not
+// changed.
+func ConfigureTenantDefaultMetadata(t *obj.Tenant, target *metav1.ObjectMeta) {
+	d := t.Object.Spec.Defaults
+	if d == nil {
+		return
+	}
+
+	for name, value := range d.Labels {
+		if _, found := target.Labels[name]; found {
+			continue
+		}
+
+		if target.Labels == nil {
+			target.Labels = make(map[string]string)
+		}
+		target.Labels[name] = value
+	}
+
+	for name, value := range d.Annotations {
+		if _, found := target.Annotations[name]; found {
+			continue
+		}
+
+		if target.Annotations == nil {
+			target.Annotations = make(map[string]string)
+		}
+		target.Annotations[name] = value
 	}
 }
@@ -0,0 +1,427 @@
+package app_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"github.com/puppetlabs/relay-core/pkg/obj"
+	"github.com/puppetlabs/relay-core/pkg/operator/app"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConfigureTenantEventSink(t *testing.T) {
+	api := &relayv1beta1.APITriggerEventSink{
+		URL:   "https://example.com/events",
+		Token: "hunter2",
+	}
+	workflowRef := &corev1.LocalObjectReference{Name: "my-workflow"}
+
+	tcs := []struct {
+		Name           string
+		Sink           relayv1beta1.TriggerEventSink
+		ExpectedStatus corev1.ConditionStatus
+		ExpectedReason string
+	}{
+		{
+			Name:           "None",
+			ExpectedStatus: corev1.ConditionTrue,
+			ExpectedReason: obj.TenantStatusReasonEventSinkMissing,
+		},
+		{
+			Name:           "API",
+			Sink:           relayv1beta1.TriggerEventSink{API: api},
+			ExpectedStatus: corev1.ConditionTrue,
+			ExpectedReason: obj.TenantStatusReasonEventSinkReady,
+		},
+		{
+			Name:           "WorkflowRef",
+			Sink:           relayv1beta1.TriggerEventSink{WorkflowRef: workflowRef},
+			ExpectedStatus: corev1.ConditionTrue,
+			ExpectedReason: obj.TenantStatusReasonEventSinkReady,
+		},
+		{
+			Name:           "APIAndWorkflowRef",
+			Sink:           relayv1beta1.TriggerEventSink{API: api, WorkflowRef: workflowRef},
+			ExpectedStatus: corev1.ConditionFalse,
+			ExpectedReason: obj.TenantStatusReasonEventSinkNotConfigured,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Name, func(t *testing.T) {
+			tn := obj.NewTenant(client.ObjectKey{Namespace: "default", Name: "my-tenant"})
+			tn.Object.Spec.TriggerEventSink = tc.Sink
+
+			td := app.NewTenantDeps(tn)
+
+			app.ConfigureTenant(tn, app.AsTenantDepsResult(td, nil), nil)
+
+			var found bool
+			for _, cond := range tn.Object.Status.Conditions {
+				if cond.Type != relayv1beta1.TenantEventSinkReady {
+					continue
+				}
+
+				found = true
+				assert.Equal(t, tc.ExpectedStatus, cond.Status)
+				assert.Equal(t, tc.ExpectedReason, cond.Reason)
+			}
+			require.True(t, found)
+		})
+	}
+}
+
+func TestApplyTenantDepsQuota(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, networkingv1.AddToScheme(scheme))
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	tn := obj.NewTenant(client.ObjectKey{Namespace: "default", Name: "my-tenant"})
+	tn.Object.UID = types.UID("my-tenant")
+	tn.Object.Spec.NamespaceTemplate.Metadata.Name = "tenant"
+	tn.Object.Spec.LimitRange = &relayv1beta1.TenantLimitRange{
+		Max: corev1.ResourceList{
+			corev1.ResourceEphemeralStorage: resource.MustParse("5Gi"),
+		},
+	}
+	tn.Object.Spec.Quota = &relayv1beta1.TenantQuota{
+		CPU:  resource.NewMilliQuantity(4000, resource.DecimalSI),
+		Pods: pointer.Int32Ptr(10),
+	}
+
+	td, err := app.ApplyTenantDeps(ctx, cl, tn)
+	require.NoError(t, err)
+
+	// Resources the tenant does not override keep their defaults.
+	max := app.LimitRangeContainerMax(td.LimitRange)
+	assert.Equal(t, "5Gi", max.StorageEphemeral().String())
+	assert.Equal(t, "3Gi", max.Memory().String())
+
+	rq := &corev1.ResourceQuota{}
+	require.NoError(t, cl.Get(ctx, client.ObjectKey{Namespace: "tenant", Name: "my-tenant"}, rq))
+	assert.Equal(t, corev1.ResourceList{
+		corev1.ResourceLimitsCPU: resource.MustParse("4"),
+		corev1.ResourcePods:      resource.MustParse("10"),
+	}, rq.Spec.Hard)
+
+	// The fake client does not assign UIDs, which the dependencies use to tell
+	// whether they exist, or run the quota controller.
+	for _, o := range []client.Object{&corev1.Namespace{}, &networkingv1.NetworkPolicy{}, &corev1.LimitRange{}} {
+		key := client.ObjectKey{Namespace: "tenant", Name: "my-tenant"}
+		if _, ok := o.(*corev1.Namespace); ok {
+			key = client.ObjectKey{Name: "tenant"}
+		}
+
+		require.NoError(t, cl.Get(ctx, key, o))
+		o.SetUID(types.UID(key.String()))
+		require.NoError(t, cl.Update(ctx, o))
+	}
+
+	rq.UID = types.UID("my-tenant-quota")
+	rq.Status.Hard = rq.Spec.Hard
+	rq.Status.Used = corev1.ResourceList{corev1.ResourcePods: resource.MustParse("2")}
+	require.NoError(t, cl.Update(ctx, rq))
+
+	td = app.NewTenantDeps(tn)
+	_, err = td.Load(ctx, cl)
+	require.NoError(t, err)
+
+	app.ConfigureTenant(tn, app.AsTenantDepsResult(td, nil), nil)
+	require.NotNil(t, tn.Object.Status.Quota)
+	assert.Equal(t, rq.Status.Used, tn.Object.Status.Quota.Used)
+
+	// Removing the quota from the tenant deletes it.
+	tn.Object.Spec.Quota = nil
+
+	_, err = app.ApplyTenantDeps(ctx, cl, tn)
+	require.NoError(t, err)
+
+	var rqs corev1.ResourceQuotaList
+	require.NoError(t, cl.List(ctx, &rqs, client.InNamespace("tenant")))
+	assert.Empty(t, rqs.Items)
+}
+
+func TestConfigureTenantDepsLimitRangeValidation(t *testing.T) {
+	ctx := context.Background()
+
+	tcs := []struct {
+		Name            string
+		LimitRange      *relayv1beta1.TenantLimitRange
+		ExpectedStatus  corev1.ConditionStatus
+		ExpectedReason  string
+		ExpectedMessage string
+		ExpectedMaxCPU  string
+	}{
+		{
+			Name: "Valid",
+			LimitRange: &relayv1beta1.TenantLimitRange{
+				Default: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				Max:     corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+			},
+			ExpectedStatus: corev1.ConditionTrue,
+			ExpectedReason: obj.TenantStatusReasonNamespaceReady,
+			ExpectedMaxCPU: "4",
+		},
+		{
+			Name: "DefaultExceedsBuiltInMax",
+			LimitRange: &relayv1beta1.TenantLimitRange{
+				Default: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			},
+			ExpectedStatus:  corev1.ConditionFalse,
+			ExpectedReason:  obj.TenantStatusReasonLimitRangeInvalid,
+			ExpectedMessage: "default limit cpu 2 exceeds maximum 1",
+			ExpectedMaxCPU:  "1",
+		},
+		{
+			Name: "DefaultRequestExceedsDefault",
+			LimitRange: &relayv1beta1.TenantLimitRange{
+				DefaultRequest: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+				Max:            corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+			},
+			ExpectedStatus:  corev1.ConditionFalse,
+			ExpectedReason:  obj.TenantStatusReasonLimitRangeInvalid,
+			ExpectedMessage: "default request memory 4Gi exceeds default limit 2Gi; default request memory 4Gi exceeds maximum 3Gi",
+			ExpectedMaxCPU:  "1",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Name, func(t *testing.T) {
+			tn := obj.NewTenant(client.ObjectKey{Namespace: "default", Name: "my-tenant"})
+			tn.Object.Spec.NamespaceTemplate.Metadata.Name = "tenant"
+			tn.Object.Spec.LimitRange = tc.LimitRange
+
+			td := app.NewTenantDeps(tn)
+			require.NoError(t, app.ConfigureTenantDeps(ctx, td))
+			require.NoError(t, app.ValidateLimitRange(td.LimitRange))
+
+			maxCPU := app.LimitRangeContainerMax(td.LimitRange)[corev1.ResourceCPU]
+			assert.Equal(t, tc.ExpectedMaxCPU, maxCPU.String())
+
+			app.ConfigureTenant(tn, app.AsTenantDepsResult(td, nil), nil)
+
+			var found bool
+			for _, cond := range tn.Object.Status.Conditions {
+				if cond.Type != relayv1beta1.TenantNamespaceReady {
+					continue
+				}
+
+				found = true
+				assert.Equal(t, tc.ExpectedStatus, cond.Status)
+				assert.Equal(t, tc.ExpectedReason, cond.Reason)
+				assert.Contains(t, cond.Message, tc.ExpectedMessage)
+			}
+			require.True(t, found)
+		})
+	}
+}
+
+func TestTenantEnvironment(t *testing.T) {
+	tn := obj.NewTenant(client.ObjectKey{Namespace: "default", Name: "my-tenant"})
+	assert.Equal(t, map[string]interface{}{}, app.TenantEnvironment(tn, nil))
+
+	tn.Object.Spec.Defaults = &relayv1beta1.TenantDefaults{
+		Env: relayv1beta1.NewUnstructuredObject(map[string]interface{}{
+			"HTTPS_PROXY": "http://proxy.example.com:3128",
+			"TOKEN":       map[string]interface{}{"$type": "Secret", "name": "default-token"},
+		}),
+	}
+
+	env := app.TenantEnvironment(tn, relayv1beta1.NewUnstructuredObject(map[string]interface{}{
+		"TOKEN": "step-token",
+		"DEBUG": true,
+	}))
+	assert.Equal(t, map[string]interface{}{
+		"HTTPS_PROXY": "http://proxy.example.com:3128",
+		"TOKEN":       "step-token",
+		"DEBUG":       true,
+	}, env)
+}
+
+func TestConfigureTenantDefaultMetadata(t *testing.T) {
+	tn := obj.NewTenant(client.ObjectKey{Namespace: "default", Name: "my-tenant"})
+	tn.Object.Spec.Defaults = &relayv1beta1.TenantDefaults{
+		Labels: map[string]string{
+			"team":                 "platform",
+			"relay.sh/run-id":      "not-mine",
+			"example.com/cost-tag": "ci",
+		},
+		Annotations: map[string]string{
+			"example.com/owner": "platform@example.com",
+		},
+	}
+
+	meta := &metav1.ObjectMeta{
+		Labels: map[string]string{"relay.sh/run-id": "my-run"},
+	}
+	app.ConfigureTenantDefaultMetadata(tn, meta)
+
+	assert.Equal(t, map[string]string{
+		"team":                 "platform",
+		"relay.sh/run-id":      "my-run",
+		"example.com/cost-tag": "ci",
+	}, meta.Labels)
+	assert.Equal(t, map[string]string{"example.com/owner": "platform@example.com"}, meta.Annotations)
+}
+
+func TestTenantSinkStatuses(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}).
+		Build()
+
+	tn := obj.NewTenant(client.ObjectKey{Namespace: "default", Name: "my-tenant"})
+	tn.Object.Spec.TriggerEventSink.API = &relayv1beta1.APITriggerEventSink{
+		URL: "https://example.com/events",
+		TokenFrom: &relayv1beta1.APITokenSource{
+			SecretKeyRef: &relayv1beta1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "my-token"},
+				Key:                  "token",
+			},
+		},
+	}
+
+	td := app.NewTenantDeps(tn)
+	_, err := td.Load(ctx, cl)
+	require.NoError(t, err)
+
+	earlier := metav1.NewTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(earlier.Add(time.Minute))
+
+	tw := &app.TenantWorkloads{
+		WebhookTriggers: []*obj.WebhookTrigger{
+			webhookTriggerWithRecentEvent(earlier, "delivered", ""),
+			webhookTriggerWithRecentEvent(later, "rejected", "bad request"),
+		},
+	}
+
+	statuses := app.TenantSinkStatuses(tn, td, tw)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, relayv1beta1.TenantSinkTriggerEvent, statuses[0].Type)
+	assert.False(t, statuses[0].Ready)
+	assert.Contains(t, statuses[0].Message, `"my-token"`)
+	assert.Equal(t, &relayv1beta1.TenantSinkDelivery{
+		Time:  later,
+		Error: "bad request",
+	}, statuses[0].LastDelivery)
+
+	// Once the token exists, the sink is ready.
+	require.NoError(t, cl.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "my-token"},
+		Type:       corev1.SecretTypeOpaque,
+		Data:       map[string][]byte{"token": []byte("hunter2")},
+	}))
+
+	td = app.NewTenantDeps(tn)
+	_, err = td.Load(ctx, cl)
+	require.NoError(t, err)
+
+	statuses = app.TenantSinkStatuses(tn, td, nil)
+	require.Len(t, statuses, 1)
+	assert.True(t, statuses[0].Ready)
+	assert.Nil(t, statuses[0].LastDelivery)
+}
+
+func TestLoadTenantWorkloads(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, relayv1beta1.AddToScheme(scheme))
+
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&relayv1beta1.Workflow{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mine"},
+				Spec:       relayv1beta1.WorkflowSpec{TenantRef: corev1.LocalObjectReference{Name: "my-tenant"}},
+			},
+			&relayv1beta1.Workflow{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "theirs"},
+				Spec:       relayv1beta1.WorkflowSpec{TenantRef: corev1.LocalObjectReference{Name: "their-tenant"}},
+			},
+			runWithConditions("active", "mine"),
+			runWithConditions("succeeded", "mine",
+				relayv1beta1.RunCompleted, relayv1beta1.RunSucceeded),
+			runWithConditions("failed", "mine",
+				relayv1beta1.RunCompleted),
+			runWithConditions("other", "theirs"),
+			&relayv1beta1.WebhookTrigger{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ready"},
+				Spec:       relayv1beta1.WebhookTriggerSpec{TenantRef: corev1.LocalObjectReference{Name: "my-tenant"}},
+				Status: relayv1beta1.WebhookTriggerStatus{
+					Conditions: []relayv1beta1.WebhookTriggerCondition{
+						{
+							Condition: relayv1beta1.Condition{Status: corev1.ConditionTrue},
+							Type:      relayv1beta1.WebhookTriggerReady,
+						},
+					},
+				},
+			},
+			&relayv1beta1.WebhookTrigger{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "not-ready"},
+				Spec:       relayv1beta1.WebhookTriggerSpec{TenantRef: corev1.LocalObjectReference{Name: "my-tenant"}},
+			},
+			&relayv1beta1.WebhookTrigger{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other"},
+				Spec:       relayv1beta1.WebhookTriggerSpec{TenantRef: corev1.LocalObjectReference{Name: "their-tenant"}},
+			},
+		).
+		Build()
+
+	tn := obj.NewTenant(client.ObjectKey{Namespace: "default", Name: "my-tenant"})
+
+	tw, err := app.LoadTenantWorkloads(ctx, cl, tn)
+	require.NoError(t, err)
+	assert.Len(t, tw.Runs, 3)
+	assert.Len(t, tw.WebhookTriggers, 2)
+
+	assert.Equal(t, &relayv1beta1.TenantRunCounts{Active: 1, Succeeded: 1, Failed: 1}, app.TenantRunCounts(tw))
+	assert.Equal(t, &relayv1beta1.TenantTriggerCounts{Ready: 1, NotReady: 1}, app.TenantTriggerCounts(tw))
+}
+
+func webhookTriggerWithRecentEvent(tm metav1.Time, outcome, err string) *obj.WebhookTrigger {
+	wt := obj.NewWebhookTrigger(client.ObjectKey{Namespace: "default", Name: "my-trigger"})
+	wt.Object.Status.Events = &relayv1beta1.WebhookTriggerEventStatus{
+		Recent: []relayv1beta1.WebhookTriggerRecordedEvent{
+			{ID: "event", Time: tm, Outcome: outcome, Error: err},
+		},
+	}
+	return wt
+}
+
+func runWithConditions(name, workflow string, types ...relayv1beta1.RunConditionType) *relayv1beta1.Run {
+	r := &relayv1beta1.Run{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: relayv1beta1.RunSpec{
+			WorkflowRef: corev1.LocalObjectReference{Name: workflow},
+		},
+	}
+
+	for _, typ := range types {
+		r.Status.Conditions = append(r.Status.Conditions, relayv1beta1.RunCondition{
+			Condition: relayv1beta1.Condition{Status: corev1.ConditionTrue},
+			Type:      typ,
+		})
+	}
+
+	return r
+}
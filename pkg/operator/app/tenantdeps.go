@@ -23,9 +23,15 @@ type TenantDeps struct {
 	Namespace     *corev1obj.Namespace
 	NetworkPolicy *networkingv1obj.NetworkPolicy
 	LimitRange    *corev1obj.LimitRange
+	ResourceQuota *obj.ResourceQuota
+
+	// LimitRangeError describes why the limit range of the tenant could not
+	// be applied.
+	LimitRangeError error
 
 	APITriggerEventSink      *APITriggerEventSink
 	APIWorkflowExecutionSink *APIWorkflowExecutionSink
+	WebhookNotificationSink  *WebhookNotificationSink
 }
 
 var _ lifecycle.Deleter = &TenantDeps{}
@@ -58,12 +64,13 @@ func (td *TenantDeps) Load(ctx context.Context, cl client.Client) (bool, error)
 	loaders := lifecycle.Loaders{
 		lifecycle.IgnoreNilLoader{Loader: td.APITriggerEventSink},
 		lifecycle.IgnoreNilLoader{Loader: td.APIWorkflowExecutionSink},
+		lifecycle.IgnoreNilLoader{Loader: td.WebhookNotificationSink},
 	}
 
 	if !td.Tenant.Managed() {
 		loaders = append(loaders, lifecycle.RequiredLoader{Loader: td.Namespace})
 	} else {
-		loaders = append(loaders, td.Namespace, td.NetworkPolicy, td.LimitRange)
+		loaders = append(loaders, td.Namespace, td.NetworkPolicy, td.LimitRange, td.ResourceQuota)
 	}
 
 	// Check for stale namespace. We only clean up the stale namespace if it was
@@ -81,6 +88,10 @@ func (td *TenantDeps) Persist(ctx context.Context, cl client.Client) error {
 
 	if td.Tenant.Managed() {
 		ps = append(ps, td.Namespace, td.NetworkPolicy, td.LimitRange)
+
+		if td.Tenant.Object.Spec.Quota != nil {
+			ps = append(ps, td.ResourceQuota)
+		}
 	}
 
 	for _, p := range ps {
@@ -89,9 +100,32 @@ func (td *TenantDeps) Persist(ctx context.Context, cl client.Client) error {
 		}
 	}
 
+	if td.Tenant.Managed() && td.Tenant.Object.Spec.Quota == nil {
+		if _, err := td.deleteResourceQuota(ctx, cl); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// deleteResourceQuota removes the quota of a tenant that no longer has one. A
+// quota with the same name that was not created for the tenant is left
+// alone.
+func (td *TenantDeps) deleteResourceQuota(ctx context.Context, cl client.Client) (bool, error) {
+	if td.ResourceQuota.Object.GetUID() == "" {
+		return true, nil
+	}
+
+	if ok, err := DependencyManager.IsDependencyOf(td.ResourceQuota.Object, lifecycle.TypedObject{Object: td.Tenant.Object, GVK: relayv1beta1.TenantKind}); err != nil {
+		return false, err
+	} else if !ok {
+		return true, nil
+	}
+
+	return td.ResourceQuota.Delete(ctx, cl)
+}
+
 func (td *TenantDeps) DeleteStale(ctx context.Context, cl client.Client, opts ...lifecycle.DeleteOption) (bool, error) {
 	if td.StaleNamespace != nil && td.StaleNamespace.Object.GetUID() != "" {
 		if ok, err := DependencyManager.IsDependencyOf(td.StaleNamespace.Object, lifecycle.TypedObject{Object: td.Tenant.Object, GVK: relayv1beta1.TenantKind}); err != nil {
@@ -127,6 +161,7 @@ func NewTenantDeps(t *obj.Tenant, opts ...TenantDepsOption) *TenantDeps {
 		td.Namespace = corev1obj.NewNamespace(ns)
 		td.NetworkPolicy = networkingv1obj.NewNetworkPolicy(client.ObjectKey{Namespace: ns, Name: t.Key.Name})
 		td.LimitRange = corev1obj.NewLimitRange(client.ObjectKey{Namespace: ns, Name: t.Key.Name})
+		td.ResourceQuota = obj.NewResourceQuota(client.ObjectKey{Namespace: ns, Name: t.Key.Name})
 	}
 
 	if sink := t.Object.Spec.TriggerEventSink.API; sink != nil {
@@ -137,6 +172,10 @@ func NewTenantDeps(t *obj.Tenant, opts ...TenantDepsOption) *TenantDeps {
 		td.APIWorkflowExecutionSink = NewAPIWorkflowExecutionSink(td.Tenant.Key.Namespace, sink)
 	}
 
+	if sink := t.Object.Spec.NotificationSink.Webhook; sink != nil {
+		td.WebhookNotificationSink = NewWebhookNotificationSink(td.Tenant.Key.Namespace, sink)
+	}
+
 	for _, opt := range opts {
 		opt(td)
 	}
@@ -156,13 +195,34 @@ func ConfigureTenantDeps(ctx context.Context, td *TenantDeps) error {
 
 	lifecycle.Label(ctx, td.Namespace, model.RelayControllerTenantWorkloadLabel, "true")
 	td.Namespace.LabelAnnotateFrom(ctx, &td.Tenant.Object.Spec.NamespaceTemplate.Metadata)
+	ConfigureNamespacePodSecurity(ctx, td.Namespace, td.Tenant)
 
 	if td.Standalone {
 		td.NetworkPolicy.AllowAll()
 	} else {
-		ConfigureNetworkPolicyForTenant(td.NetworkPolicy)
+		ConfigureNetworkPolicyForTenant(td.NetworkPolicy, NetworkPolicyOptionsForTenant(td.Tenant)...)
+	}
+
+	var lros []LimitRangeOption
+	if tlr := td.Tenant.Object.Spec.LimitRange; tlr != nil {
+		lros = append(lros, LimitRangeWithTenantLimitRange(tlr))
+	}
+	ConfigureLimitRange(td.LimitRange, lros...)
+
+	// An inconsistent limit range would be rejected by the API server, so the
+	// built-in limits apply until the tenant corrects it.
+	td.LimitRangeError = ValidateLimitRange(td.LimitRange)
+	if td.LimitRangeError != nil {
+		ConfigureLimitRange(td.LimitRange)
+	}
+
+	if quota := td.Tenant.Object.Spec.Quota; quota != nil {
+		if err := DependencyManager.SetDependencyOf(&td.ResourceQuota.Object.ObjectMeta, lifecycle.TypedObject{Object: td.Tenant.Object, GVK: relayv1beta1.TenantKind}); err != nil {
+			return err
+		}
+
+		ConfigureResourceQuota(td.ResourceQuota, quota)
 	}
-	ConfigureLimitRange(td.LimitRange)
 
 	return nil
 }
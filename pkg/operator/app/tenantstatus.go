@@ -0,0 +1,223 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"github.com/puppetlabs/relay-core/pkg/model"
+	"github.com/puppetlabs/relay-core/pkg/obj"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TenantWorkloads are the runs and webhook triggers attached to a tenant.
+type TenantWorkloads struct {
+	Runs            []*obj.Run
+	WebhookTriggers []*obj.WebhookTrigger
+}
+
+// LoadTenantWorkloads finds the runs and webhook triggers attached to the
+// given tenant. Runs are attached through their workflow.
+func LoadTenantWorkloads(ctx context.Context, cl client.Client, t *obj.Tenant) (*TenantWorkloads, error) {
+	tw := &TenantWorkloads{}
+
+	var wfs relayv1beta1.WorkflowList
+	if err := cl.List(ctx, &wfs, client.InNamespace(t.Key.Namespace)); err != nil {
+		return nil, err
+	}
+
+	workflows := make(map[string]struct{})
+	for _, wf := range wfs.Items {
+		if wf.Spec.TenantRef.Name == t.Key.Name {
+			workflows[wf.GetName()] = struct{}{}
+		}
+	}
+
+	if len(workflows) > 0 {
+		var runs relayv1beta1.RunList
+		if err := cl.List(ctx, &runs, client.InNamespace(t.Key.Namespace)); err != nil {
+			return nil, err
+		}
+
+		for i := range runs.Items {
+			if _, found := workflows[runs.Items[i].Spec.WorkflowRef.Name]; found {
+				tw.Runs = append(tw.Runs, obj.NewRunFromObject(&runs.Items[i]))
+			}
+		}
+	}
+
+	var wts relayv1beta1.WebhookTriggerList
+	if err := cl.List(ctx, &wts, client.InNamespace(t.Key.Namespace)); err != nil {
+		return nil, err
+	}
+
+	for i := range wts.Items {
+		if wts.Items[i].Spec.TenantRef.Name == t.Key.Name {
+			tw.WebhookTriggers = append(tw.WebhookTriggers, obj.NewWebhookTriggerFromObject(&wts.Items[i]))
+		}
+	}
+
+	return tw, nil
+}
+
+// TenantRunCounts summarizes the state of the runs attached to a tenant.
+func TenantRunCounts(tw *TenantWorkloads) *relayv1beta1.TenantRunCounts {
+	counts := &relayv1beta1.TenantRunCounts{}
+
+	for _, r := range tw.Runs {
+		switch {
+		case !r.IsCondition(relayv1beta1.RunCompleted, corev1.ConditionTrue):
+			counts.Active++
+		case r.IsCondition(relayv1beta1.RunSucceeded, corev1.ConditionTrue):
+			counts.Succeeded++
+		default:
+			counts.Failed++
+		}
+	}
+
+	return counts
+}
+
+// TenantTriggerCounts summarizes the state of the webhook triggers attached
+// to a tenant.
+func TenantTriggerCounts(tw *TenantWorkloads) *relayv1beta1.TenantTriggerCounts {
+	counts := &relayv1beta1.TenantTriggerCounts{}
+
+	for _, wt := range tw.WebhookTriggers {
+		if wt.Ready() {
+			counts.Ready++
+		} else {
+			counts.NotReady++
+		}
+	}
+
+	return counts
+}
+
+type tenantAPISink interface {
+	URL() string
+	Token() (string, bool)
+}
+
+func tenantAPISinkStatus(typ relayv1beta1.TenantSinkType, sink tenantAPISink, tokenFrom *relayv1beta1.APITokenSource) relayv1beta1.TenantSinkStatus {
+	status := relayv1beta1.TenantSinkStatus{
+		Type: typ,
+	}
+
+	if sink.URL() == "" {
+		status.Message = "The sink is missing an endpoint URL."
+	} else if _, ok := sink.Token(); !ok {
+		if tokenFrom != nil && tokenFrom.SecretKeyRef != nil {
+			status.Message = fmt.Sprintf("The secret %q does not exist or does not contain the key %q.", tokenFrom.SecretKeyRef.Name, tokenFrom.SecretKeyRef.Key)
+		} else {
+			status.Message = "The sink is missing a token."
+		}
+	} else {
+		status.Ready = true
+		status.Message = "The sink is ready."
+	}
+
+	return status
+}
+
+// TenantSinkStatuses reports the configuration of each sink of a tenant and
+// the outcome of the most recent delivery to it, if known.
+func TenantSinkStatuses(t *obj.Tenant, td *TenantDeps, tw *TenantWorkloads) []relayv1beta1.TenantSinkStatus {
+	var statuses []relayv1beta1.TenantSinkStatus
+
+	if tes := t.Object.Spec.TriggerEventSink; tes.API != nil || tes.WorkflowRef != nil {
+		var status relayv1beta1.TenantSinkStatus
+		switch {
+		case tes.API != nil && tes.WorkflowRef != nil:
+			status = relayv1beta1.TenantSinkStatus{
+				Type:    relayv1beta1.TenantSinkTriggerEvent,
+				Message: "The sink may specify either an API or a workflow reference, but not both.",
+			}
+		case td.APITriggerEventSink != nil:
+			status = tenantAPISinkStatus(relayv1beta1.TenantSinkTriggerEvent, td.APITriggerEventSink, tes.API.TokenFrom)
+		default:
+			status = relayv1beta1.TenantSinkStatus{
+				Type:    relayv1beta1.TenantSinkTriggerEvent,
+				Ready:   true,
+				Message: fmt.Sprintf("Events create runs of the workflow %q.", tes.WorkflowRef.Name),
+			}
+		}
+
+		if tw != nil {
+			status.LastDelivery = lastTriggerEventDelivery(tw)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	if sink := td.APIWorkflowExecutionSink; sink != nil {
+		statuses = append(statuses, tenantAPISinkStatus(relayv1beta1.TenantSinkWorkflowExecution, sink, sink.Sink.TokenFrom))
+	}
+
+	if sink := td.WebhookNotificationSink; sink != nil {
+		status := tenantAPISinkStatus(relayv1beta1.TenantSinkNotification, sink, sink.Sink.TokenFrom)
+
+		if tw != nil {
+			status.LastDelivery = lastRunNotificationDelivery(tw)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
+
+func lastTriggerEventDelivery(tw *TenantWorkloads) *relayv1beta1.TenantSinkDelivery {
+	var last *relayv1beta1.WebhookTriggerRecordedEvent
+	for _, wt := range tw.WebhookTriggers {
+		es := wt.Object.Status.Events
+		if es == nil || len(es.Recent) == 0 {
+			continue
+		}
+
+		// The recent events are ordered newest first.
+		if re := &es.Recent[0]; last == nil || re.Time.After(last.Time.Time) {
+			last = re
+		}
+	}
+
+	if last == nil {
+		return nil
+	}
+
+	return &relayv1beta1.TenantSinkDelivery{
+		Time:      last.Time,
+		Succeeded: last.Outcome == string(model.EventOutcomeDelivered),
+		Error:     last.Error,
+	}
+}
+
+func lastRunNotificationDelivery(tw *TenantWorkloads) *relayv1beta1.TenantSinkDelivery {
+	var last *relayv1beta1.RunNotificationStatus
+	for _, r := range tw.Runs {
+		ns := r.Object.Status.Notification
+		if ns == nil || ns.LastAttemptTime == nil {
+			continue
+		}
+
+		if last == nil || ns.LastAttemptTime.After(last.LastAttemptTime.Time) {
+			last = ns
+		}
+	}
+
+	if last == nil {
+		return nil
+	}
+
+	delivery := &relayv1beta1.TenantSinkDelivery{
+		Time:      metav1.Time{Time: last.LastAttemptTime.Time},
+		Succeeded: last.State == relayv1beta1.RunNotificationDelivered,
+	}
+	if !delivery.Succeeded {
+		delivery.Error = last.Message
+	}
+
+	return delivery
+}
@@ -0,0 +1,179 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"github.com/puppetlabs/relay-core/pkg/manager/api"
+	"github.com/puppetlabs/relay-core/pkg/manager/configmap"
+	kubernetesmgr "github.com/puppetlabs/relay-core/pkg/manager/kubernetes"
+	"github.com/puppetlabs/relay-core/pkg/model"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TriggerEventAttemptTimeout is the maximum amount of time to wait for the
+// event sink to respond to a single redelivery attempt.
+const TriggerEventAttemptTimeout = 5 * time.Second
+
+type timeoutEventManager struct {
+	delegate model.EventManager
+	timeout  time.Duration
+}
+
+func (m *timeoutEventManager) Emit(ctx context.Context, data map[string]interface{}, key string) (*model.Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	return m.delegate.Emit(ctx, data, key)
+}
+
+// TriggerEventManager returns an event manager that delivers events to the
+// sink configured for the tenant of the given webhook trigger, or nil if the
+// tenant does not have a usable sink.
+func TriggerEventManager(wtd *WebhookTriggerDeps, cl client.Client) model.EventManager {
+	mt := ModelWebhookTrigger(wtd.WebhookTrigger)
+	tes := wtd.TenantDeps.Tenant.Object.Spec.TriggerEventSink
+
+	if sink := wtd.TenantDeps.APITriggerEventSink; sink != nil {
+		token, _ := sink.Token()
+
+		var opts []api.EventManagerOption
+		if tes.Format == relayv1beta1.TriggerEventSinkFormatCloudEvents {
+			var mode relayv1beta1.CloudEventsMode
+			var dataSchema string
+			if tes.CloudEvents != nil {
+				mode = tes.CloudEvents.Mode
+				dataSchema = tes.CloudEvents.DataSchema
+			}
+
+			opts = append(opts, api.EventManagerWithCloudEvents(
+				api.TriggerCloudEventsSource(wtd.TenantDeps.Namespace.Name, mt.Name),
+				mode,
+				dataSchema,
+			))
+		}
+
+		return api.NewEventManager(mt, sink.URL(), token, opts...)
+	}
+
+	if tes.WorkflowRef != nil {
+		var opts []kubernetesmgr.EventManagerOption
+		if tes.Binding != nil && len(tes.Binding.Parameters) > 0 {
+			opts = append(opts, kubernetesmgr.EventManagerWithParameters(tes.Binding.Parameters.Value()))
+		}
+
+		return kubernetesmgr.NewEventManager(mt, cl, wtd.TenantDeps.Tenant.Key.Namespace, tes.WorkflowRef.Name, opts...)
+	}
+
+	return nil
+}
+
+func triggerConfigMapEventManager(cl client.Client, wtd *WebhookTriggerDeps, delegate model.EventManager) *configmap.EventManager {
+	if delegate != nil {
+		delegate = &timeoutEventManager{delegate: delegate, timeout: TriggerEventAttemptTimeout}
+	}
+
+	return configmap.NewEventManager(
+		ModelWebhookTrigger(wtd.WebhookTrigger),
+		configmap.NewControllerRuntimeConfigMap(cl, wtd.MutableConfigMap.Key),
+		delegate,
+	)
+}
+
+// TriggerEventHistory is the record kept of the events emitted by a webhook
+// trigger.
+type TriggerEventHistory struct {
+	Stats  *model.EventStats
+	Events []*model.RecordedEvent
+}
+
+// LoadTriggerEventHistory reads the event statistics and recent events of the
+// given webhook trigger.
+func LoadTriggerEventHistory(ctx context.Context, cl client.Client, wtd *WebhookTriggerDeps) (*TriggerEventHistory, error) {
+	em := triggerConfigMapEventManager(cl, wtd, nil)
+
+	stats, err := em.Stats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := em.History(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TriggerEventHistory{
+		Stats:  stats,
+		Events: events,
+	}, nil
+}
+
+// ReplayTriggerEvent sends the event named by the replay annotation of the
+// given webhook trigger to the event sink again and then removes the
+// annotation. The outcome is reported using events on the webhook trigger.
+func ReplayTriggerEvent(ctx context.Context, cl client.Client, rec record.EventRecorder, wtd *WebhookTriggerDeps) error {
+	wt := wtd.WebhookTrigger
+
+	id, found := wt.Object.GetAnnotations()[model.RelayReplayEventAnnotation]
+	if !found {
+		return nil
+	}
+
+	if delegate := TriggerEventManager(wtd, cl); delegate == nil {
+		rec.Eventf(wt.Object, corev1.EventTypeWarning, EventReasonTriggerEventReplayFailed, "Event %s could not be replayed because the tenant has no event sink", id)
+	} else {
+		re, err := triggerConfigMapEventManager(cl, wtd, delegate).Replay(ctx, id)
+		switch {
+		case err == model.ErrNotFound:
+			rec.Eventf(wt.Object, corev1.EventTypeWarning, EventReasonTriggerEventReplayFailed, "Event %s could not be replayed because it is no longer in the history", id)
+		case err == configmap.ErrEventTruncated:
+			rec.Eventf(wt.Object, corev1.EventTypeWarning, EventReasonTriggerEventReplayFailed, "Event %s could not be replayed because it was too large to keep in the history", id)
+		case err != nil && re == nil:
+			return err
+		case err != nil:
+			rec.Eventf(wt.Object, corev1.EventTypeWarning, EventReasonTriggerEventReplayFailed, "Event %s could not be replayed: %v", id, err)
+		case re.Outcome == model.EventOutcomePending:
+			rec.Eventf(wt.Object, corev1.EventTypeWarning, EventReasonTriggerEventReplayFailed, "Event %s could not be replayed and will be retried as event %s: %s", id, re.ID, re.Error)
+		default:
+			rec.Eventf(wt.Object, corev1.EventTypeNormal, EventReasonTriggerEventReplayed, "Event %s replayed as event %s", id, re.ID)
+		}
+	}
+
+	delete(wt.Object.Annotations, model.RelayReplayEventAnnotation)
+	return wt.Persist(ctx, cl)
+}
+
+// RedeliverTriggerEvents sends the events of the given webhook trigger that
+// previously failed to reach the event sink again. It returns how long to
+// wait before events that are still pending should be retried.
+func RedeliverTriggerEvents(ctx context.Context, cl client.Client, rec record.EventRecorder, wtd *WebhookTriggerDeps) (time.Duration, error) {
+	delegate := TriggerEventManager(wtd, cl)
+	if delegate == nil {
+		return 0, nil
+	}
+
+	em := triggerConfigMapEventManager(cl, wtd, delegate)
+
+	rs, next, err := em.RedeliverPending(ctx)
+	RecordTriggerEventRedeliveries(rec, wtd.WebhookTrigger.Object, rs)
+
+	return next, err
+}
+
+// RecordTriggerEventRedeliveries emits an event for each attempt to redeliver
+// a pending trigger event.
+func RecordTriggerEventRedeliveries(rec record.EventRecorder, wt *relayv1beta1.WebhookTrigger, rs []*configmap.EventRedelivery) {
+	for _, r := range rs {
+		switch {
+		case r.Error == nil:
+			rec.Eventf(wt, corev1.EventTypeNormal, EventReasonTriggerEventDelivered, "Event %s delivered after %d attempts", r.Event.ID, r.Event.Attempts)
+		case r.Dropped:
+			rec.Eventf(wt, corev1.EventTypeWarning, EventReasonTriggerEventDropped, "Event %s could not be delivered after %d attempts and was discarded: %v", r.Event.ID, r.Event.Attempts, r.Error)
+		default:
+			rec.Eventf(wt, corev1.EventTypeWarning, EventReasonTriggerEventDeliveryFailed, "Event %s could not be delivered and will be retried: %v", r.Event.ID, r.Error)
+		}
+	}
+}
@@ -1,12 +1,15 @@
 package app
 
 import (
+	"fmt"
+
 	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
 	"github.com/puppetlabs/relay-core/pkg/obj"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func ConfigureWebhookTrigger(wt *obj.WebhookTrigger, ksr *KnativeServiceResult) {
+func ConfigureWebhookTrigger(wt *obj.WebhookTrigger, ksr *KnativeServiceResult, dmr *KnativeDomainMappingResult, teh *TriggerEventHistory) {
 	// Set up our initial map from the existing data.
 	conds := map[relayv1beta1.WebhookTriggerConditionType]*relayv1beta1.Condition{
 		relayv1beta1.WebhookTriggerServiceReady: {},
@@ -27,6 +30,12 @@ func ConfigureWebhookTrigger(wt *obj.WebhookTrigger, ksr *KnativeServiceResult)
 				Reason:  obj.WebhookTriggerStatusReasonServiceError,
 				Message: ksr.Error.Error(),
 			}
+		} else if dmr.Error != nil {
+			return relayv1beta1.Condition{
+				Status:  corev1.ConditionFalse,
+				Reason:  obj.WebhookTriggerStatusReasonServiceError,
+				Message: fmt.Sprintf("The custom domain could not be configured: %v", dmr.Error),
+			}
 		} else if ksr.KnativeService != nil && ksr.KnativeService.Object.IsReady() {
 			return relayv1beta1.Condition{
 				Status:  corev1.ConditionTrue,
@@ -76,10 +85,41 @@ func ConfigureWebhookTrigger(wt *obj.WebhookTrigger, ksr *KnativeServiceResult)
 		},
 	}
 
+	if teh != nil && (teh.Stats.Accepted > 0 || teh.Stats.Rejected > 0 || len(teh.Events) > 0) {
+		es := &relayv1beta1.WebhookTriggerEventStatus{
+			Accepted:  teh.Stats.Accepted,
+			Rejected:  teh.Stats.Rejected,
+			LastError: teh.Stats.LastError,
+		}
+
+		if !teh.Stats.LastEventTime.IsZero() {
+			es.LastEventTime = &metav1.Time{Time: teh.Stats.LastEventTime}
+		}
+
+		for _, re := range teh.Events {
+			es.Recent = append(es.Recent, relayv1beta1.WebhookTriggerRecordedEvent{
+				ID:      re.ID,
+				Time:    metav1.Time{Time: re.Time},
+				Outcome: string(re.Outcome),
+				Error:   re.Error,
+			})
+		}
+
+		wt.Object.Status.Events = es
+	}
+
 	if ksr.KnativeService != nil {
 		wt.Object.Status.Namespace = ksr.KnativeService.Key.Namespace
 
-		if ksr.KnativeService.Object.IsReady() && ksr.KnativeService.Object.Status.URL != nil {
+		if dm := dmr.KnativeDomainMapping; dm != nil {
+			// Only advertise the custom domain once requests to it will
+			// actually reach the service.
+			if ksr.KnativeService.Object.IsReady() && dm.Object.IsReady() && dm.Object.Status.URL != nil {
+				wt.Object.Status.URL = dm.Object.Status.URL.String()
+			} else {
+				wt.Object.Status.URL = ""
+			}
+		} else if ksr.KnativeService.Object.IsReady() && ksr.KnativeService.Object.Status.URL != nil {
 			wt.Object.Status.URL = ksr.KnativeService.Object.Status.URL.String()
 		} else {
 			wt.Object.Status.URL = ""
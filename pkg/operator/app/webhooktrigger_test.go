@@ -0,0 +1,210 @@
+package app_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1obj "github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/api/corev1"
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"github.com/puppetlabs/relay-core/pkg/model"
+	"github.com/puppetlabs/relay-core/pkg/obj"
+	"github.com/puppetlabs/relay-core/pkg/operator/app"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	servingv1beta1 "knative.dev/serving/pkg/apis/serving/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func readyConditions(ready bool) duckv1.Conditions {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+
+	return duckv1.Conditions{{Type: apis.ConditionReady, Status: status}}
+}
+
+func TestConfigureWebhookTriggerURL(t *testing.T) {
+	serviceURL, _ := apis.ParseURL("http://my-trigger.tenant.example.com")
+	domainURL, _ := apis.ParseURL("https://hooks.example.com")
+
+	tcs := []struct {
+		Name          string
+		ServiceReady  bool
+		Domain        bool
+		DomainReady   bool
+		ExpectedURL   string
+		ExpectedReady corev1.ConditionStatus
+	}{
+		{
+			Name:          "Service",
+			ServiceReady:  true,
+			ExpectedURL:   serviceURL.String(),
+			ExpectedReady: corev1.ConditionTrue,
+		},
+		{
+			Name:          "ServiceNotReady",
+			ExpectedReady: corev1.ConditionUnknown,
+		},
+		{
+			Name:          "Domain",
+			ServiceReady:  true,
+			Domain:        true,
+			DomainReady:   true,
+			ExpectedURL:   domainURL.String(),
+			ExpectedReady: corev1.ConditionTrue,
+		},
+		{
+			Name:          "DomainNotReady",
+			ServiceReady:  true,
+			Domain:        true,
+			ExpectedReady: corev1.ConditionTrue,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Name, func(t *testing.T) {
+			wt := obj.NewWebhookTrigger(client.ObjectKey{Namespace: "default", Name: "my-trigger"})
+
+			ks := obj.NewKnativeService(client.ObjectKey{Namespace: "tenant", Name: "my-trigger"})
+			ks.Object.Status.Conditions = readyConditions(tc.ServiceReady)
+			ks.Object.Status.URL = serviceURL
+
+			dmr := &app.KnativeDomainMappingResult{}
+			if tc.Domain {
+				dm := obj.NewKnativeDomainMapping(client.ObjectKey{Namespace: "tenant", Name: domainURL.Host})
+				dm.Object.Status.Conditions = readyConditions(tc.DomainReady)
+				dm.Object.Status.URL = domainURL
+
+				dmr.KnativeDomainMapping = dm
+			}
+
+			app.ConfigureWebhookTrigger(wt, app.AsKnativeServiceResult(ks, nil), dmr, nil)
+
+			assert.Equal(t, "tenant", wt.Object.Status.Namespace)
+			assert.Equal(t, tc.ExpectedURL, wt.Object.Status.URL)
+			for _, cond := range wt.Object.Status.Conditions {
+				if cond.Type == relayv1beta1.WebhookTriggerServiceReady {
+					assert.Equal(t, tc.ExpectedReady, cond.Status)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigureWebhookTriggerEvents(t *testing.T) {
+	now := time.Now().UTC()
+
+	wt := obj.NewWebhookTrigger(client.ObjectKey{Namespace: "default", Name: "my-trigger"})
+	ks := obj.NewKnativeService(client.ObjectKey{Namespace: "tenant", Name: "my-trigger"})
+
+	app.ConfigureWebhookTrigger(wt, app.AsKnativeServiceResult(ks, nil), &app.KnativeDomainMappingResult{}, &app.TriggerEventHistory{
+		Stats: &model.EventStats{},
+	})
+	assert.Nil(t, wt.Object.Status.Events)
+
+	app.ConfigureWebhookTrigger(wt, app.AsKnativeServiceResult(ks, nil), &app.KnativeDomainMappingResult{}, &app.TriggerEventHistory{
+		Stats: &model.EventStats{
+			Accepted:      2,
+			Rejected:      1,
+			LastEventTime: now,
+			LastError:     "bad request",
+		},
+		Events: []*model.RecordedEvent{
+			{ID: "b", Time: now, Outcome: model.EventOutcomeRejected, Error: "bad request"},
+			{ID: "a", Time: now.Add(-time.Minute), Outcome: model.EventOutcomeDelivered},
+		},
+	})
+
+	es := wt.Object.Status.Events
+	require.NotNil(t, es)
+	assert.Equal(t, int64(2), es.Accepted)
+	assert.Equal(t, int64(1), es.Rejected)
+	assert.Equal(t, "bad request", es.LastError)
+	assert.True(t, now.Equal(es.LastEventTime.Time))
+	require.Len(t, es.Recent, 2)
+	assert.Equal(t, "b", es.Recent[0].ID)
+	assert.Equal(t, "rejected", es.Recent[0].Outcome)
+	assert.Equal(t, "delivered", es.Recent[1].Outcome)
+}
+
+func TestApplyKnativeDomainMapping(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, servingv1beta1.AddToScheme(scheme))
+
+	newDomainMapping := func(name, trigger string) *servingv1beta1.DomainMapping {
+		return &servingv1beta1.DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "tenant",
+				Name:      name,
+				UID:       types.UID(name),
+				Labels: map[string]string{
+					model.RelayControllerWebhookTriggerIDLabel: trigger,
+				},
+			},
+		}
+	}
+
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newDomainMapping("old.example.com", "my-trigger"),
+			newDomainMapping("other.example.com", "other-trigger"),
+		).
+		Build()
+
+	newDeps := func(domain string) *app.WebhookTriggerDeps {
+		wt := obj.NewWebhookTrigger(client.ObjectKey{Namespace: "default", Name: "my-trigger"})
+		wt.Object.Spec.Domain = &relayv1beta1.WebhookTriggerDomain{
+			Name: domain,
+			TLS:  &relayv1beta1.WebhookTriggerDomainTLS{SecretName: "hooks-tls"},
+		}
+
+		owner := corev1obj.NewConfigMap(client.ObjectKey{Namespace: "tenant", Name: "my-trigger-owner"})
+		owner.Object.UID = types.UID("owner")
+
+		return &app.WebhookTriggerDeps{
+			WebhookTrigger: wt,
+			TenantDeps: &app.TenantDeps{
+				Namespace: corev1obj.NewNamespace("tenant"),
+			},
+			OwnerConfigMap: owner,
+		}
+	}
+
+	ks := obj.NewKnativeService(client.ObjectKey{Namespace: "tenant", Name: "my-trigger"})
+
+	dm, err := app.ApplyKnativeDomainMapping(ctx, cl, newDeps("hooks.example.com"), ks)
+	require.NoError(t, err)
+	assert.Equal(t, "hooks.example.com", dm.Key.Name)
+	assert.Equal(t, servingv1.SchemeGroupVersion.String(), dm.Object.Spec.Ref.APIVersion)
+	assert.Equal(t, "Service", dm.Object.Spec.Ref.Kind)
+	assert.Equal(t, "my-trigger", dm.Object.Spec.Ref.Name)
+	assert.Equal(t, "hooks-tls", dm.Object.Spec.TLS.SecretName)
+
+	// The mapping for the domain the trigger used previously is removed, but
+	// the mappings of other triggers are left alone.
+	dms := &servingv1beta1.DomainMappingList{}
+	require.NoError(t, cl.List(ctx, dms))
+
+	var names []string
+	for _, item := range dms.Items {
+		names = append(names, item.Name)
+	}
+	assert.ElementsMatch(t, []string{"hooks.example.com", "other.example.com"}, names)
+
+	// Another trigger's domain can't be taken over.
+	_, err = app.ApplyKnativeDomainMapping(ctx, cl, newDeps("other.example.com"), ks)
+	require.EqualError(t, err, `the domain "other.example.com" is already in use`)
+}
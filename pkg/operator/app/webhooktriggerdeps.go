@@ -3,10 +3,12 @@ package app
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"math"
 	"net/url"
 	"path"
+	"strconv"
 	"time"
 
 	"github.com/puppetlabs/leg/errmap/pkg/errmark"
@@ -44,9 +46,10 @@ type WebhookTriggerDeps struct {
 	Tenant         *obj.Tenant
 	TenantDeps     *TenantDeps
 
-	Environment       string
-	RuntimeToolsImage string
-	Standalone        bool
+	Environment          string
+	RuntimeToolsImage    string
+	Standalone           bool
+	NetworkPolicyOptions []NetworkPolicyOption
 
 	// StaleOwnerConfigMap is a reference to a now-outdated stub object that
 	// needs to be cleaned up. It is set if the tenant is deleted or if the
@@ -305,6 +308,70 @@ func (wtd *WebhookTriggerDeps) AnnotateTriggerToken(ctx context.Context, target
 			claims.RelayEventAPIToken, _ = sink.Token()
 			idh.Set("event", claims.RelayEventAPIURL.String(), claims.RelayEventAPIToken)
 		}
+
+		if tes := wtd.TenantDeps.Tenant.Object.Spec.TriggerEventSink; tes.Format == relayv1beta1.TriggerEventSinkFormatCloudEvents {
+			claims.RelayEventAPIFormat = string(tes.Format)
+			if tes.CloudEvents != nil {
+				claims.RelayEventAPICloudEventsMode = string(tes.CloudEvents.Mode)
+				claims.RelayEventAPICloudEventsDataSchema = tes.CloudEvents.DataSchema
+			}
+			idh.Set("event-format", claims.RelayEventAPIFormat, claims.RelayEventAPICloudEventsMode, claims.RelayEventAPICloudEventsDataSchema)
+		}
+	}
+
+	// A workflow reference is only honored when no API sink is configured;
+	// the tenant reports the combination as misconfigured.
+	if tes := wtd.TenantDeps.Tenant.Object.Spec.TriggerEventSink; tes.WorkflowRef != nil && tes.API == nil {
+		claims.RelayEventWorkflowNamespace = wtd.TenantDeps.Tenant.Key.Namespace
+		claims.RelayEventWorkflowName = tes.WorkflowRef.Name
+
+		var binding []byte
+		if tes.Binding != nil && len(tes.Binding.Parameters) > 0 {
+			claims.RelayEventWorkflowParameters = tes.Binding.Parameters.Value()
+
+			binding, err = json.Marshal(claims.RelayEventWorkflowParameters)
+			if err != nil {
+				return err
+			}
+		}
+
+		idh.Set("event-workflow", claims.RelayEventWorkflowNamespace, claims.RelayEventWorkflowName, string(binding))
+	}
+
+	if v := wtd.WebhookTrigger.Object.Spec.Verification; v != nil {
+		claims.RelayWebhookVerificationScheme = string(v.Scheme)
+		claims.RelayWebhookVerificationSecretName = v.SecretName
+		if v.HMAC != nil {
+			claims.RelayWebhookVerificationHeader = v.HMAC.Header
+			claims.RelayWebhookVerificationAlgorithm = v.HMAC.Algorithm
+			claims.RelayWebhookVerificationPrefix = v.HMAC.Prefix
+			claims.RelayWebhookVerificationEncoding = v.HMAC.Encoding
+		}
+		idh.Set(
+			"verification",
+			claims.RelayWebhookVerificationScheme,
+			claims.RelayWebhookVerificationSecretName,
+			claims.RelayWebhookVerificationHeader,
+			claims.RelayWebhookVerificationAlgorithm,
+			claims.RelayWebhookVerificationPrefix,
+			claims.RelayWebhookVerificationEncoding,
+		)
+	}
+
+	if l := wtd.WebhookTrigger.Object.Spec.Limits; l != nil {
+		if l.RequestsPerSecond != nil {
+			claims.RelayWebhookLimitsRequestsPerSecond = *l.RequestsPerSecond
+			claims.RelayWebhookLimitsBurst = WebhookTriggerBurst(l)
+		}
+		if l.MaxBodySize != nil {
+			claims.RelayWebhookLimitsMaxBodySize = l.MaxBodySize.Value()
+		}
+		idh.Set(
+			"limits",
+			strconv.FormatInt(int64(claims.RelayWebhookLimitsRequestsPerSecond), 10),
+			strconv.FormatInt(int64(claims.RelayWebhookLimitsBurst), 10),
+			strconv.FormatInt(claims.RelayWebhookLimitsMaxBodySize, 10),
+		)
 	}
 
 	if h, err := idh.Sum(); err != nil {
@@ -337,6 +404,14 @@ func WebhookTriggerDepsWithRuntimeToolsImage(image string) WebhookTriggerDepsOpt
 	}
 }
 
+// WebhookTriggerDepsWithNetworkPolicyOptions sets options for the network
+// policy of the webhook trigger before the options of its tenant are applied.
+func WebhookTriggerDepsWithNetworkPolicyOptions(opts ...NetworkPolicyOption) WebhookTriggerDepsOption {
+	return func(wtd *WebhookTriggerDeps) {
+		wtd.NetworkPolicyOptions = append(wtd.NetworkPolicyOptions, opts...)
+	}
+}
+
 func WebhookTriggerDepsWithStandaloneMode(standalone bool) WebhookTriggerDepsOption {
 	return func(wtd *WebhookTriggerDeps) {
 		wtd.Standalone = standalone
@@ -373,6 +448,17 @@ func ConfigureWebhookTriggerDeps(ctx context.Context, wtd *WebhookTriggerDeps) e
 		return err
 	}
 
+	// Undelivered events are buffered in the mutable config map, so changes to
+	// it need to wake up the trigger.
+	if err := DependencyManager.SetDependencyOf(
+		wtd.MutableConfigMap.Object,
+		lifecycle.TypedObject{
+			Object: wtd.WebhookTrigger.Object,
+			GVK:    relayv1beta1.WebhookTriggerKind,
+		}); err != nil {
+		return err
+	}
+
 	lafs := []lifecycle.LabelAnnotatableFrom{
 		wtd.ImmutableConfigMap,
 		wtd.MutableConfigMap,
@@ -388,10 +474,11 @@ func ConfigureWebhookTriggerDeps(ctx context.Context, wtd *WebhookTriggerDeps) e
 	if wtd.Standalone {
 		wtd.NetworkPolicy.AllowAll()
 	} else {
-		ConfigureNetworkPolicyForWebhookTrigger(wtd.NetworkPolicy, wtd.WebhookTrigger)
+		opts := append(append([]NetworkPolicyOption{}, wtd.NetworkPolicyOptions...), NetworkPolicyOptionsForTenant(wtd.Tenant)...)
+		ConfigureNetworkPolicyForWebhookTrigger(wtd.NetworkPolicy, wtd.WebhookTrigger, opts...)
 	}
 
-	if err := ConfigureImmutableConfigMapForWebhookTrigger(ctx, wtd.ImmutableConfigMap, wtd.WebhookTrigger); err != nil {
+	if err := ConfigureImmutableConfigMapForWebhookTrigger(ctx, wtd.ImmutableConfigMap, wtd); err != nil {
 		return err
 	}
 
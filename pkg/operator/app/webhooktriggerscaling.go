@@ -0,0 +1,154 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"k8s.io/utils/pointer"
+	"knative.dev/serving/pkg/apis/autoscaling"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+// WebhookTriggerMaxScaleDownDelay is the longest scale-down delay Knative
+// accepts.
+const WebhookTriggerMaxScaleDownDelay = time.Hour
+
+// WebhookTriggerSpecError is returned when a webhook trigger specification
+// contains a combination of settings that cannot be applied.
+type WebhookTriggerSpecError struct {
+	Field  string
+	Reason string
+}
+
+func (e *WebhookTriggerSpecError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Reason)
+}
+
+// ValidateWebhookTriggerSpec checks the scaling and resource settings of a
+// webhook trigger for consistency.
+func ValidateWebhookTriggerSpec(spec *relayv1beta1.WebhookTriggerSpec) error {
+	if sc := spec.Scaling; sc != nil {
+		if sc.MinScale != nil && *sc.MinScale < 0 {
+			return &WebhookTriggerSpecError{Field: "scaling.minScale", Reason: "must not be negative"}
+		}
+
+		if sc.MaxScale != nil && *sc.MaxScale < 0 {
+			return &WebhookTriggerSpecError{Field: "scaling.maxScale", Reason: "must not be negative"}
+		}
+
+		if sc.MinScale != nil && sc.MaxScale != nil && *sc.MaxScale > 0 && *sc.MinScale > *sc.MaxScale {
+			return &WebhookTriggerSpecError{
+				Field:  "scaling.minScale",
+				Reason: fmt.Sprintf("%d is greater than scaling.maxScale (%d)", *sc.MinScale, *sc.MaxScale),
+			}
+		}
+
+		if sc.TargetConcurrency != nil && *sc.TargetConcurrency < 1 {
+			return &WebhookTriggerSpecError{Field: "scaling.targetConcurrency", Reason: "must be at least 1"}
+		}
+
+		if sc.ScaleDownDelay != nil {
+			if d := sc.ScaleDownDelay.Duration; d < 0 {
+				return &WebhookTriggerSpecError{Field: "scaling.scaleDownDelay", Reason: "must not be negative"}
+			} else if d > WebhookTriggerMaxScaleDownDelay {
+				return &WebhookTriggerSpecError{
+					Field:  "scaling.scaleDownDelay",
+					Reason: fmt.Sprintf("%s is longer than the maximum of %s", d, WebhookTriggerMaxScaleDownDelay),
+				}
+			} else if d%time.Second != 0 {
+				return &WebhookTriggerSpecError{Field: "scaling.scaleDownDelay", Reason: "must be a whole number of seconds"}
+			}
+		}
+	}
+
+	if l := spec.Limits; l != nil {
+		if l.RequestsPerSecond != nil && *l.RequestsPerSecond < 1 {
+			return &WebhookTriggerSpecError{Field: "limits.requestsPerSecond", Reason: "must be at least 1"}
+		}
+
+		if l.Burst != nil && *l.Burst < 1 {
+			return &WebhookTriggerSpecError{Field: "limits.burst", Reason: "must be at least 1"}
+		}
+
+		if l.MaxBodySize != nil && l.MaxBodySize.Sign() <= 0 {
+			return &WebhookTriggerSpecError{Field: "limits.maxBodySize", Reason: "must be greater than zero"}
+		}
+
+		// Knative will not route more requests to a replica than its
+		// container concurrency, so the scaling target must fit within it.
+		if burst := WebhookTriggerBurst(l); burst > 0 && spec.Scaling != nil && spec.Scaling.TargetConcurrency != nil && *spec.Scaling.TargetConcurrency > burst {
+			return &WebhookTriggerSpecError{
+				Field:  "scaling.targetConcurrency",
+				Reason: fmt.Sprintf("%d is greater than the burst limit (%d)", *spec.Scaling.TargetConcurrency, burst),
+			}
+		}
+	}
+
+	if res := spec.Resources; res != nil {
+		for name, req := range res.Requests {
+			if limit, ok := res.Limits[name]; ok && req.Cmp(limit) > 0 {
+				return &WebhookTriggerSpecError{
+					Field:  fmt.Sprintf("resources.requests.%s", name),
+					Reason: fmt.Sprintf("%s is greater than the limit of %s", req.String(), limit.String()),
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// WebhookTriggerBurst returns the number of events a webhook trigger may emit
+// at once, or zero if it is not limited.
+func WebhookTriggerBurst(l *relayv1beta1.WebhookTriggerLimits) int32 {
+	switch {
+	case l == nil:
+		return 0
+	case l.Burst != nil:
+		return *l.Burst
+	case l.RequestsPerSecond != nil:
+		return *l.RequestsPerSecond
+	default:
+		return 0
+	}
+}
+
+// ConfigureKnativeRevisionTemplateScaling sets the autoscaling annotations,
+// container concurrency and container resources of a revision template from a
+// webhook trigger specification. The template must already contain the
+// webhook container as its only container.
+func ConfigureKnativeRevisionTemplateScaling(template *servingv1.RevisionTemplateSpec, spec *relayv1beta1.WebhookTriggerSpec) {
+	if sc := spec.Scaling; sc != nil {
+		if template.Annotations == nil {
+			template.Annotations = make(map[string]string)
+		}
+
+		if sc.MinScale != nil {
+			template.Annotations[autoscaling.MinScaleAnnotationKey] = strconv.FormatInt(int64(*sc.MinScale), 10)
+		}
+
+		if sc.MaxScale != nil {
+			template.Annotations[autoscaling.MaxScaleAnnotationKey] = strconv.FormatInt(int64(*sc.MaxScale), 10)
+		}
+
+		if sc.TargetConcurrency != nil {
+			template.Annotations[autoscaling.TargetAnnotationKey] = strconv.FormatInt(int64(*sc.TargetConcurrency), 10)
+		}
+
+		if sc.ScaleDownDelay != nil {
+			template.Annotations[autoscaling.ScaleDownDelayAnnotationKey] = sc.ScaleDownDelay.Duration.String()
+		}
+	}
+
+	if burst := WebhookTriggerBurst(spec.Limits); burst > 0 {
+		template.Spec.ContainerConcurrency = pointer.Int64Ptr(int64(burst))
+	}
+
+	if spec.Resources != nil {
+		for i := range template.Spec.PodSpec.Containers {
+			template.Spec.PodSpec.Containers[i].Resources = *spec.Resources.DeepCopy()
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package app_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"github.com/puppetlabs/relay-core/pkg/operator/app"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"knative.dev/serving/pkg/apis/autoscaling"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+func TestValidateWebhookTriggerSpec(t *testing.T) {
+	tcs := []struct {
+		Name          string
+		Spec          relayv1beta1.WebhookTriggerSpec
+		ExpectedField string
+	}{
+		{
+			Name: "Empty",
+		},
+		{
+			Name: "Valid",
+			Spec: relayv1beta1.WebhookTriggerSpec{
+				Scaling: &relayv1beta1.WebhookTriggerScaling{
+					MinScale:          pointer.Int32Ptr(1),
+					MaxScale:          pointer.Int32Ptr(5),
+					TargetConcurrency: pointer.Int32Ptr(10),
+					ScaleDownDelay:    &metav1.Duration{Duration: 15 * time.Minute},
+				},
+				Resources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+					Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				},
+			},
+		},
+		{
+			Name: "UnlimitedMaxScale",
+			Spec: relayv1beta1.WebhookTriggerSpec{
+				Scaling: &relayv1beta1.WebhookTriggerScaling{
+					MinScale: pointer.Int32Ptr(3),
+					MaxScale: pointer.Int32Ptr(0),
+				},
+			},
+		},
+		{
+			Name: "MinScaleGreaterThanMaxScale",
+			Spec: relayv1beta1.WebhookTriggerSpec{
+				Scaling: &relayv1beta1.WebhookTriggerScaling{
+					MinScale: pointer.Int32Ptr(3),
+					MaxScale: pointer.Int32Ptr(2),
+				},
+			},
+			ExpectedField: "scaling.minScale",
+		},
+		{
+			Name: "ZeroTargetConcurrency",
+			Spec: relayv1beta1.WebhookTriggerSpec{
+				Scaling: &relayv1beta1.WebhookTriggerScaling{
+					TargetConcurrency: pointer.Int32Ptr(0),
+				},
+			},
+			ExpectedField: "scaling.targetConcurrency",
+		},
+		{
+			Name: "ScaleDownDelayTooLong",
+			Spec: relayv1beta1.WebhookTriggerSpec{
+				Scaling: &relayv1beta1.WebhookTriggerScaling{
+					ScaleDownDelay: &metav1.Duration{Duration: 2 * time.Hour},
+				},
+			},
+			ExpectedField: "scaling.scaleDownDelay",
+		},
+		{
+			Name: "RequestGreaterThanLimit",
+			Spec: relayv1beta1.WebhookTriggerSpec{
+				Resources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+				},
+			},
+			ExpectedField: "resources.requests.memory",
+		},
+		{
+			Name: "TargetConcurrencyGreaterThanBurst",
+			Spec: relayv1beta1.WebhookTriggerSpec{
+				Scaling: &relayv1beta1.WebhookTriggerScaling{
+					TargetConcurrency: pointer.Int32Ptr(20),
+				},
+				Limits: &relayv1beta1.WebhookTriggerLimits{
+					RequestsPerSecond: pointer.Int32Ptr(10),
+				},
+			},
+			ExpectedField: "scaling.targetConcurrency",
+		},
+		{
+			Name: "ZeroMaxBodySize",
+			Spec: relayv1beta1.WebhookTriggerSpec{
+				Limits: &relayv1beta1.WebhookTriggerLimits{
+					MaxBodySize: resource.NewQuantity(0, resource.BinarySI),
+				},
+			},
+			ExpectedField: "limits.maxBodySize",
+		},
+	}
+	for _, test := range tcs {
+		t.Run(test.Name, func(t *testing.T) {
+			err := app.ValidateWebhookTriggerSpec(&test.Spec)
+			if test.ExpectedField == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var serr *app.WebhookTriggerSpecError
+			if assert.True(t, errors.As(err, &serr)) {
+				assert.Equal(t, test.ExpectedField, serr.Field)
+			}
+		})
+	}
+}
+
+func TestConfigureKnativeRevisionTemplateScaling(t *testing.T) {
+	spec := &relayv1beta1.WebhookTriggerSpec{
+		Scaling: &relayv1beta1.WebhookTriggerScaling{
+			MinScale:          pointer.Int32Ptr(1),
+			MaxScale:          pointer.Int32Ptr(4),
+			TargetConcurrency: pointer.Int32Ptr(20),
+			ScaleDownDelay:    &metav1.Duration{Duration: 5 * time.Minute},
+		},
+		Resources: &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+		},
+		Limits: &relayv1beta1.WebhookTriggerLimits{
+			RequestsPerSecond: pointer.Int32Ptr(10),
+			Burst:             pointer.Int32Ptr(25),
+		},
+	}
+
+	template := &servingv1.RevisionTemplateSpec{
+		Spec: servingv1.RevisionSpec{
+			PodSpec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "tools"}},
+				Containers:     []corev1.Container{{Name: "trigger"}},
+			},
+		},
+	}
+
+	app.ConfigureKnativeRevisionTemplateScaling(template, spec)
+
+	assert.Equal(t, map[string]string{
+		autoscaling.MinScaleAnnotationKey:       "1",
+		autoscaling.MaxScaleAnnotationKey:       "4",
+		autoscaling.TargetAnnotationKey:         "20",
+		autoscaling.ScaleDownDelayAnnotationKey: "5m0s",
+	}, template.Annotations)
+	assert.Equal(t, pointer.Int64Ptr(25), template.Spec.ContainerConcurrency)
+	assert.Equal(t, *spec.Resources, template.Spec.PodSpec.Containers[0].Resources)
+	assert.Empty(t, template.Spec.PodSpec.InitContainers[0].Resources)
+}
@@ -23,6 +23,7 @@ type WorkflowControllerConfig struct {
 	WebhookServerKeyDir     string
 	DynamicRBACBinding      bool
 	AlertsDelegate          alerts.DelegateFunc
+	Platform                *PlatformConfig
 }
 
 func (c *WorkflowControllerConfig) Capturer() trackers.Capturer {
@@ -0,0 +1,209 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/puppetlabs/relay-core/pkg/util/typeutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	PlatformConfigAPIVersion = "config.relay.sh/v1alpha1"
+	PlatformConfigKind       = "OperatorConfig"
+)
+
+var PlatformConfigVersionKind = typeutil.NewVersionKindExpectation(PlatformConfigAPIVersion, PlatformConfigKind)
+
+// PlatformConfig describes the cluster the operator runs tenant workloads in.
+// It is read from the operator configuration file. Any section that is not
+// specified uses the values of DefaultPlatformConfig.
+type PlatformConfig struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+
+	// Scheduling determines the nodes that tenant pods run on.
+	Scheduling SchedulingConfig `json:"scheduling,omitempty"`
+
+	// DNS is the DNS configuration of tenant pods.
+	DNS DNSConfig `json:"dns,omitempty"`
+
+	// Sandbox configures the container runtime used to isolate tenant pods.
+	Sandbox SandboxConfig `json:"sandbox,omitempty"`
+
+	// NetworkPolicy identifies the system components that tenant pods may
+	// connect to or receive connections from.
+	NetworkPolicy NetworkPolicyConfig `json:"networkPolicy,omitempty"`
+}
+
+type SchedulingConfig struct {
+	// NodeSelector is added to the node selector of every tenant pod. An
+	// empty map disables the default.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations replace the tolerations of every tenant pod. An empty list
+	// disables the default.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+type DNSConfig struct {
+	// Policy is the DNS policy of tenant pods. If it is not specified, the
+	// default policy and configuration are used.
+	Policy corev1.DNSPolicy `json:"policy,omitempty"`
+
+	// Config is the DNS configuration of tenant pods. It is required if the
+	// policy is None.
+	Config *corev1.PodDNSConfig `json:"config,omitempty"`
+}
+
+type SandboxConfig struct {
+	// RuntimeClassName is the runtime class of tenant pods. If it is not
+	// specified, tenant pods are not sandboxed.
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+}
+
+type NetworkPolicyConfig struct {
+	// SystemNamespaceSelector selects the namespaces the metadata API runs in.
+	SystemNamespaceSelector *metav1.LabelSelector `json:"systemNamespaceSelector,omitempty"`
+
+	// MetadataAPIPodSelector selects the metadata API pods.
+	MetadataAPIPodSelector *metav1.LabelSelector `json:"metadataAPIPodSelector,omitempty"`
+
+	// MetadataAPIPort is the port the metadata API pods listen on.
+	MetadataAPIPort int `json:"metadataAPIPort,omitempty"`
+
+	// WebhookGatewayNamespaceSelector selects the namespaces that may send
+	// requests to webhook triggers.
+	WebhookGatewayNamespaceSelector *metav1.LabelSelector `json:"webhookGatewayNamespaceSelector,omitempty"`
+}
+
+// DefaultPlatformConfig returns the configuration used by the hosted Relay
+// service, which is also the configuration used if no file is given.
+func DefaultPlatformConfig() *PlatformConfig {
+	return &PlatformConfig{
+		APIVersion: PlatformConfigAPIVersion,
+		Kind:       PlatformConfigKind,
+		Scheduling: SchedulingConfig{
+			NodeSelector: map[string]string{
+				"nebula.puppet.com/scheduling.customer-ready": "true",
+			},
+			Tolerations: []corev1.Toleration{
+				{
+					Key:    "nebula.puppet.com/scheduling.customer-workload",
+					Value:  "true",
+					Effect: corev1.TaintEffectNoSchedule,
+				},
+				{
+					Key:    "sandbox.gke.io/runtime",
+					Value:  "gvisor",
+					Effect: corev1.TaintEffectNoSchedule,
+				},
+			},
+		},
+		DNS: DNSConfig{
+			Policy: corev1.DNSNone,
+			Config: &corev1.PodDNSConfig{
+				Nameservers: []string{
+					"1.1.1.1",
+					"1.0.0.1",
+					"8.8.8.8",
+				},
+			},
+		},
+		NetworkPolicy: NetworkPolicyConfig{
+			SystemNamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"nebula.puppet.com/network-policy.tasks": "true",
+				},
+			},
+			MetadataAPIPodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name":      "nebula-system",
+					"app.kubernetes.io/component": "metadata-api",
+				},
+			},
+			MetadataAPIPort: 7000,
+			WebhookGatewayNamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"nebula.puppet.com/network-policy.webhook-gateway": "true",
+				},
+			},
+		},
+	}
+}
+
+// PlatformConfigError is returned when a platform configuration cannot be
+// used.
+type PlatformConfigError struct {
+	Field  string
+	Reason string
+}
+
+func (e *PlatformConfigError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Reason)
+}
+
+// ParsePlatformConfig decodes a YAML or JSON platform configuration, fills in
+// defaults for the settings it does not specify and validates the result.
+func ParsePlatformConfig(b []byte) (*PlatformConfig, error) {
+	if _, err := PlatformConfigVersionKind.NewFromYAMLString(string(b)); err != nil {
+		return nil, err
+	}
+
+	cfg := &PlatformConfig{}
+	if err := yaml.UnmarshalStrict(b, cfg); err != nil {
+		return nil, err
+	}
+
+	def := DefaultPlatformConfig()
+
+	if cfg.Scheduling.NodeSelector == nil {
+		cfg.Scheduling.NodeSelector = def.Scheduling.NodeSelector
+	}
+
+	if cfg.Scheduling.Tolerations == nil {
+		cfg.Scheduling.Tolerations = def.Scheduling.Tolerations
+	}
+
+	switch {
+	case cfg.DNS.Policy == "" && cfg.DNS.Config == nil:
+		cfg.DNS = def.DNS
+	case cfg.DNS.Policy == "":
+		return nil, &PlatformConfigError{Field: "dns.policy", Reason: "must be specified with dns.config"}
+	case cfg.DNS.Policy == corev1.DNSNone && cfg.DNS.Config == nil:
+		return nil, &PlatformConfigError{Field: "dns.config", Reason: "must be specified when dns.policy is None"}
+	}
+
+	if cfg.NetworkPolicy.SystemNamespaceSelector == nil {
+		cfg.NetworkPolicy.SystemNamespaceSelector = def.NetworkPolicy.SystemNamespaceSelector
+	}
+
+	if cfg.NetworkPolicy.MetadataAPIPodSelector == nil {
+		cfg.NetworkPolicy.MetadataAPIPodSelector = def.NetworkPolicy.MetadataAPIPodSelector
+	}
+
+	if cfg.NetworkPolicy.MetadataAPIPort == 0 {
+		cfg.NetworkPolicy.MetadataAPIPort = def.NetworkPolicy.MetadataAPIPort
+	} else if port := cfg.NetworkPolicy.MetadataAPIPort; port < 0 || port > 65535 {
+		return nil, &PlatformConfigError{Field: "networkPolicy.metadataAPIPort", Reason: fmt.Sprintf("%d is not a valid port", port)}
+	}
+
+	if cfg.NetworkPolicy.WebhookGatewayNamespaceSelector == nil {
+		cfg.NetworkPolicy.WebhookGatewayNamespaceSelector = def.NetworkPolicy.WebhookGatewayNamespaceSelector
+	}
+
+	return cfg, nil
+}
+
+// LoadPlatformConfig reads the platform configuration from the file at the
+// given path.
+func LoadPlatformConfig(path string) (*PlatformConfig, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePlatformConfig(b)
+}
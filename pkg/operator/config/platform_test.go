@@ -0,0 +1,134 @@
+package config_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/puppetlabs/relay-core/pkg/operator/config"
+	"github.com/puppetlabs/relay-core/pkg/util/typeutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParsePlatformConfigDefaults(t *testing.T) {
+	cfg, err := config.ParsePlatformConfig([]byte(`
+apiVersion: config.relay.sh/v1alpha1
+kind: OperatorConfig
+`))
+	require.NoError(t, err)
+	assert.Equal(t, config.DefaultPlatformConfig(), cfg)
+}
+
+func TestParsePlatformConfig(t *testing.T) {
+	cfg, err := config.ParsePlatformConfig([]byte(`
+apiVersion: config.relay.sh/v1alpha1
+kind: OperatorConfig
+scheduling:
+  nodeSelector: {}
+  tolerations:
+  - key: example.com/workloads
+    operator: Exists
+    effect: NoSchedule
+dns:
+  policy: ClusterFirst
+sandbox:
+  runtimeClassName: kata
+networkPolicy:
+  metadataAPIPodSelector:
+    matchLabels:
+      app: relay-metadata-api
+  metadataAPIPort: 8080
+`))
+	require.NoError(t, err)
+
+	def := config.DefaultPlatformConfig()
+
+	// An empty node selector disables the default.
+	assert.Empty(t, cfg.Scheduling.NodeSelector)
+	assert.Equal(t, []corev1.Toleration{
+		{
+			Key:      "example.com/workloads",
+			Operator: corev1.TolerationOpExists,
+			Effect:   corev1.TaintEffectNoSchedule,
+		},
+	}, cfg.Scheduling.Tolerations)
+	assert.Equal(t, config.DNSConfig{Policy: corev1.DNSClusterFirst}, cfg.DNS)
+	assert.Equal(t, "kata", cfg.Sandbox.RuntimeClassName)
+	assert.Equal(t, &metav1.LabelSelector{MatchLabels: map[string]string{"app": "relay-metadata-api"}}, cfg.NetworkPolicy.MetadataAPIPodSelector)
+	assert.Equal(t, 8080, cfg.NetworkPolicy.MetadataAPIPort)
+
+	// Settings that are not given keep their defaults.
+	assert.Equal(t, def.NetworkPolicy.SystemNamespaceSelector, cfg.NetworkPolicy.SystemNamespaceSelector)
+	assert.Equal(t, def.NetworkPolicy.WebhookGatewayNamespaceSelector, cfg.NetworkPolicy.WebhookGatewayNamespaceSelector)
+}
+
+func TestParsePlatformConfigErrors(t *testing.T) {
+	tcs := []struct {
+		Name          string
+		Data          string
+		ExpectedField string
+	}{
+		{
+			Name: "DNSConfigWithoutPolicy",
+			Data: `
+apiVersion: config.relay.sh/v1alpha1
+kind: OperatorConfig
+dns:
+  config:
+    nameservers: [9.9.9.9]
+`,
+			ExpectedField: "dns.policy",
+		},
+		{
+			Name: "NoneWithoutDNSConfig",
+			Data: `
+apiVersion: config.relay.sh/v1alpha1
+kind: OperatorConfig
+dns:
+  policy: None
+`,
+			ExpectedField: "dns.config",
+		},
+		{
+			Name: "InvalidPort",
+			Data: `
+apiVersion: config.relay.sh/v1alpha1
+kind: OperatorConfig
+networkPolicy:
+  metadataAPIPort: 70000
+`,
+			ExpectedField: "networkPolicy.metadataAPIPort",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := config.ParsePlatformConfig([]byte(tc.Data))
+
+			var cerr *config.PlatformConfigError
+			require.True(t, errors.As(err, &cerr), "unexpected error: %v", err)
+			assert.Equal(t, tc.ExpectedField, cerr.Field)
+		})
+	}
+
+	t.Run("WrongVersion", func(t *testing.T) {
+		_, err := config.ParsePlatformConfig([]byte(`
+apiVersion: config.relay.sh/v2
+kind: OperatorConfig
+`))
+
+		var verr *typeutil.InvalidVersionKindError
+		assert.True(t, errors.As(err, &verr))
+	})
+
+	t.Run("UnknownField", func(t *testing.T) {
+		_, err := config.ParsePlatformConfig([]byte(`
+apiVersion: config.relay.sh/v1alpha1
+kind: OperatorConfig
+scheduling:
+  nodeSelectors: {}
+`))
+		assert.Error(t, err)
+	})
+}
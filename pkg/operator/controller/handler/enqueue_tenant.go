@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+)
+
+const (
+	EnqueueRequestForTenantTimeout = 30 * time.Second
+)
+
+// EnqueueRequestForTenant enqueues the tenant that a run or webhook trigger
+// is attached to. Runs are attached to the tenant of their workflow.
+type EnqueueRequestForTenant struct {
+	cl client.Client
+}
+
+var _ handler.EventHandler = &EnqueueRequestForTenant{}
+var _ inject.Client = &EnqueueRequestForTenant{}
+
+func (e *EnqueueRequestForTenant) Create(evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	e.add(evt.Object, q)
+}
+
+func (e *EnqueueRequestForTenant) Update(evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	e.add(evt.ObjectNew, q)
+}
+
+func (e *EnqueueRequestForTenant) Delete(evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	e.add(evt.Object, q)
+}
+
+func (e *EnqueueRequestForTenant) Generic(evt event.GenericEvent, q workqueue.RateLimitingInterface) {
+	e.add(evt.Object, q)
+}
+
+func (e *EnqueueRequestForTenant) add(target client.Object, q workqueue.RateLimitingInterface) {
+	var name string
+
+	switch t := target.(type) {
+	case *relayv1beta1.WebhookTrigger:
+		name = t.Spec.TenantRef.Name
+	case *relayv1beta1.Run:
+		ctx, cancel := context.WithTimeout(context.Background(), EnqueueRequestForTenantTimeout)
+		defer cancel()
+
+		wf := &relayv1beta1.Workflow{}
+		if err := e.cl.Get(ctx, client.ObjectKey{Namespace: t.GetNamespace(), Name: t.Spec.WorkflowRef.Name}, wf); errors.IsNotFound(err) {
+			return
+		} else if err != nil {
+			// Only the tenant status is affected, so it is safe to wait
+			// for the next change.
+			klog.Errorf("enqueue: failed to get workflow of run %s/%s: %+v", t.GetNamespace(), t.GetName(), err)
+			return
+		}
+
+		name = wf.Spec.TenantRef.Name
+	}
+
+	if name == "" {
+		return
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: target.GetNamespace(),
+			Name:      name,
+		},
+	}
+	q.Add(req)
+	klog.V(4).Infof("enqueue: successful enqueue of tenant %s", req.NamespacedName)
+}
+
+func (e *EnqueueRequestForTenant) InjectClient(cl client.Client) error {
+	e.cl = cl
+	return nil
+}
@@ -5,14 +5,20 @@ import (
 	"github.com/puppetlabs/leg/k8sutil/pkg/controller/errhandler"
 	"github.com/puppetlabs/leg/k8sutil/pkg/controller/filter"
 	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"github.com/puppetlabs/relay-core/pkg/obj"
 	"github.com/puppetlabs/relay-core/pkg/operator/app"
 	"github.com/puppetlabs/relay-core/pkg/operator/config"
+	"github.com/puppetlabs/relay-core/pkg/operator/controller/handler"
 	"github.com/puppetlabs/relay-core/pkg/operator/reconciler/tenant"
 	"github.com/puppetlabs/relay-core/pkg/util/capturer"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
@@ -27,6 +33,41 @@ func add(mgr manager.Manager, r reconcile.Reconciler, cfg *config.WorkflowContro
 			&source.Kind{Type: &corev1.Namespace{}},
 			app.DependencyManager.NewEnqueueRequestForAnnotatedDependencyOf(&relayv1beta1.Tenant{}),
 		).
+		Watches(
+			&source.Kind{Type: &corev1.ResourceQuota{}},
+			app.DependencyManager.NewEnqueueRequestForAnnotatedDependencyOf(&relayv1beta1.Tenant{}),
+		).
+		// Runs and triggers are counted in the tenant status.
+		Watches(
+			&source.Kind{Type: &relayv1beta1.Run{}},
+			&handler.EnqueueRequestForTenant{},
+			builder.WithPredicates(predicate.Funcs{
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					prev, ok := e.ObjectOld.(*relayv1beta1.Run)
+					if !ok {
+						return false
+					}
+
+					r, ok := e.ObjectNew.(*relayv1beta1.Run)
+					return ok && runStateChanged(prev, r)
+				},
+			}),
+		).
+		Watches(
+			&source.Kind{Type: &relayv1beta1.WebhookTrigger{}},
+			&handler.EnqueueRequestForTenant{},
+			builder.WithPredicates(predicate.Funcs{
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					prev, ok := e.ObjectOld.(*relayv1beta1.WebhookTrigger)
+					if !ok {
+						return false
+					}
+
+					wt, ok := e.ObjectNew.(*relayv1beta1.WebhookTrigger)
+					return ok && webhookTriggerStateChanged(prev, wt)
+				},
+			}),
+		).
 		Complete(filter.ChainR(
 			r,
 			errhandler.ChainReconciler(
@@ -45,6 +86,50 @@ func add(mgr manager.Manager, r reconcile.Reconciler, cfg *config.WorkflowContro
 		))
 }
 
+// runStateChanged returns true if a change to a run affects how it is counted
+// or the last notification delivered to the tenant sink.
+func runStateChanged(prev, r *relayv1beta1.Run) bool {
+	condition := func(r *relayv1beta1.Run, typ relayv1beta1.RunConditionType) corev1.ConditionStatus {
+		for _, cond := range r.Status.Conditions {
+			if cond.Type == typ {
+				return cond.Status
+			}
+		}
+
+		return corev1.ConditionUnknown
+	}
+
+	for _, typ := range []relayv1beta1.RunConditionType{relayv1beta1.RunCompleted, relayv1beta1.RunSucceeded} {
+		if condition(prev, typ) != condition(r, typ) {
+			return true
+		}
+	}
+
+	return !equality.Semantic.DeepEqual(prev.Status.Notification, r.Status.Notification)
+}
+
+// webhookTriggerStateChanged returns true if a change to a webhook trigger
+// affects how it is counted or the last event delivered to the tenant sink.
+func webhookTriggerStateChanged(prev, wt *relayv1beta1.WebhookTrigger) bool {
+	if prev.Spec.TenantRef != wt.Spec.TenantRef {
+		return true
+	}
+
+	if obj.NewWebhookTriggerFromObject(prev).Ready() != obj.NewWebhookTriggerFromObject(wt).Ready() {
+		return true
+	}
+
+	newest := func(wt *relayv1beta1.WebhookTrigger) *relayv1beta1.WebhookTriggerRecordedEvent {
+		if es := wt.Status.Events; es != nil && len(es.Recent) > 0 {
+			return &es.Recent[0]
+		}
+
+		return nil
+	}
+
+	return !equality.Semantic.DeepEqual(newest(prev), newest(wt))
+}
+
 func Add(mgr manager.Manager, cfg *config.WorkflowControllerConfig) error {
 	return add(mgr, tenant.NewReconciler(mgr.GetClient(), cfg), cfg)
 }
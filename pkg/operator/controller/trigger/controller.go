@@ -5,6 +5,7 @@ import (
 	"github.com/puppetlabs/leg/k8sutil/pkg/controller/errhandler"
 	"github.com/puppetlabs/leg/k8sutil/pkg/controller/filter"
 	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"github.com/puppetlabs/relay-core/pkg/manager/configmap"
 	"github.com/puppetlabs/relay-core/pkg/model"
 	"github.com/puppetlabs/relay-core/pkg/operator/app"
 	"github.com/puppetlabs/relay-core/pkg/operator/config"
@@ -12,15 +13,46 @@ import (
 	"github.com/puppetlabs/relay-core/pkg/operator/dependency"
 	"github.com/puppetlabs/relay-core/pkg/operator/reconciler/trigger"
 	"github.com/puppetlabs/relay-core/pkg/util/capturer"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	servingv1beta1 "knative.dev/serving/pkg/apis/serving/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 func add(mgr manager.Manager, r reconcile.Reconciler, cfg *config.WorkflowControllerConfig) error {
+	// Only the configuration maps of webhook triggers carry events, so they
+	// are watched using a cache of their own instead of caching every
+	// configuration map in the cluster.
+	triggerLabel, err := labels.NewRequirement(model.RelayControllerWebhookTriggerIDLabel, selection.Exists, nil)
+	if err != nil {
+		return err
+	}
+
+	configMapCache, err := cache.New(mgr.GetConfig(), cache.Options{
+		Scheme: mgr.GetScheme(),
+		Mapper: mgr.GetRESTMapper(),
+		SelectorsByObject: cache.SelectorsByObject{
+			&corev1.ConfigMap{}: {Label: labels.NewSelector().Add(*triggerLabel)},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := mgr.Add(configMapCache); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: cfg.MaxConcurrentReconciles,
@@ -38,6 +70,36 @@ func add(mgr manager.Manager, r reconcile.Reconciler, cfg *config.WorkflowContro
 			&source.Kind{Type: &servingv1.Revision{}},
 			app.DependencyManager.NewEnqueueRequestForAnnotatedDependencyOf(&relayv1beta1.WebhookTrigger{}),
 		).
+		Watches(
+			&source.Kind{Type: &servingv1beta1.DomainMapping{}},
+			app.DependencyManager.NewEnqueueRequestForAnnotatedDependencyOf(&relayv1beta1.WebhookTrigger{}),
+		).
+		Watches(
+			source.NewKindWithCache(&corev1.ConfigMap{}, configMapCache),
+			app.DependencyManager.NewEnqueueRequestForAnnotatedDependencyOf(&relayv1beta1.WebhookTrigger{}),
+			builder.WithPredicates(predicate.Funcs{
+				CreateFunc: func(e event.CreateEvent) bool {
+					cm, ok := e.Object.(*corev1.ConfigMap)
+					return ok && configmap.HasPendingEvents(cm)
+				},
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					prev, ok := e.ObjectOld.(*corev1.ConfigMap)
+					if !ok {
+						return false
+					}
+
+					cm, ok := e.ObjectNew.(*corev1.ConfigMap)
+					return ok && (configmap.HasPendingEvents(cm) || configmap.HasEventChanges(prev, cm))
+				},
+				DeleteFunc: func(e event.DeleteEvent) bool {
+					return false
+				},
+				GenericFunc: func(e event.GenericEvent) bool {
+					cm, ok := e.Object.(*corev1.ConfigMap)
+					return ok && configmap.HasPendingEvents(cm)
+				},
+			}),
+		).
 		Complete(filter.ChainR(
 			r,
 			errhandler.ChainReconciler(
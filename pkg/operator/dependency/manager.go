@@ -15,6 +15,7 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	servingv1beta1 "knative.dev/serving/pkg/apis/serving/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
@@ -26,6 +27,7 @@ var (
 		tektonv1alpha1.AddToScheme,
 		tektonv1beta1.AddToScheme,
 		servingv1.AddToScheme,
+		servingv1beta1.AddToScheme,
 	)
 	AddToScheme = SchemeBuilder.AddToScheme
 
@@ -2,12 +2,14 @@ package run
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 
 	"github.com/puppetlabs/leg/errmap/pkg/errmap"
 	"github.com/puppetlabs/leg/errmap/pkg/errmark"
 	"github.com/puppetlabs/leg/k8sutil/pkg/controller/errhandler"
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/eventctx"
 	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/lifecycle"
 	"github.com/puppetlabs/leg/storage"
 	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
@@ -15,6 +17,7 @@ import (
 	"github.com/puppetlabs/relay-core/pkg/obj"
 	"github.com/puppetlabs/relay-core/pkg/operator/app"
 	"github.com/puppetlabs/relay-core/pkg/operator/dependency"
+	"github.com/puppetlabs/relay-core/pkg/workflow/validation"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
@@ -67,6 +70,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 		return ctrl.Result{}, nil
 	}
 
+	rec := eventctx.EventRecorder(ctx)
+	prev := run.Object.Status.DeepCopy()
+
 	rd := app.NewRunDeps(
 		run,
 		r.issuer,
@@ -74,6 +80,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 		app.RunDepsWithEnvironment(r.Config.Environment),
 		app.RunDepsWithRuntimeToolsImage(r.Config.RuntimeToolsImage),
 		app.RunDepsWithStandaloneMode(r.Config.Standalone),
+		app.RunDepsWithNetworkPolicyOptions(app.NetworkPolicyWithPlatformConfig(r.Config.Platform)),
 	)
 
 	loaded, err := rd.Load(ctx, r.Client)
@@ -83,6 +90,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 	}
 
 	finalized, err := lifecycle.Finalize(ctx, r.Client, FinalizerName, run, func() error {
+		if err := app.DeleteRunArtifacts(ctx, rd, r.StorageClient); err != nil {
+			return errmap.Wrap(err, "failed to delete Run artifacts")
+		}
+
 		_, err := rd.Delete(ctx, r.Client)
 		return err
 	})
@@ -94,6 +105,27 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 		return ctrl.Result{}, errmark.MarkTransient(fmt.Errorf("waiting on Run upstream dependencies"))
 	}
 
+	if run.IsValidationFailed() {
+		return r.deliverNotification(ctx, rd)
+	} else if run.Object.Status.StartTime == nil {
+		var verr *validation.WorkflowValidationError
+		if err := validation.ValidateWorkflow(
+			ctx,
+			rd.Workflow.Object,
+			validation.ValidateWorkflowWithStepServiceAccountNames(rd.WorkflowDeps.Tenant.Object.Spec.StepServiceAccountNames),
+		); errors.As(err, &verr) {
+			app.ConfigureRunWithValidationError(rd.Run, verr)
+
+			if err := run.PersistStatus(ctx, r.Client); err != nil {
+				return ctrl.Result{}, errmap.Wrap(err, "failed to persist Run status")
+			}
+
+			app.RecordRunEvents(rec, run, prev)
+
+			return r.deliverNotification(ctx, rd)
+		}
+	}
+
 	if err := app.ConfigureRunDeps(ctx, rd); err != nil {
 		return ctrl.Result{}, errmap.Wrap(err, "failed to configure Run dependencies")
 	}
@@ -109,7 +141,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 			return ctrl.Result{}, errmap.Wrap(err, "failed to persist Run status")
 		}
 
-		return ctrl.Result{}, nil
+		app.RecordRunEvents(rec, run, prev)
+
+		return r.deliverNotification(ctx, rd)
 	}
 
 	var pr *obj.PipelineRun
@@ -137,6 +171,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 	default:
 		pipeline, err := app.ApplyPipelineParts(ctx, r.Client, rd)
 		if err != nil {
+			app.RecordImageResolutionError(rec, run.Object, err)
+
 			return ctrl.Result{}, errmap.Wrap(err, "failed to apply Pipeline")
 		}
 
@@ -154,7 +190,22 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 		return ctrl.Result{}, errmap.Wrap(err, "failed to persist Run status")
 	}
 
-	return ctrl.Result{}, nil
+	app.RecordRunEvents(rec, run, prev)
+
+	return r.deliverNotification(ctx, rd)
+}
+
+// deliverNotification sends the completion notification for the run, if
+// necessary, and persists the resulting delivery status.
+func (r *Reconciler) deliverNotification(ctx context.Context, rd *app.RunDeps) (ctrl.Result, error) {
+	changed, retry := app.DeliverRunNotification(ctx, rd)
+	if changed {
+		if err := rd.Run.PersistStatus(ctx, r.Client); err != nil {
+			return ctrl.Result{}, errmap.Wrap(err, "failed to persist Run status")
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: retry}, nil
 }
 
 // FIXME Temporary handling for legacy logs
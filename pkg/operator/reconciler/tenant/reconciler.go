@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/puppetlabs/leg/errmap/pkg/errmap"
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/eventctx"
 	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/lifecycle"
 	"github.com/puppetlabs/relay-core/pkg/obj"
 	"github.com/puppetlabs/relay-core/pkg/operator/app"
@@ -64,11 +65,20 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 		return ctrl.Result{}, errmap.Wrap(tdr.Error, "failed to persist Tenant dependencies")
 	}
 
-	app.ConfigureTenant(tn, tdr)
+	tw, err := app.LoadTenantWorkloads(ctx, r.Client, tn)
+	if err != nil {
+		return ctrl.Result{}, errmap.Wrap(err, "failed to load Tenant workloads")
+	}
+
+	prev := tn.Object.Status.DeepCopy()
+
+	app.ConfigureTenant(tn, tdr, tw)
 
 	if err := tn.PersistStatus(ctx, r.Client); err != nil {
 		return ctrl.Result{}, errmap.Wrap(err, "failed to persist Tenant status")
 	}
 
+	app.RecordTenantEvents(eventctx.EventRecorder(ctx), tn, prev)
+
 	return ctrl.Result{}, nil
 }
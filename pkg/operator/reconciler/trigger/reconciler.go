@@ -8,6 +8,7 @@ import (
 	"github.com/puppetlabs/leg/errmap/pkg/errmap"
 	"github.com/puppetlabs/leg/errmap/pkg/errmark"
 	"github.com/puppetlabs/leg/k8sutil/pkg/controller/errhandler"
+	"github.com/puppetlabs/leg/k8sutil/pkg/controller/eventctx"
 	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/helper"
 	"github.com/puppetlabs/leg/k8sutil/pkg/controller/obj/lifecycle"
 	"github.com/puppetlabs/relay-core/pkg/authenticate"
@@ -71,6 +72,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 		app.WebhookTriggerDepsWithEnvironment(r.Config.Environment),
 		app.WebhookTriggerDepsWithRuntimeToolsImage(r.Config.RuntimeToolsImage),
 		app.WebhookTriggerDepsWithStandaloneMode(r.Config.Standalone),
+		app.WebhookTriggerDepsWithNetworkPolicyOptions(app.NetworkPolicyWithPlatformConfig(r.Config.Platform)),
 	)
 	loaded, err := deps.Load(ctx, r.Client)
 	if err != nil {
@@ -115,17 +117,42 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 		return ctrl.Result{}, errmap.Wrap(err, "failed to apply WebhookTrigger dependencies")
 	}
 
+	rec := eventctx.EventRecorder(ctx)
+	prev := wt.Object.Status.DeepCopy()
+
 	ksr := app.AsKnativeServiceResult(app.ApplyKnativeService(ctx, r.Client, deps))
+	app.RecordImageResolutionError(rec, wt.Object, ksr.Error)
+
+	dmr := &app.KnativeDomainMappingResult{}
+	if ksr.Error == nil {
+		dmr = app.AsKnativeDomainMappingResult(app.ApplyKnativeDomainMapping(ctx, r.Client, deps, ksr.KnativeService))
+	}
+
+	teh, err := app.LoadTriggerEventHistory(ctx, r.Client, deps)
+	if err != nil {
+		return ctrl.Result{}, errmap.Wrap(err, "failed to load WebhookTrigger event history")
+	}
 
-	app.ConfigureWebhookTrigger(wt, ksr)
+	app.ConfigureWebhookTrigger(wt, ksr, dmr, teh)
 
 	if err := wt.PersistStatus(ctx, r.Client); err != nil {
 		return ctrl.Result{}, errmap.Wrap(err, "failed to persist WebhookTrigger status")
 	}
 
-	if !wt.Ready() {
-		return ctrl.Result{RequeueAfter: 2 * time.Minute}, nil
+	app.RecordWebhookTriggerEvents(rec, wt, prev)
+
+	next, err := app.RedeliverTriggerEvents(ctx, r.Client, rec, deps)
+	if err != nil {
+		return ctrl.Result{}, errmap.Wrap(err, "failed to redeliver WebhookTrigger events")
+	}
+
+	if err := app.ReplayTriggerEvent(ctx, r.Client, rec, deps); err != nil {
+		return ctrl.Result{}, errmap.Wrap(err, "failed to replay WebhookTrigger event")
+	}
+
+	if !wt.Ready() && (next == 0 || next > 2*time.Minute) {
+		next = 2 * time.Minute
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: next}, nil
 }
@@ -1,6 +1,10 @@
 package validation
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/puppetlabs/relay-core/pkg/spec"
+)
 
 type SchemaDoesNotExistError struct {
 	Name string
@@ -17,3 +21,35 @@ type StepMetadataFetchError struct {
 func (e *StepMetadataFetchError) Error() string {
 	return fmt.Sprintf("step metadata entrypoint responded with %d status code", e.StatusCode)
 }
+
+type InvalidStepOutputSchemaError struct {
+	StepName string
+	Output   string
+	Cause    error
+}
+
+func (e *InvalidStepOutputSchemaError) Unwrap() error {
+	return e.Cause
+}
+
+func (e *InvalidStepOutputSchemaError) Error() string {
+	return fmt.Sprintf("step %q declares output %q with an invalid schema: %v", e.StepName, e.Output, e.Cause)
+}
+
+type UndeclaredOutputReferenceError struct {
+	StepName string
+	Output   spec.OutputID
+}
+
+func (e *UndeclaredOutputReferenceError) Error() string {
+	return fmt.Sprintf("step %q references %s, but the output is not declared", e.StepName, e.Output)
+}
+
+type StepServiceAccountNotAllowedError struct {
+	StepName           string
+	ServiceAccountName string
+}
+
+func (e *StepServiceAccountNotAllowedError) Error() string {
+	return fmt.Sprintf("step %q uses service account %q, but the tenant does not allow it", e.StepName, e.ServiceAccountName)
+}
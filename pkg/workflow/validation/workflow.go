@@ -0,0 +1,155 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/puppetlabs/leg/relspec/pkg/evaluate"
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"github.com/puppetlabs/relay-core/pkg/spec"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+type validateWorkflowOptions struct {
+	stepServiceAccountNames map[string]struct{}
+}
+
+type ValidateWorkflowOption func(opts *validateWorkflowOptions)
+
+// ValidateWorkflowWithStepServiceAccountNames sets the service accounts that
+// steps may run as. By default, steps may not specify a service account.
+func ValidateWorkflowWithStepServiceAccountNames(names []string) ValidateWorkflowOption {
+	return func(opts *validateWorkflowOptions) {
+		for _, name := range names {
+			opts.stepServiceAccountNames[name] = struct{}{}
+		}
+	}
+}
+
+// ValidateWorkflow checks the step definitions of a workflow for consistency.
+// It reports output declarations with schemas that are not valid JSON Schemas
+// and references to outputs that a step does not declare. Steps that do not
+// declare any outputs may provide arbitrary outputs. Steps may only use the
+// service accounts allowed by the given options.
+func ValidateWorkflow(ctx context.Context, wf *relayv1beta1.Workflow, opts ...ValidateWorkflowOption) error {
+	o := &validateWorkflowOptions{
+		stepServiceAccountNames: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	verr := &WorkflowValidationError{}
+
+	declared := make(map[string]map[string]struct{}, len(wf.Spec.Steps))
+	for _, step := range wf.Spec.Steps {
+		if step == nil {
+			continue
+		}
+
+		outputs := make(map[string]struct{}, len(step.Outputs))
+		for _, output := range step.Outputs {
+			if output == nil {
+				continue
+			}
+
+			outputs[output.Name] = struct{}{}
+
+			if output.Schema != nil {
+				if _, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(output.Schema.Value())); err != nil {
+					verr.Causes = append(verr.Causes, &InvalidStepOutputSchemaError{StepName: step.Name, Output: output.Name, Cause: err})
+				}
+			}
+		}
+
+		declared[step.Name] = outputs
+
+		if sa := step.ServiceAccountName; sa != "" {
+			if _, found := o.stepServiceAccountNames[sa]; !found {
+				verr.Causes = append(verr.Causes, &StepServiceAccountNotAllowedError{StepName: step.Name, ServiceAccountName: sa})
+			}
+		}
+	}
+
+	for _, step := range wf.Spec.Steps {
+		if step == nil {
+			continue
+		}
+
+		for _, id := range referencedOutputs(ctx, step) {
+			// References to steps that do not exist never resolve, but they
+			// have always been allowed, so we only check the outputs of steps
+			// we know about.
+			outputs, found := declared[id.From]
+			if !found || len(outputs) == 0 {
+				continue
+			}
+
+			if _, found := outputs[id.Name]; !found {
+				verr.Causes = append(verr.Causes, &UndeclaredOutputReferenceError{StepName: step.Name, Output: id})
+			}
+		}
+	}
+
+	if len(verr.Causes) > 0 {
+		return verr
+	}
+
+	return nil
+}
+
+func referencedOutputs(ctx context.Context, step *relayv1beta1.Step) []spec.OutputID {
+	var trees []interface{}
+	if len(step.Spec) > 0 {
+		trees = append(trees, step.Spec.Value())
+	}
+	if len(step.Env) > 0 {
+		trees = append(trees, step.Env.Value())
+	}
+	if step.When != nil {
+		trees = append(trees, step.When.Value())
+	}
+
+	seen := make(map[spec.OutputID]struct{})
+
+	for _, tree := range trees {
+		// Expressions that cannot be evaluated statically are reported when
+		// the step runs, so we only look at what we can resolve here.
+		r, err := evaluate.EvaluateAll(ctx, spec.NewEvaluator(), tree)
+		if err != nil || r.References == nil || r.References.Outputs == nil {
+			continue
+		}
+
+		for _, ref := range r.References.Outputs.AllReferences() {
+			seen[ref.ID()] = struct{}{}
+		}
+	}
+
+	ids := make([]spec.OutputID, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].Less(ids[j])
+	})
+
+	return ids
+}
+
+// WorkflowValidationError collects all of the problems found when validating a
+// workflow.
+type WorkflowValidationError struct {
+	Causes []error
+}
+
+func (e *WorkflowValidationError) Error() string {
+	msgs := make([]string, len(e.Causes))
+	for i, cause := range e.Causes {
+		msgs[i] = cause.Error()
+	}
+
+	return fmt.Sprintf("workflow is invalid: %s", strings.Join(msgs, "; "))
+}
@@ -0,0 +1,119 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	relayv1beta1 "github.com/puppetlabs/relay-core/pkg/apis/relay.sh/v1beta1"
+	"github.com/puppetlabs/relay-core/pkg/spec"
+	"github.com/puppetlabs/relay-core/pkg/workflow/validation"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateWorkflow(t *testing.T) {
+	ctx := context.Background()
+
+	outputRef := func(from, name string) map[string]interface{} {
+		return map[string]interface{}{
+			"$type": "Output",
+			"from":  from,
+			"name":  name,
+		}
+	}
+
+	newWorkflow := func(spec map[string]interface{}) *relayv1beta1.Workflow {
+		return &relayv1beta1.Workflow{
+			Spec: relayv1beta1.WorkflowSpec{
+				Steps: []*relayv1beta1.Step{
+					{
+						Name: "declared",
+						Outputs: []*relayv1beta1.StepOutputDeclaration{
+							{Name: "url"},
+						},
+					},
+					{
+						Name: "undeclared",
+					},
+					{
+						Name: "consumer",
+						Container: relayv1beta1.Container{
+							Spec: relayv1beta1.NewUnstructuredObject(spec),
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		require.NoError(t, validation.ValidateWorkflow(ctx, newWorkflow(map[string]interface{}{
+			"url":   outputRef("declared", "url"),
+			"other": outputRef("undeclared", "anything"),
+		})))
+	})
+
+	t.Run("UndeclaredOutput", func(t *testing.T) {
+		err := validation.ValidateWorkflow(ctx, newWorkflow(map[string]interface{}{
+			"url": outputRef("declared", "uri"),
+		}))
+
+		var verr *validation.WorkflowValidationError
+		require.True(t, errors.As(err, &verr))
+		require.Equal(t, []error{
+			&validation.UndeclaredOutputReferenceError{
+				StepName: "consumer",
+				Output:   spec.OutputID{From: "declared", Name: "uri"},
+			},
+		}, verr.Causes)
+	})
+
+	t.Run("UnknownStep", func(t *testing.T) {
+		// References to steps that do not exist are not validation errors.
+		require.NoError(t, validation.ValidateWorkflow(ctx, newWorkflow(map[string]interface{}{
+			"url": outputRef("missing", "url"),
+		})))
+	})
+
+	t.Run("InvalidSchema", func(t *testing.T) {
+		schema := relayv1beta1.AsUnstructured(map[string]interface{}{
+			"type": 42,
+		})
+
+		wf := newWorkflow(nil)
+		wf.Spec.Steps[0].Outputs[0].Schema = &schema
+
+		err := validation.ValidateWorkflow(ctx, wf)
+
+		var verr *validation.WorkflowValidationError
+		require.True(t, errors.As(err, &verr))
+		require.Len(t, verr.Causes, 1)
+
+		var serr *validation.InvalidStepOutputSchemaError
+		require.True(t, errors.As(verr.Causes[0], &serr))
+		require.Equal(t, "declared", serr.StepName)
+		require.Equal(t, "url", serr.Output)
+	})
+
+	t.Run("StepServiceAccount", func(t *testing.T) {
+		wf := newWorkflow(nil)
+		wf.Spec.Steps[0].ServiceAccountName = "deployer"
+
+		require.NoError(t, validation.ValidateWorkflow(
+			ctx,
+			wf,
+			validation.ValidateWorkflowWithStepServiceAccountNames([]string{"deployer"}),
+		))
+
+		err := validation.ValidateWorkflow(ctx, wf)
+
+		var verr *validation.WorkflowValidationError
+		require.True(t, errors.As(err, &verr))
+		require.Equal(t, []error{
+			&validation.StepServiceAccountNotAllowedError{
+				StepName:           "declared",
+				ServiceAccountName: "deployer",
+			},
+		}, verr.Causes)
+	})
+}
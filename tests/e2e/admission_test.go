@@ -5,7 +5,7 @@ import (
 	"testing"
 	"time"
 
-	"github.com/puppetlabs/relay-core/pkg/operator/admission"
+	"github.com/puppetlabs/relay-core/pkg/operator/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -38,9 +38,10 @@ func TestPodEnforcementHandler(t *testing.T) {
 		}
 		require.NoError(t, eit.ControllerClient.Create(ctx, pod))
 
-		assert.Equal(t, admission.PodNodeSelector, pod.Spec.NodeSelector)
-		assert.Equal(t, admission.PodTolerations, pod.Spec.Tolerations)
-		assert.Equal(t, admission.PodDNSPolicy, pod.Spec.DNSPolicy)
-		assert.Equal(t, admission.PodDNSConfig, pod.Spec.DNSConfig)
+		platform := config.DefaultPlatformConfig()
+		assert.Equal(t, platform.Scheduling.NodeSelector, pod.Spec.NodeSelector)
+		assert.Equal(t, platform.Scheduling.Tolerations, pod.Spec.Tolerations)
+		assert.Equal(t, platform.DNS.Policy, pod.Spec.DNSPolicy)
+		assert.Equal(t, platform.DNS.Config, pod.Spec.DNSConfig)
 	})
 }